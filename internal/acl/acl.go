@@ -0,0 +1,129 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"fmt"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+// Resource identifies an object type which ACL policies control access to.
+type Resource string
+
+const (
+	ResourceRegion   Resource = "region"
+	ResourceMethod   Resource = "method"
+	ResourceRule     Resource = "rule"
+	ResourcePlan     Resource = "plan"
+	ResourceTopology Resource = "topology"
+	ResourceJob      Resource = "job"
+)
+
+// Resources is the list of all resources ACL policies control access to.
+var Resources = []Resource{
+	ResourceRegion,
+	ResourceMethod,
+	ResourceRule,
+	ResourcePlan,
+	ResourceTopology,
+	ResourceJob,
+}
+
+// ACL is the compiled access of a token and is used to authorize requests.
+type ACL struct {
+	management bool
+	access     map[Resource]string
+}
+
+// New compiles the access granted by the token and its policies. Policies are
+// merged so that the highest access wins, except deny, which always takes
+// precedence.
+func New(token *domain.ACLToken, policies []*domain.ACLPolicy) *ACL {
+	a := ACL{
+		management: token.IsManagement(),
+		access:     make(map[Resource]string),
+	}
+
+	if a.management {
+		return &a
+	}
+
+	for _, policy := range policies {
+		for resource, access := range map[Resource]string{
+			ResourceRegion:   policy.Region,
+			ResourceMethod:   policy.Method,
+			ResourceRule:     policy.Rule,
+			ResourcePlan:     policy.Plan,
+			ResourceTopology: policy.Topology,
+			ResourceJob:      policy.Job,
+		} {
+			a.access[resource] = mergeAccess(a.access[resource], access)
+		}
+	}
+
+	return &a
+}
+
+func mergeAccess(current, next string) string {
+	switch {
+	case current == domain.ACLAccessDeny, next == domain.ACLAccessDeny:
+		return domain.ACLAccessDeny
+	case current == domain.ACLAccessWrite, next == domain.ACLAccessWrite:
+		return domain.ACLAccessWrite
+	case current == domain.ACLAccessRead, next == domain.ACLAccessRead:
+		return domain.ACLAccessRead
+	default:
+		return ""
+	}
+}
+
+// IsManagement returns whether the ACL has full access, including to the ACL
+// system itself.
+func (a *ACL) IsManagement() bool { return a.management }
+
+// AllowRead returns whether the ACL allows the resource to be read.
+func (a *ACL) AllowRead(resource Resource) bool {
+	if a.management {
+		return true
+	}
+	switch a.access[resource] {
+	case domain.ACLAccessRead, domain.ACLAccessWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllowWrite returns whether the ACL allows the resource to be written.
+func (a *ACL) AllowWrite(resource Resource) bool {
+	return a.management || a.access[resource] == domain.ACLAccessWrite
+}
+
+// Resolve looks up the token identified by the secret ID along with its
+// policies and compiles them into an ACL. Policies which no longer exist are
+// ignored, as they may have been deleted after the token was created.
+func Resolve(state store.State, secretID string) (*ACL, *domain.ACLToken, *store.ErrorResp) {
+	tokenResp, errResp := state.ACL().Token().GetBySecret(&store.ACLTokenGetBySecretReq{SecretID: secretID})
+	if errResp != nil {
+		return nil, nil, errResp
+	}
+
+	policies := make([]*domain.ACLPolicy, 0, len(tokenResp.Token.Policies))
+
+	for _, name := range tokenResp.Token.Policies {
+		policyResp, errResp := state.ACL().Policy().Get(&store.ACLPolicyGetReq{Name: name})
+		if errResp != nil {
+			if errResp.StatusCode() == 404 {
+				continue
+			}
+			return nil, nil, store.NewErrorResp(
+				fmt.Errorf("failed to read acl policy %q: %w", name, errResp.Err()), errResp.StatusCode())
+		}
+		policies = append(policies, policyResp.Policy)
+	}
+
+	return New(tokenResp.Token, policies), tokenResp.Token, nil
+}
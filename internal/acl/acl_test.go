@@ -0,0 +1,106 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+func TestACL(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		inputToken    *domain.ACLToken
+		inputPolicies []*domain.ACLPolicy
+		expectedRead  map[Resource]bool
+		expectedWrite map[Resource]bool
+	}{
+		{
+			name:       "management",
+			inputToken: domain.NewACLToken("test", domain.ACLTokenTypeManagement, nil),
+			expectedRead: map[Resource]bool{
+				ResourceRegion: true, ResourceMethod: true, ResourceRule: true,
+				ResourcePlan: true, ResourceTopology: true, ResourceJob: true,
+			},
+			expectedWrite: map[Resource]bool{
+				ResourceRegion: true, ResourceMethod: true, ResourceRule: true,
+				ResourcePlan: true, ResourceTopology: true, ResourceJob: true,
+			},
+		},
+		{
+			name:       "single policy",
+			inputToken: domain.NewACLToken("test", domain.ACLTokenTypeClient, []string{"a"}),
+			inputPolicies: []*domain.ACLPolicy{
+				{Name: "a", Region: domain.ACLAccessRead, Plan: domain.ACLAccessWrite},
+			},
+			expectedRead:  map[Resource]bool{ResourceRegion: true, ResourcePlan: true},
+			expectedWrite: map[Resource]bool{ResourcePlan: true},
+		},
+		{
+			name:       "highest access wins",
+			inputToken: domain.NewACLToken("test", domain.ACLTokenTypeClient, []string{"a", "b"}),
+			inputPolicies: []*domain.ACLPolicy{
+				{Name: "a", Region: domain.ACLAccessRead, Rule: domain.ACLAccessWrite},
+				{Name: "b", Region: domain.ACLAccessWrite, Rule: domain.ACLAccessRead},
+			},
+			expectedRead:  map[Resource]bool{ResourceRegion: true, ResourceRule: true},
+			expectedWrite: map[Resource]bool{ResourceRegion: true, ResourceRule: true},
+		},
+		{
+			name:       "deny takes precedence",
+			inputToken: domain.NewACLToken("test", domain.ACLTokenTypeClient, []string{"a", "b"}),
+			inputPolicies: []*domain.ACLPolicy{
+				{Name: "a", Region: domain.ACLAccessWrite, Topology: domain.ACLAccessRead},
+				{Name: "b", Region: domain.ACLAccessDeny},
+			},
+			expectedRead:  map[Resource]bool{ResourceTopology: true},
+			expectedWrite: map[Resource]bool{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aclObj := New(tc.inputToken, tc.inputPolicies)
+			must.Eq(t, tc.inputToken.IsManagement(), aclObj.IsManagement())
+
+			for _, resource := range Resources {
+				must.Eq(t, tc.expectedRead[resource], aclObj.AllowRead(resource), must.Sprint(resource))
+				must.Eq(t, tc.expectedWrite[resource], aclObj.AllowWrite(resource), must.Sprint(resource))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	testState, err := mem.New()
+	must.NoError(t, err)
+
+	_, errResp := testState.ACL().Policy().Create(&store.ACLPolicyCreateReq{
+		Policy: &domain.ACLPolicy{Name: "region-read", Region: domain.ACLAccessRead},
+	})
+	must.Nil(t, errResp)
+
+	// The token links a policy which does not exist, which should be ignored.
+	token := domain.NewACLToken("test", domain.ACLTokenTypeClient, []string{"region-read"})
+	token.Policies = append(token.Policies, "deleted")
+
+	_, errResp = testState.ACL().Token().Bootstrap(&store.ACLTokenBootstrapReq{Token: token})
+	must.Nil(t, errResp)
+
+	aclObj, resolvedToken, errResp := Resolve(testState, token.SecretID)
+	must.Nil(t, errResp)
+	must.Eq(t, token.AccessorID, resolvedToken.AccessorID)
+	must.True(t, aclObj.AllowRead(ResourceRegion))
+	must.False(t, aclObj.AllowWrite(ResourceRegion))
+
+	_, _, errResp = Resolve(testState, "unknown")
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+}
@@ -0,0 +1,25 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/acl/policy"
+	"github.com/rasorp/attila/internal/cmd/acl/token"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "acl",
+		Usage:           "Bootstrap and administer Attila ACL policies and tokens",
+		HideHelpCommand: true,
+		UsageText:       "attila acl <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			bootstrapCommand(),
+			policy.Command(),
+			token.Command(),
+		},
+	}
+}
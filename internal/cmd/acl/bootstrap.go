@@ -0,0 +1,37 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package acl
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/acl/token"
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func bootstrapCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "bootstrap",
+		Usage:     "Bootstrap the Attila ACL system and create the initial management token",
+		Category:  "acl",
+		Args:      false,
+		UsageText: "attila acl bootstrap [options]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			bootstrapResp, _, err := client.ACL().Bootstrap(context.Background())
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to bootstrap Attila ACL system", err), 1)
+			}
+
+			token.OutputToken(cliCtx, bootstrapResp.Token)
+			return nil
+		},
+	}
+}
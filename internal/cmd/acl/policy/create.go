@@ -0,0 +1,56 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	createCLIErrorMsg = "failed to create Attila ACL policy"
+)
+
+func createCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "create",
+		Usage:     "Create an Attila ACL policy",
+		Category:  "policy",
+		Args:      true,
+		UsageText: "attila acl policy create [options] [policy-spec]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					createCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			var policy api.ACLPolicy
+
+			if err := file.ParseConfig(cliCtx.Args().First(), &policy); err != nil {
+				return cli.Exit(helper.FormatError(createCLIErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			policyCreateResp, _, err := client.ACLPolicies().Create(context.Background(), &policy)
+			if err != nil {
+				return cli.Exit(helper.FormatError(createCLIErrorMsg, err), 1)
+			}
+
+			outputPolicy(cliCtx, policyCreateResp.Policy)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func deleteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "delete",
+		Usage:     "Delete an Attila ACL policy",
+		Category:  "policy",
+		Args:      true,
+		UsageText: "attila acl policy delete [options] [policy-name]",
+		Flags:     helper.WriteFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					"failed to delete Attila ACL policy",
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			_, err := client.ACLPolicies().Delete(context.Background(), cliCtx.Args().First(), helper.WriteOptionsFromFlags(cliCtx)...)
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to delete Attila ACL policy", err), 1)
+			}
+
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "successfully deleted Attila ACL policy %q", cliCtx.Args().First())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "list",
+		Usage:     "List Attila ACL policies",
+		Category:  "policy",
+		Args:      false,
+		UsageText: "attila acl policy list [options]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			policies, _, err := client.ACLPolicies().List(context.Background())
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to list Attila ACL policies", err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, formatPolicyList(policies.Policies))
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+
+			return nil
+		},
+	}
+}
+
+func formatPolicyList(policies []*api.ACLPolicyStub) string {
+	if len(policies) == 0 {
+		return "No Attila ACL Policies found"
+	}
+
+	out := make([]string, 0, len(policies)+1)
+	out = append(out, "Name|Description")
+	for _, policy := range policies {
+		out = append(out, fmt.Sprintf("%s|%s", policy.Name, policy.Description))
+	}
+
+	return helper.FormatList(out)
+}
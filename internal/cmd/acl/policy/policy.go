@@ -0,0 +1,47 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "policy",
+		Category:        "acl",
+		Usage:           "Administer Attila ACL policies",
+		HideHelpCommand: true,
+		UsageText:       "attila acl policy <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			createCommand(),
+			deleteCommand(),
+			getCommand(),
+			listCommand(),
+			updateCommand(),
+		},
+	}
+}
+
+func outputPolicy(cliCtx *cli.Context, p *api.ACLPolicy) {
+	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV([]string{
+		fmt.Sprintf("Name|%s", p.Name),
+		fmt.Sprintf("Description|%s", p.Description),
+		fmt.Sprintf("Region|%s", p.Region),
+		fmt.Sprintf("Method|%s", p.Method),
+		fmt.Sprintf("Rule|%s", p.Rule),
+		fmt.Sprintf("Plan|%s", p.Plan),
+		fmt.Sprintf("Topology|%s", p.Topology),
+		fmt.Sprintf("Job|%s", p.Job),
+		fmt.Sprintf("Create Time|%s", helper.FormatTime(p.Metadata.CreateTime)),
+		fmt.Sprintf("Update Time|%s", helper.FormatTime(p.Metadata.UpdateTime)),
+		fmt.Sprintf("Modify Index|%v", p.Metadata.ModifyIndex),
+	}))
+	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+}
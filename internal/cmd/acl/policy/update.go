@@ -0,0 +1,57 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	updateCLIErrorMsg = "failed to update Attila ACL policy"
+)
+
+func updateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update an Attila ACL policy",
+		Category:  "policy",
+		Args:      true,
+		UsageText: "attila acl policy update [options] [policy-spec]",
+		Flags:     helper.WriteFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					updateCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			var policy api.ACLPolicy
+
+			if err := file.ParseConfig(cliCtx.Args().First(), &policy); err != nil {
+				return cli.Exit(helper.FormatError(updateCLIErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			policyUpdateResp, _, err := client.ACLPolicies().Update(
+				context.Background(), &policy, helper.WriteOptionsFromFlags(cliCtx)...)
+			if err != nil {
+				return cli.Exit(helper.FormatError(updateCLIErrorMsg, err), 1)
+			}
+
+			outputPolicy(cliCtx, policyUpdateResp.Policy)
+			return nil
+		},
+	}
+}
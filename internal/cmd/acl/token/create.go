@@ -0,0 +1,56 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func createCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "create",
+		Usage:     "Create an Attila ACL token",
+		Category:  "token",
+		Args:      false,
+		UsageText: "attila acl token create [options]",
+		Flags: append(helper.ClientFlags(),
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "A human friendly name for the token",
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Value: api.ACLTokenTypeClient,
+				Usage: "The type of token to create, either client or management",
+			},
+			&cli.StringSliceFlag{
+				Name:  "policy",
+				Usage: "The name of a policy to link to the token, can be specified multiple times",
+			},
+		),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			req := api.ACLTokenCreateReq{
+				Name:     cliCtx.String("name"),
+				Type:     cliCtx.String("type"),
+				Policies: cliCtx.StringSlice("policy"),
+			}
+
+			tokenCreateResp, _, err := client.ACLTokens().Create(context.Background(), &req)
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to create Attila ACL token", err), 1)
+			}
+
+			OutputToken(cliCtx, tokenCreateResp.Token)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func getCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Detail an Attila ACL token",
+		Category:  "token",
+		Args:      true,
+		UsageText: "attila acl token get [options] [accessor-id]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					"failed to get Attila ACL token",
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			tokenResp, _, err := client.ACLTokens().Get(context.Background(), cliCtx.Args().First())
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to get Attila ACL token", err), 1)
+			}
+
+			OutputToken(cliCtx, tokenResp.Token)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "list",
+		Usage:     "List Attila ACL tokens",
+		Category:  "token",
+		Args:      false,
+		UsageText: "attila acl token list [options]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			tokens, _, err := client.ACLTokens().List(context.Background())
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to list Attila ACL tokens", err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, formatTokenList(tokens.Tokens))
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+
+			return nil
+		},
+	}
+}
+
+func formatTokenList(tokens []*api.ACLTokenStub) string {
+	if len(tokens) == 0 {
+		return "No Attila ACL Tokens found"
+	}
+
+	out := make([]string, 0, len(tokens)+1)
+	out = append(out, "Accessor ID|Name|Type|Policies")
+	for _, token := range tokens {
+		out = append(out, fmt.Sprintf(
+			"%s|%s|%s|%s", token.AccessorID, token.Name, token.Type, strings.Join(token.Policies, ", ")))
+	}
+
+	return helper.FormatList(out)
+}
@@ -0,0 +1,36 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func selfCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "self",
+		Usage:     "Detail the Attila ACL token used to make the request",
+		Category:  "token",
+		Args:      false,
+		UsageText: "attila acl token self [options]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			tokenResp, _, err := client.ACLTokens().Self(context.Background())
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to get Attila ACL token", err), 1)
+			}
+
+			OutputToken(cliCtx, tokenResp.Token)
+			return nil
+		},
+	}
+}
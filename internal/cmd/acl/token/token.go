@@ -0,0 +1,46 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "token",
+		Category:        "acl",
+		Usage:           "Administer Attila ACL tokens",
+		HideHelpCommand: true,
+		UsageText:       "attila acl token <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			createCommand(),
+			deleteCommand(),
+			getCommand(),
+			listCommand(),
+			selfCommand(),
+		},
+	}
+}
+
+// OutputToken writes the token detail, including the secret ID, to the CLI
+// writer. It is exported, so the bootstrap command can use it.
+func OutputToken(cliCtx *cli.Context, t *api.ACLToken) {
+	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV([]string{
+		fmt.Sprintf("Accessor ID|%s", t.AccessorID),
+		fmt.Sprintf("Secret ID|%s", t.SecretID),
+		fmt.Sprintf("Name|%s", t.Name),
+		fmt.Sprintf("Type|%s", t.Type),
+		fmt.Sprintf("Policies|%s", strings.Join(t.Policies, ", ")),
+		fmt.Sprintf("Create Time|%s", helper.FormatTime(t.Metadata.CreateTime)),
+		fmt.Sprintf("Modify Index|%v", t.Metadata.ModifyIndex),
+	}))
+	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+}
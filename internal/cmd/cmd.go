@@ -9,8 +9,10 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/rasorp/attila/internal/cmd/acl"
 	"github.com/rasorp/attila/internal/cmd/helper"
 	"github.com/rasorp/attila/internal/cmd/job"
+	"github.com/rasorp/attila/internal/cmd/operator"
 	"github.com/rasorp/attila/internal/cmd/region"
 	"github.com/rasorp/attila/internal/cmd/server"
 	"github.com/rasorp/attila/internal/cmd/topology"
@@ -30,7 +32,9 @@ func main() {
 
 	cliApp := cli.App{
 		Commands: []*cli.Command{
+			acl.Command(),
 			job.Command(),
+			operator.Command(),
 			region.Command(),
 			server.Command(),
 			topology.Command(),
@@ -0,0 +1,25 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Confirm writes the question and reads a single line answer. Only "yes" is
+// accepted as confirmation, matching the Nomad and Terraform CLIs, so a stray
+// key press cannot approve a change.
+func Confirm(r io.Reader, w io.Writer, question string) (bool, error) {
+	_, _ = fmt.Fprintf(w, "%s Only 'yes' will be accepted to approve.\n\nEnter a value: ", question)
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	return strings.TrimSpace(answer) == "yes", nil
+}
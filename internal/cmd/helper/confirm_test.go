@@ -0,0 +1,52 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestConfirm(t *testing.T) {
+	testCases := []struct {
+		name           string
+		inputAnswer    string
+		expectedResult bool
+	}{
+		{
+			name:           "yes",
+			inputAnswer:    "yes\n",
+			expectedResult: true,
+		},
+		{
+			name:           "yes without newline",
+			inputAnswer:    "  yes",
+			expectedResult: true,
+		},
+		{
+			name:           "y",
+			inputAnswer:    "y\n",
+			expectedResult: false,
+		},
+		{
+			name:           "empty",
+			inputAnswer:    "",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			actualResult, err := Confirm(strings.NewReader(tc.inputAnswer), &out, "Run the plan?")
+			must.NoError(t, err)
+			must.Eq(t, tc.expectedResult, actualResult)
+			must.StrContains(t, out.String(), "Run the plan?")
+		})
+	}
+}
@@ -10,7 +10,12 @@ import (
 )
 
 const (
-	addressCLIFlag = "address"
+	addressCLIFlag    = "address"
+	caCertCLIFlag     = "ca-cert"
+	checkIndexCLIFlag = "check-index"
+	clientCertCLIFlag = "client-cert"
+	clientKeyCLIFlag  = "client-key"
+	tokenCLIFlag      = "token"
 )
 
 func ClientFlags() []cli.Flag {
@@ -21,6 +26,27 @@ func ClientFlags() []cli.Flag {
 			Value:   "http://127.0.0.1:8080",
 			Usage:   "Attila server address to make API requests to",
 		},
+		&cli.StringFlag{
+			Aliases: []string{"t"},
+			Name:    tokenCLIFlag,
+			EnvVars: []string{"ATTILA_TOKEN"},
+			Usage:   "ACL token secret ID to authenticate API requests with",
+		},
+		&cli.StringFlag{
+			Name:    caCertCLIFlag,
+			EnvVars: []string{"ATTILA_CACERT"},
+			Usage:   "Path to a CA certificate used to verify the Attila server certificate",
+		},
+		&cli.StringFlag{
+			Name:    clientCertCLIFlag,
+			EnvVars: []string{"ATTILA_CLIENT_CERT"},
+			Usage:   "Path to a client certificate presented to the Attila server",
+		},
+		&cli.StringFlag{
+			Name:    clientKeyCLIFlag,
+			EnvVars: []string{"ATTILA_CLIENT_KEY"},
+			Usage:   "Path to the private key of the client certificate",
+		},
 	}
 }
 
@@ -31,6 +57,40 @@ func ClientConfigFromFlags(ctx *cli.Context) *api.Config {
 	if addr := ctx.String(addressCLIFlag); addr != "" {
 		defaultConfig.Address = addr
 	}
+	if token := ctx.String(tokenCLIFlag); token != "" {
+		defaultConfig.Token = token
+	}
+
+	tlsConfig := api.TLSConfig{
+		CACert:     ctx.String(caCertCLIFlag),
+		ClientCert: ctx.String(clientCertCLIFlag),
+		ClientKey:  ctx.String(clientKeyCLIFlag),
+	}
+	if tlsConfig != (api.TLSConfig{}) {
+		defaultConfig.TLS = &tlsConfig
+	}
 
 	return defaultConfig
 }
+
+// WriteFlags returns the client flags along with the flags shared by commands
+// which modify or delete existing objects.
+func WriteFlags() []cli.Flag {
+	return append(ClientFlags(), &cli.Uint64Flag{
+		Name:  checkIndexCLIFlag,
+		Usage: "Only perform the write if the object modify index matches this value",
+	})
+}
+
+// WriteOptionsFromFlags returns the API request options configured by the
+// flags from WriteFlags.
+func WriteOptionsFromFlags(ctx *cli.Context) []api.RequestOption {
+
+	var opts []api.RequestOption
+
+	if ctx.IsSet(checkIndexCLIFlag) {
+		opts = append(opts, api.WithCheckIndex(ctx.Uint64(checkIndexCLIFlag)))
+	}
+
+	return opts
+}
@@ -0,0 +1,78 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const driftCLIErrorMsg = "failed to list job drift"
+
+func driftCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "drift",
+		Usage:     "List the drift of jobs from their last registration run",
+		Args:      false,
+		UsageText: "attila job drift [options]",
+		Flags: append(helper.ClientFlags(),
+			&cli.StringFlag{
+				Name:  "job-id",
+				Usage: "Only list the drift of the Nomad job with this ID",
+			},
+			&cli.StringFlag{
+				Name:  "namespace",
+				Usage: "Only list the drift of Nomad jobs within this namespace",
+			},
+		),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			listResp, _, err := client.Jobs().Drift(context.Background(), &api.JobDriftListReq{
+				JobID:     cliCtx.String("job-id"),
+				Namespace: cliCtx.String("namespace"),
+			})
+			if err != nil {
+				return cli.Exit(helper.FormatError(driftCLIErrorMsg, err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, formatDriftList(listResp.Drifts))
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+			return nil
+		},
+	}
+}
+
+func formatDriftList(drifts []*api.JobDrift) string {
+	if len(drifts) == 0 {
+		return "No job drift checks found"
+	}
+
+	out := make([]string, 0, len(drifts)+1)
+	out = append(out, "Job|Namespace|Region|Status|Job Version|Drift Time|Reason")
+
+	for _, drift := range drifts {
+
+		var jobVersion string
+		if drift.JobVersion != nil {
+			jobVersion = fmt.Sprint(*drift.JobVersion)
+		}
+
+		var driftTime string
+		if !drift.DriftTime.IsZero() {
+			driftTime = helper.FormatTime(drift.DriftTime)
+		}
+
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			drift.JobID, drift.JobNamespace, drift.Region, drift.Status, jobVersion, driftTime, drift.Reason))
+	}
+
+	return helper.FormatList(out)
+}
@@ -0,0 +1,42 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Test_formatDriftList(t *testing.T) {
+	must.Eq(t, "No job drift checks found", formatDriftList(nil))
+
+	driftTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	output := formatDriftList([]*api.JobDrift{
+		{
+			JobID:        "example",
+			JobNamespace: "platform",
+			Region:       "euw1",
+			Status:       api.JobDriftStatusInSync,
+			JobVersion:   new(uint64(2)),
+		},
+		{
+			JobID:        "example",
+			JobNamespace: "platform",
+			Region:       "euw2",
+			Status:       api.JobDriftStatusDrifted,
+			JobVersion:   new(uint64(2)),
+			DriftTime:    driftTime,
+			Reason:       "job spec changed",
+		},
+	})
+
+	must.Eq(t, "Job      Namespace  Region  Status   Job Version  Drift Time            Reason\n"+
+		"example  platform   euw1    in_sync  2            <none>                <none>\n"+
+		"example  platform   euw2    drifted  2            2026-01-02T03:04:05Z  job spec changed", output)
+}
@@ -16,7 +16,11 @@ func Command() *cli.Command {
 		HideHelpCommand: true,
 		UsageText:       "attila job <command> [options] [args]",
 		Subcommands: []*cli.Command{
+			driftCommand(),
 			register.Command(),
+			runCommand(),
+			statusCommand(),
+			stopCommand(),
 		},
 	}
 }
@@ -20,7 +20,7 @@ func deleteCommand() *cli.Command {
 		Category:  "method",
 		Args:      true,
 		UsageText: "attila job register method delete [options] [method-name]",
-		Flags:     helper.ClientFlags(),
+		Flags:     helper.WriteFlags(),
 		Action: func(cliCtx *cli.Context) error {
 
 			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
@@ -31,7 +31,7 @@ func deleteCommand() *cli.Command {
 
 			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
 
-			_, err := client.JobRegisterMethods().Delete(context.Background(), cliCtx.Args().First())
+			_, err := client.JobRegisterMethods().Delete(context.Background(), cliCtx.Args().First(), helper.WriteOptionsFromFlags(cliCtx)...)
 			if err != nil {
 				return cli.Exit(helper.FormatError("failed to delete Attila job registration method", err), 1)
 			}
@@ -24,6 +24,7 @@ func Command() *cli.Command {
 			deleteCommand(),
 			getCommand(),
 			listCommand(),
+			updateCommand(),
 		},
 	}
 }
@@ -33,6 +34,7 @@ func outputMethod(cliCtx *cli.Context, m *api.JobRegisterMethod) {
 		fmt.Sprintf("Name|%s", m.Name),
 		fmt.Sprintf("Create Time|%s", helper.FormatTime(m.Metadata.CreateTime)),
 		fmt.Sprintf("Update Time|%s", helper.FormatTime(m.Metadata.UpdateTime)),
+		fmt.Sprintf("Modify Index|%v", m.Metadata.ModifyIndex),
 	}))
 	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n\n")
 
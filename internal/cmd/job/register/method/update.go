@@ -0,0 +1,52 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package method
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	updateErrorMsg = "failed to update Attila job registration method"
+)
+
+func updateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update an Attila job registration method",
+		Category:  "method",
+		Args:      true,
+		UsageText: "attila job register method update [options] [method-spec]",
+		Flags:     helper.WriteFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(updateErrorMsg, fmt.Errorf("expected 1 argument, got %v", numArgs)), 1)
+			}
+
+			var methodObj api.JobRegisterMethod
+
+			if err := file.ParseConfig(cliCtx.Args().First(), &methodObj); err != nil {
+				return cli.Exit(helper.FormatError(updateErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			methodUpdateResp, _, err := client.JobRegisterMethods().Update(context.Background(), &methodObj, helper.WriteOptionsFromFlags(cliCtx)...)
+			if err != nil {
+				return cli.Exit(helper.FormatError(updateErrorMsg, err), 1)
+			}
+
+			outputMethod(cliCtx, methodUpdateResp.Method)
+			return nil
+		},
+	}
+}
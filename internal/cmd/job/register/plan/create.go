@@ -6,9 +6,7 @@ package plan
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/urfave/cli/v2"
 
 	"github.com/rasorp/attila/internal/cmd/helper"
@@ -22,7 +20,7 @@ func createCommand() *cli.Command {
 		Category:  "plan",
 		Args:      true,
 		UsageText: "attila job register plan create [options] [job-spec]",
-		Flags:     append(helper.ClientFlags(), createFlags()...),
+		Flags:     append(helper.ClientFlags(), JobspecFlags()...),
 		Action: func(cliCtx *cli.Context) error {
 
 			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
@@ -33,60 +31,22 @@ func createCommand() *cli.Command {
 				)
 			}
 
-			jobfile := cliCtx.Args().First()
-
-			jobsepcBytes, err := os.ReadFile(jobfile)
-			if err != nil {
-				return cli.Exit(helper.FormatError(
-					createCLIErrorMsg,
-					fmt.Errorf("failed to read jobspec file: %w", err)),
-					1,
-				)
-			}
-
-			jobspecParseConfig := jobspec2.ParseConfig{
-				Path:     jobfile,
-				Body:     jobsepcBytes,
-				ArgVars:  cliCtx.StringSlice("jobspec-var"),
-				VarFiles: cliCtx.StringSlice("jobspec-var-file"),
-				Strict:   true,
-			}
-
-			parsedJobspec, err := jobspec2.ParseWithConfig(&jobspecParseConfig)
+			parsedJobspec, submission, err := ParseJobspec(cliCtx, cliCtx.Args().First())
 			if err != nil {
-				return cli.Exit(helper.FormatError(
-					createCLIErrorMsg,
-					fmt.Errorf("failed to parse jobspec: %w", err)),
-					1,
-				)
+				return cli.Exit(helper.FormatError(createCLIErrorMsg, err), 1)
 			}
 
 			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
 
-			req := api.JobRegisterPlanCreateReq{Job: parsedJobspec}
+			req := api.JobRegisterPlanCreateReq{Job: parsedJobspec, Submission: submission}
 
 			resp, _, err := client.JobRegisterPlans().Create(context.Background(), &req)
 			if err != nil {
 				return cli.Exit(helper.FormatError(createCLIErrorMsg, err), 1)
 			}
 
-			outputPlan(cliCtx, resp.Plan)
+			OutputPlan(cliCtx, resp.Plan)
 			return nil
 		},
 	}
 }
-
-func createFlags() []cli.Flag {
-	return []cli.Flag{
-		&cli.StringSliceFlag{
-			Name:  "jobspec-var-file",
-			Value: cli.NewStringSlice(),
-			Usage: "The path to a HCL2 file containing user variables",
-		},
-		&cli.StringSliceFlag{
-			Name:  "jobspec-var",
-			Value: cli.NewStringSlice(),
-			Usage: "A HCL2 user variable",
-		},
-	}
-}
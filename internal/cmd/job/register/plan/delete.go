@@ -21,7 +21,7 @@ func deleteCommand() *cli.Command {
 		Category:  "plan",
 		Args:      true,
 		UsageText: "attila job register plan delete [options] [plan-id]",
-		Flags:     helper.ClientFlags(),
+		Flags:     helper.WriteFlags(),
 		Action: func(cliCtx *cli.Context) error {
 
 			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
@@ -41,7 +41,7 @@ func deleteCommand() *cli.Command {
 
 			deleteReq := api.JobRegisterPlanDeleteReq{ID: id}
 
-			_, err = client.JobRegisterPlans().Delete(context.Background(), &deleteReq)
+			_, err = client.JobRegisterPlans().Delete(context.Background(), &deleteReq, helper.WriteOptionsFromFlags(cliCtx)...)
 			if err != nil {
 				return cli.Exit(helper.FormatError(deleteCLIErrorMsg, err), 1)
 			}
@@ -0,0 +1,135 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	nomadAPI "github.com/hashicorp/nomad/api"
+)
+
+// The Nomad job diff types which are rendered. Objects and fields with the
+// "None" type are unchanged and are not rendered.
+const (
+	diffTypeAdded   = "Added"
+	diffTypeDeleted = "Deleted"
+	diffTypeEdited  = "Edited"
+)
+
+// formatJobDiff renders the Nomad job diff in the same structure as the Nomad
+// CLI plan output. Each changed job, task group, task, object, and field is
+// prefixed with a coloured marker showing whether it was added (+), deleted
+// (-), or edited (+/-), and is indented beneath its parent.
+func formatJobDiff(diff *nomadAPI.JobDiff) string {
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%sJob: %q\n", diffMarker(diff.Type), diff.ID)
+	writeFieldDiffs(&b, diff.Fields, 1)
+	writeObjectDiffs(&b, diff.Objects, 1)
+
+	for _, tg := range diff.TaskGroups {
+		if !diffChanged(tg.Type) {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&b, "%s%sTask Group: %q%s\n",
+			diffIndent(1), diffMarker(tg.Type), tg.Name, formatTaskGroupUpdates(tg.Updates))
+		writeFieldDiffs(&b, tg.Fields, 2)
+		writeObjectDiffs(&b, tg.Objects, 2)
+
+		for _, task := range tg.Tasks {
+			if !diffChanged(task.Type) {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(&b, "%s%sTask: %q%s\n",
+				diffIndent(2), diffMarker(task.Type), task.Name, formatAnnotations(task.Annotations))
+			writeFieldDiffs(&b, task.Fields, 3)
+			writeObjectDiffs(&b, task.Objects, 3)
+		}
+	}
+
+	return b.String()
+}
+
+func writeFieldDiffs(b *strings.Builder, fields []*nomadAPI.FieldDiff, depth int) {
+	for _, field := range fields {
+
+		var value string
+
+		switch field.Type {
+		case diffTypeAdded:
+			value = fmt.Sprintf("%q", field.New)
+		case diffTypeDeleted:
+			value = fmt.Sprintf("%q", field.Old)
+		case diffTypeEdited:
+			value = fmt.Sprintf("%q => %q", field.Old, field.New)
+		default:
+			continue
+		}
+
+		_, _ = fmt.Fprintf(b, "%s%s%s: %s%s\n",
+			diffIndent(depth), diffMarker(field.Type), field.Name, value, formatAnnotations(field.Annotations))
+	}
+}
+
+func writeObjectDiffs(b *strings.Builder, objects []*nomadAPI.ObjectDiff, depth int) {
+	for _, object := range objects {
+		if !diffChanged(object.Type) {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(b, "%s%s%s {\n", diffIndent(depth), diffMarker(object.Type), object.Name)
+		writeFieldDiffs(b, object.Fields, depth+1)
+		writeObjectDiffs(b, object.Objects, depth+1)
+		_, _ = fmt.Fprintf(b, "%s}\n", diffIndent(depth))
+	}
+}
+
+// diffMarker returns the coloured marker, including the trailing space, for
+// the diff type. Unchanged types have no marker.
+func diffMarker(diffType string) string {
+	switch diffType {
+	case diffTypeAdded:
+		return color.GreenString("+") + " "
+	case diffTypeDeleted:
+		return color.RedString("-") + " "
+	case diffTypeEdited:
+		return color.YellowString("+/-") + " "
+	default:
+		return ""
+	}
+}
+
+func diffChanged(diffType string) bool {
+	return diffType == diffTypeAdded || diffType == diffTypeDeleted || diffType == diffTypeEdited
+}
+
+func diffIndent(depth int) string { return strings.Repeat("  ", depth) }
+
+// formatTaskGroupUpdates renders the scheduler updates of a task group, such as
+// "(1 create, 2 ignore)", ordered by the update type.
+func formatTaskGroupUpdates(updates map[string]uint64) string {
+	if len(updates) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(updates))
+	for _, updateType := range slices.Sorted(maps.Keys(updates)) {
+		parts = append(parts, fmt.Sprintf("%d %s", updates[updateType], updateType))
+	}
+
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+func formatAnnotations(annotations []string) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(annotations, ", ") + ")"
+}
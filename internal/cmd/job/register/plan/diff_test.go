@@ -0,0 +1,99 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	nomadAPI "github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func Test_formatJobDiff(t *testing.T) {
+
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	testCases := []struct {
+		name           string
+		inputDiff      *nomadAPI.JobDiff
+		expectedOutput string
+	}{
+		{
+			name:           "unchanged job",
+			inputDiff:      &nomadAPI.JobDiff{Type: "None", ID: "example"},
+			expectedOutput: "Job: \"example\"\n",
+		},
+		{
+			name: "edited job",
+			inputDiff: &nomadAPI.JobDiff{
+				Type: "Edited",
+				ID:   "example",
+				Fields: []*nomadAPI.FieldDiff{
+					{Type: "None", Name: "Type", Old: "service", New: "service"},
+					{Type: "Edited", Name: "Priority", Old: "50", New: "70"},
+				},
+				TaskGroups: []*nomadAPI.TaskGroupDiff{
+					{
+						Type:    "Edited",
+						Name:    "cache",
+						Updates: map[string]uint64{"ignore": 1, "create/destroy update": 2},
+						Fields: []*nomadAPI.FieldDiff{
+							{Type: "Edited", Name: "Count", Old: "2", New: "3"},
+						},
+						Tasks: []*nomadAPI.TaskDiff{
+							{
+								Type:        "Edited",
+								Name:        "redis",
+								Annotations: []string{"forces create/destroy update"},
+								Objects: []*nomadAPI.ObjectDiff{
+									{
+										Type: "Edited",
+										Name: "Config",
+										Fields: []*nomadAPI.FieldDiff{
+											{Type: "Added", Name: "command", New: "redis-server"},
+											{Type: "Deleted", Name: "image", Old: "redis:7"},
+										},
+									},
+								},
+							},
+							{Type: "None", Name: "sidecar"},
+						},
+					},
+					{Type: "None", Name: "web"},
+				},
+			},
+			expectedOutput: "+/- Job: \"example\"\n" +
+				"  +/- Priority: \"50\" => \"70\"\n" +
+				"  +/- Task Group: \"cache\" (2 create/destroy update, 1 ignore)\n" +
+				"    +/- Count: \"2\" => \"3\"\n" +
+				"    +/- Task: \"redis\" (forces create/destroy update)\n" +
+				"      +/- Config {\n" +
+				"        + command: \"redis-server\"\n" +
+				"        - image: \"redis:7\"\n" +
+				"      }\n",
+		},
+		{
+			name: "added job",
+			inputDiff: &nomadAPI.JobDiff{
+				Type: "Added",
+				ID:   "example",
+				TaskGroups: []*nomadAPI.TaskGroupDiff{
+					{Type: "Added", Name: "cache", Tasks: []*nomadAPI.TaskDiff{{Type: "Added", Name: "redis"}}},
+				},
+			},
+			expectedOutput: "+ Job: \"example\"\n" +
+				"  + Task Group: \"cache\"\n" +
+				"    + Task: \"redis\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedOutput, formatJobDiff(tc.inputDiff))
+		})
+	}
+}
@@ -46,7 +46,7 @@ func getCommand() *cli.Command {
 				return cli.Exit(helper.FormatError(getCLIErrorMsg, err), 1)
 			}
 
-			outputPlan(cliCtx, getResp.Plan)
+			OutputPlan(cliCtx, getResp.Plan)
 			return nil
 		},
 	}
@@ -0,0 +1,86 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	nomadAPI "github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/jobspec2"
+	"github.com/urfave/cli/v2"
+)
+
+// jobspecSubmissionFormat is the format of the job source stored with plans,
+// as Attila only parses HCL2 jobspecs.
+const jobspecSubmissionFormat = "hcl2"
+
+// ParseJobspec reads and parses the jobspec file using the variables set by
+// the CLI flags. The submission contains the source and variables, so they
+// can be stored with the plan.
+func ParseJobspec(cliCtx *cli.Context, jobfile string) (*nomadAPI.Job, *nomadAPI.JobSubmission, error) {
+
+	jobspecBytes, err := os.ReadFile(jobfile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read jobspec file: %w", err)
+	}
+
+	jobspecParseConfig := jobspec2.ParseConfig{
+		Path:     jobfile,
+		Body:     jobspecBytes,
+		ArgVars:  cliCtx.StringSlice("jobspec-var"),
+		VarFiles: cliCtx.StringSlice("jobspec-var-file"),
+		Strict:   true,
+	}
+
+	parsedJobspec, err := jobspec2.ParseWithConfig(&jobspecParseConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse jobspec: %w", err)
+	}
+
+	submission := nomadAPI.JobSubmission{
+		Source: string(jobspecBytes),
+		Format: jobspecSubmissionFormat,
+	}
+
+	// The variables have already been validated by parsing the jobspec, so
+	// each flag is a key and value pair.
+	for _, argVar := range jobspecParseConfig.ArgVars {
+		if key, value, ok := strings.Cut(argVar, "="); ok {
+			if submission.VariableFlags == nil {
+				submission.VariableFlags = make(map[string]string)
+			}
+			submission.VariableFlags[key] = value
+		}
+	}
+
+	varFiles := make([]string, 0, len(jobspecParseConfig.VarFiles))
+	for _, varFile := range jobspecParseConfig.VarFiles {
+		varFileBytes, err := os.ReadFile(varFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read jobspec variable file: %w", err)
+		}
+		varFiles = append(varFiles, string(varFileBytes))
+	}
+	submission.Variables = strings.Join(varFiles, "\n")
+
+	return parsedJobspec, &submission, nil
+}
+
+// JobspecFlags returns the flags which set the variables used by ParseJobspec.
+func JobspecFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "jobspec-var-file",
+			Value: cli.NewStringSlice(),
+			Usage: "The path to a HCL2 file containing user variables",
+		},
+		&cli.StringSliceFlag{
+			Name:  "jobspec-var",
+			Value: cli.NewStringSlice(),
+			Usage: "A HCL2 user variable",
+		},
+	}
+}
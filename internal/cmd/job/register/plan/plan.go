@@ -5,6 +5,8 @@ package plan
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,18 +43,64 @@ func Command() *cli.Command {
 	}
 }
 
-func outputPlan(cliCtx *cli.Context, plan *api.JobRegisterPlan) {
-	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV([]string{
+// OutputPlan writes the plan, including the job diff and planned allocations of
+// each region.
+func OutputPlan(cliCtx *cli.Context, plan *api.JobRegisterPlan) {
+	regionNames := slices.Sorted(maps.Keys(plan.Regions))
+
+	// Flag the regions where running the plan would not change the job, so
+	// they can be spotted without reading each diff.
+	var unchanged []string
+	for _, regionName := range regionNames {
+		if plan.Regions[regionName].NoChange() {
+			unchanged = append(unchanged, regionName)
+		}
+	}
+
+	out := []string{
 		fmt.Sprintf("ID|%s", plan.ID),
 		fmt.Sprintf("Num Regions|%v", len(plan.Regions)),
 		fmt.Sprintf("Job ID|%s", plan.JobID),
 		fmt.Sprintf("Job Namespace|%s", plan.JobNamespace),
-	}))
+		fmt.Sprintf("Unchanged Regions|%s", formatRegionNames(unchanged)),
+	}
+	if !plan.ExpireTime.IsZero() {
+		out = append(out, fmt.Sprintf("Expire Time|%s", helper.FormatTime(plan.ExpireTime)))
+	}
+
+	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(out))
 	_, _ = fmt.Fprint(cliCtx.App.Writer, "\n")
 
-	for _, regionPlan := range plan.Regions {
-		outputPlannedJob(cliCtx, regionPlan)
+	for _, regionName := range regionNames {
+		outputJobDiff(cliCtx, plan.Regions[regionName])
+		outputPlannedJob(cliCtx, plan.Regions[regionName])
+	}
+}
+
+// outputJobDiff writes the diff between the planned job and the job registered
+// in the region. Plans created before diffs were requested do not include one,
+// so nothing is written.
+func outputJobDiff(cliCtx *cli.Context, regionPlan *api.JobRegisterRegionPlan) {
+	if regionPlan.Plan == nil || regionPlan.Plan.Diff == nil {
+		return
+	}
+
+	_, _ = fmt.Fprint(cliCtx.App.Writer, color.New(color.Bold).Sprintf(
+		"\nRegion %q Job Diff:\n", regionPlan.Region))
+
+	if regionPlan.NoChange() {
+		_, _ = fmt.Fprint(cliCtx.App.Writer, "No changes\n")
+		return
+	}
+
+	_, _ = fmt.Fprint(cliCtx.App.Writer, formatJobDiff(regionPlan.Plan.Diff))
+}
+
+func formatRegionNames(names []string) string {
+	if len(names) == 0 {
+		return "<none>"
 	}
+	return strings.Join(names, ", ")
 }
 
 func outputPlannedJob(cliCtx *cli.Context, regionPlan *api.JobRegisterRegionPlan) {
@@ -6,14 +6,14 @@ package plan
 import (
 	"context"
 	"fmt"
-	"os"
+	"time"
 
-	"github.com/fatih/color"
-	"github.com/hashicorp/nomad/jobspec2"
+	nomadAPI "github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 	"github.com/urfave/cli/v2"
 
 	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/cmd/job/register/run"
 	"github.com/rasorp/attila/pkg/api"
 )
 
@@ -29,9 +29,9 @@ func runCommand() *cli.Command {
 
 			cliArgs := cliCtx.Args()
 
-			if cliArgs.Len() != 2 {
+			if numArgs := cliArgs.Len(); numArgs < 1 || numArgs > 2 {
 				return cli.Exit(helper.FormatError(runCLIErrorMsg,
-					fmt.Errorf("expected 2 arguments, got %v", cliArgs.Len())), 1)
+					fmt.Errorf("expected 1 or 2 arguments, got %v", numArgs)), 1)
 			}
 
 			id, err := ulid.Parse(cliArgs.First())
@@ -39,84 +39,178 @@ func runCommand() *cli.Command {
 				return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
 			}
 
-			jobfile := cliArgs.Get(1)
+			// The jobspec is optional, as the server registers the job stored
+			// with the plan if the run does not include one. When it is
+			// included, the server checks it matches the planned job.
+			var parsedJobspec *nomadAPI.Job
 
-			jobsepcBytes, err := os.ReadFile(jobfile)
-			if err != nil {
-				return cli.Exit(helper.FormatError(runCLIErrorMsg,
-					fmt.Errorf("failed to read jobspec file: %w", err)), 1)
-			}
-
-			jobspecParseConfig := jobspec2.ParseConfig{
-				Path:     jobfile,
-				Body:     jobsepcBytes,
-				ArgVars:  cliCtx.StringSlice("jobspec-var"),
-				VarFiles: cliCtx.StringSlice("jobspec-var-file"),
-				Strict:   true,
-			}
-
-			parsedJobspec, err := jobspec2.ParseWithConfig(&jobspecParseConfig)
-			if err != nil {
-				return cli.Exit(helper.FormatError(runCLIErrorMsg,
-					fmt.Errorf("failed to parse jobspec: %w", err)), 1)
+			if cliArgs.Len() == 2 {
+				if parsedJobspec, _, err = ParseJobspec(cliCtx, cliArgs.Get(1)); err != nil {
+					return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
+				}
 			}
 
 			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
 
-			req := api.JobsRegisterPlanRunReq{ID: id, Job: parsedJobspec}
+			req := api.JobsRegisterPlanRunReq{
+				ID:      id,
+				Job:     parsedJobspec,
+				Rollout: RolloutFromFlags(cliCtx),
+			}
 
-			resp, _, err := client.JobRegisterPlans().Run(context.Background(), &req)
+			resp, err := RunPlan(cliCtx, client, &req)
 			if err != nil {
 				return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
 			}
 
-			outputPlanRun(cliCtx, resp)
+			run.OutputRun(cliCtx, resp.Run)
 			return nil
 		},
 	}
 }
 
 func runFlags() []cli.Flag {
+	return append(JobspecFlags(), RolloutFlags()...)
+}
+
+// RolloutFlags returns the flags which override the rollout of the plan when
+// it is run. The rollout is read from them using RolloutFromFlags.
+func RolloutFlags() []cli.Flag {
 	return []cli.Flag{
-		&cli.StringSliceFlag{
-			Name:  "jobspec-var-file",
-			Value: cli.NewStringSlice(),
-			Usage: "The path to a HCL2 file containing user variables",
+		&cli.StringFlag{
+			Name:  "rollout-strategy",
+			Usage: "Override the plan rollout strategy: all-at-once, serial, canary-then-rest, or batched",
+		},
+		&cli.IntFlag{
+			Name:  "rollout-canary-regions",
+			Usage: "The number of regions within the first stage of the canary-then-rest strategy",
+		},
+		&cli.IntFlag{
+			Name:  "rollout-batch-size",
+			Usage: "The number of regions within each stage of the batched strategy",
+		},
+		&cli.StringFlag{
+			Name:  "rollout-deployment-timeout",
+			Usage: "The maximum time each stage waits for its Nomad deployments to complete",
+		},
+		&cli.StringFlag{
+			Name:  "rollout-on-failure",
+			Usage: "What happens to updated regions when the run fails: none or rollback",
 		},
-		&cli.StringSliceFlag{
-			Name:  "jobspec-var",
-			Value: cli.NewStringSlice(),
-			Usage: "A HCL2 user variable",
+		&cli.BoolFlag{
+			Name:  "rollout-wait-for-healthy",
+			Usage: "Wait for the Nomad deployments of the final stage to succeed before the run completes",
 		},
 	}
 }
 
-func outputPlanRun(cliCtx *cli.Context, resp *api.JobsRegisterPlanRunResp) {
-	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV([]string{
-		fmt.Sprintf("ID|%s", resp.Run.ID),
-		fmt.Sprintf("Num Regions|%v", len(resp.Run.Regions)),
-		fmt.Sprintf("Job ID|%s", resp.Run.JobID),
-		fmt.Sprintf("Job Namespace|%s", resp.Run.JobNamespace),
-		fmt.Sprintf("Partial Error|%s", errorString(resp.PatrialFailureError)),
-	}))
-
-	for _, regionPlan := range resp.Run.Regions {
-		_, _ = fmt.Fprint(cliCtx.App.Writer, color.New(color.Bold).Sprintf(
-			"\n\nRegion %q Run:\n", regionPlan.Region))
-
-		_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV([]string{
-			fmt.Sprintf("Eval ID|%s", regionPlan.Run.EvalID),
-			fmt.Sprintf("Warnings|%s", regionPlan.Run.Warnings),
-			fmt.Sprintf("Error|%s", errorString(regionPlan.Error)),
-		}))
-
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+// RolloutFromFlags returns the rollout detailed by the CLI flags, or nil if
+// no strategy, failure mode, or health wait was set, so the rollout of the plan
+// is used.
+func RolloutFromFlags(cliCtx *cli.Context) *api.JobRegisterRollout {
+	if cliCtx.String("rollout-strategy") == "" &&
+		cliCtx.String("rollout-on-failure") == "" &&
+		!cliCtx.Bool("rollout-wait-for-healthy") {
+		return nil
+	}
+	return &api.JobRegisterRollout{
+		Strategy:          cliCtx.String("rollout-strategy"),
+		CanaryRegions:     cliCtx.Int("rollout-canary-regions"),
+		BatchSize:         cliCtx.Int("rollout-batch-size"),
+		DeploymentTimeout: cliCtx.String("rollout-deployment-timeout"),
+		OnFailure:         cliCtx.String("rollout-on-failure"),
+		WaitForHealthy:    cliCtx.Bool("rollout-wait-for-healthy"),
 	}
 }
 
-func errorString(e error) string {
-	if e != nil {
-		return e.Error()
+// RunPlan runs the plan, writing the progress of each region while the run
+// waits on Nomad. The progress is followed using the event stream, so it is
+// written even though the run response is only received once the run has
+// finished.
+func RunPlan(cliCtx *cli.Context, client *api.Client, req *api.JobsRegisterPlanRunReq) (*api.JobsRegisterPlanRunResp, error) {
+
+	streamCtx, streamCancel := context.WithCancel(context.Background())
+	defer streamCancel()
+
+	// The subscription must be established before the run is sent, otherwise
+	// the events of the first stage can be published before the server has
+	// subscribed and are never received.
+	msgCh := openRunProgress(streamCtx, cliCtx, client, req.ID)
+	progressDoneCh := writeRunProgress(cliCtx, msgCh)
+
+	resp, _, err := client.JobRegisterPlans().Run(context.Background(), req)
+
+	// Progress events can arrive after the run response, so give the stream a
+	// short time to deliver them before stopping it.
+	select {
+	case <-progressDoneCh:
+	case <-time.After(runProgressDrainTimeout):
+	}
+
+	return resp, err
+}
+
+// runProgressDrainTimeout is the maximum time to wait for the finished event
+// of a run once the run response has been received.
+const runProgressDrainTimeout = time.Second
+
+// openRunProgress subscribes to the events of the plan run, returning once the
+// server has established the subscription. The run does not depend on the
+// stream, so a nil channel is returned if it cannot be opened, such as when the
+// token is not allowed to read events.
+func openRunProgress(ctx context.Context, cliCtx *cli.Context, client *api.Client, id ulid.ULID) <-chan *api.EventStreamMsg {
+
+	msgCh, err := client.Events().Stream(ctx, &api.EventStreamReq{
+		Topics: map[string][]string{api.EventTopicJobRegisterRun: {id.String()}},
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(cliCtx.App.ErrWriter, "unable to stream run progress: %v\n", err)
+		return nil
+	}
+	return msgCh
+}
+
+// writeRunProgress writes each progress event of the plan run as it arrives.
+// The returned channel is closed once the run has finished, or the stream
+// ends.
+func writeRunProgress(cliCtx *cli.Context, msgCh <-chan *api.EventStreamMsg) <-chan struct{} {
+
+	doneCh := make(chan struct{})
+
+	if msgCh == nil {
+		close(doneCh)
+		return doneCh
+	}
+
+	go func() {
+		defer close(doneCh)
+
+		for msg := range msgCh {
+			if msg.Err != nil {
+				return
+			}
+
+			switch msg.Event.Type {
+			case api.EventTypeFinished:
+				return
+			case api.EventTypeProgress:
+				var progress api.JobRegisterRegionProgress
+				if err := msg.Event.DecodePayload(&progress); err == nil {
+					_, _ = fmt.Fprintln(cliCtx.App.Writer, formatRunProgress(msg.Event.Time, &progress))
+				}
+			}
+		}
+	}()
+
+	return doneCh
+}
+
+func formatRunProgress(t time.Time, progress *api.JobRegisterRegionProgress) string {
+	out := fmt.Sprintf("%s: region %q %s %s",
+		helper.FormatTime(t), progress.Region, progress.Phase, progress.Status)
+
+	if progress.Error != "" {
+		out += ": " + progress.Error
 	}
-	return ""
+	return out
 }
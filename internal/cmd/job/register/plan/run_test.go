@@ -0,0 +1,133 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func TestRunPlan(t *testing.T) {
+
+	eventTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	progress, err := json.Marshal(api.JobRegisterRegionProgress{
+		Region: "euw1",
+		Phase:  api.JobRegisterProgressPhaseRegister,
+		Status: "complete",
+	})
+	must.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		inputStreamStatus int
+		expectedCalls     []string
+		expectedOutput    string
+		expectedErrOutput string
+	}{
+		{
+			name:              "progress written",
+			inputStreamStatus: http.StatusOK,
+			expectedCalls:     []string{"subscribe", "run"},
+			expectedOutput:    helper.FormatTime(eventTime) + `: region "euw1" register complete` + "\n",
+		},
+		{
+			name:              "stream forbidden",
+			inputStreamStatus: http.StatusForbidden,
+			expectedCalls:     []string{"run"},
+			expectedErrOutput: "unable to stream run progress",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var (
+				callsLock sync.Mutex
+				calls     []string
+			)
+
+			addCall := func(call string) {
+				callsLock.Lock()
+				defer callsLock.Unlock()
+				calls = append(calls, call)
+			}
+
+			// The run publishes its events to the stream, which only receives
+			// them if it subscribed before the run was sent.
+			eventCh := make(chan *api.Event, 2)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1alpha1/event/stream":
+					if tc.inputStreamStatus != http.StatusOK {
+						w.WriteHeader(tc.inputStreamStatus)
+						return
+					}
+
+					// Delay the subscription, so a run sent before it is
+					// established is recorded first.
+					time.Sleep(100 * time.Millisecond)
+					addCall("subscribe")
+
+					w.WriteHeader(http.StatusOK)
+					w.(http.Flusher).Flush()
+
+					for {
+						select {
+						case event := <-eventCh:
+							_ = json.NewEncoder(w).Encode(event)
+							w.(http.Flusher).Flush()
+							if event.Type == api.EventTypeFinished {
+								return
+							}
+						case <-r.Context().Done():
+							return
+						}
+					}
+				case "/v1alpha1/jobs/register/plans/" + ulid.Zero.String() + "/run":
+					addCall("run")
+
+					eventCh <- &api.Event{
+						Topic: api.EventTopicJobRegisterRun, Type: api.EventTypeProgress, Time: eventTime, Payload: progress}
+					eventCh <- &api.Event{
+						Topic: api.EventTopicJobRegisterRun, Type: api.EventTypeFinished, Time: eventTime}
+
+					_ = json.NewEncoder(w).Encode(api.JobsRegisterPlanRunResp{Run: &api.JobRegisterPlanRun{}})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			var output, errOutput bytes.Buffer
+
+			cliCtx := cli.NewContext(&cli.App{Writer: &output, ErrWriter: &errOutput}, nil, nil)
+			client := api.NewClient(&api.Config{Address: server.URL})
+
+			resp, err := RunPlan(cliCtx, client, &api.JobsRegisterPlanRunReq{ID: ulid.Zero})
+			must.NoError(t, err)
+			must.NotNil(t, resp.Run)
+
+			callsLock.Lock()
+			must.Eq(t, tc.expectedCalls, calls)
+			callsLock.Unlock()
+
+			must.Eq(t, tc.expectedOutput, output.String())
+			must.StrContains(t, errOutput.String(), tc.expectedErrOutput)
+		})
+	}
+}
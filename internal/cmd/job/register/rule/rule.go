@@ -25,6 +25,7 @@ func Command() *cli.Command {
 			deleteCommand(),
 			getCommand(),
 			listCommand(),
+			updateCommand(),
 		},
 	}
 }
@@ -34,8 +35,10 @@ func outputRule(cliCtx *cli.Context, r *api.JobRegisterRule) {
 		fmt.Sprintf("Name|%s", r.Name),
 		fmt.Sprintf("Region Contexts|%s", contextsAsString(r.RegionContexts)),
 		fmt.Sprintf("Region Pickers|%s", formatRegionPicker(r.RegionPickers)),
+		fmt.Sprintf("Rollout|%s", formatRollout(r.Rollout)),
 		fmt.Sprintf("Create Time|%s", helper.FormatTime(r.Metadata.CreateTime)),
 		fmt.Sprintf("Update Time|%s", helper.FormatTime(r.Metadata.UpdateTime)),
+		fmt.Sprintf("Modify Index|%v", r.Metadata.ModifyIndex),
 	}))
 	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
 }
@@ -63,3 +66,30 @@ func formatRegionPicker(rp []*api.JobRegisterRegionPicker) string {
 
 	return strings.Join(out, ", ")
 }
+
+func formatRollout(rollout *api.JobRegisterRollout) string {
+	if rollout == nil {
+		return api.JobRegisterRolloutStrategyAllAtOnce
+	}
+
+	var out string
+
+	switch rollout.Strategy {
+	case "":
+		out = api.JobRegisterRolloutStrategyAllAtOnce
+	case api.JobRegisterRolloutStrategyCanaryThenRest:
+		out = fmt.Sprintf("%s (%v canary regions)", rollout.Strategy, rollout.CanaryRegions)
+	case api.JobRegisterRolloutStrategyBatched:
+		out = fmt.Sprintf("%s (batch size %v)", rollout.Strategy, rollout.BatchSize)
+	default:
+		out = rollout.Strategy
+	}
+
+	if rollout.WaitForHealthy {
+		out += ", wait for healthy"
+	}
+	if rollout.OnFailure == api.JobRegisterRolloutOnFailureRollback {
+		out += ", rollback on failure"
+	}
+	return out
+}
@@ -46,3 +46,61 @@ func Test_formatRegionPicker(t *testing.T) {
 		})
 	}
 }
+
+func Test_formatRollout(t *testing.T) {
+
+	testCases := []struct {
+		name           string
+		inputRollout   *api.JobRegisterRollout
+		expectedOutput string
+	}{
+		{
+			name:           "nil rollout",
+			inputRollout:   nil,
+			expectedOutput: "all-at-once",
+		},
+		{
+			name:           "serial",
+			inputRollout:   &api.JobRegisterRollout{Strategy: api.JobRegisterRolloutStrategySerial},
+			expectedOutput: "serial",
+		},
+		{
+			name: "canary then rest",
+			inputRollout: &api.JobRegisterRollout{
+				Strategy:      api.JobRegisterRolloutStrategyCanaryThenRest,
+				CanaryRegions: 2,
+			},
+			expectedOutput: "canary-then-rest (2 canary regions)",
+		},
+		{
+			name: "batched",
+			inputRollout: &api.JobRegisterRollout{
+				Strategy:  api.JobRegisterRolloutStrategyBatched,
+				BatchSize: 5,
+			},
+			expectedOutput: "batched (batch size 5)",
+		},
+		{
+			name: "rollback without strategy",
+			inputRollout: &api.JobRegisterRollout{
+				OnFailure: api.JobRegisterRolloutOnFailureRollback,
+			},
+			expectedOutput: "all-at-once, rollback on failure",
+		},
+		{
+			name: "serial wait for healthy with rollback",
+			inputRollout: &api.JobRegisterRollout{
+				Strategy:       api.JobRegisterRolloutStrategySerial,
+				OnFailure:      api.JobRegisterRolloutOnFailureRollback,
+				WaitForHealthy: true,
+			},
+			expectedOutput: "serial, wait for healthy, rollback on failure",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedOutput, formatRollout(tc.inputRollout))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	updateErrorMsg = "failed to update Attila job registration rule"
+)
+
+func updateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update an Attila job registration rule",
+		Category:  "rule",
+		Args:      true,
+		UsageText: "attila job register rule update [options] [rule-spec]",
+		Flags:     helper.WriteFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					updateErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			var ruleObj api.JobRegisterRule
+
+			if err := file.ParseConfig(cliCtx.Args().First(), &ruleObj); err != nil {
+				return cli.Exit(helper.FormatError(updateErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			ruleUpdateResp, _, err := client.JobRegisterRules().Update(context.Background(), &ruleObj, helper.WriteOptionsFromFlags(cliCtx)...)
+			if err != nil {
+				return cli.Exit(helper.FormatError(updateErrorMsg, err), 1)
+			}
+
+			outputRule(cliCtx, ruleUpdateResp.Rule)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func getCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Detail a job registration run",
+		Category:  "run",
+		Args:      true,
+		UsageText: "attila job register run get [options] [run-id]",
+		Flags:     helper.ClientFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					getCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			id, err := ulid.Parse(cliCtx.Args().First())
+			if err != nil {
+				return cli.Exit(helper.FormatError(getCLIErrorMsg, err), 1)
+			}
+
+			getResp, _, err := client.JobRegisterRuns().Get(context.Background(), &api.JobRegisterRunGetReq{ID: id})
+			if err != nil {
+				return cli.Exit(helper.FormatError(getCLIErrorMsg, err), 1)
+			}
+
+			OutputRun(cliCtx, getResp.Run)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "list",
+		Usage:     "List job registration runs",
+		Category:  "run",
+		Args:      false,
+		UsageText: "attila job register run list [options]",
+		Flags: append(helper.ClientFlags(),
+			&cli.StringFlag{
+				Name:  "job-id",
+				Usage: "Only list the runs of the Nomad job with this ID",
+			},
+			&cli.StringFlag{
+				Name:  "namespace",
+				Usage: "Only list the runs of Nomad jobs within this namespace",
+			},
+		),
+		Action: func(cliCtx *cli.Context) error {
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			listReq := api.JobRegisterRunListReq{
+				JobID:     cliCtx.String("job-id"),
+				Namespace: cliCtx.String("namespace"),
+			}
+
+			listResp, _, err := client.JobRegisterRuns().List(context.Background(), &listReq)
+			if err != nil {
+				return cli.Exit(helper.FormatError(listCLIErrorMsg, err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, formatRunList(listResp.Runs))
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+
+			return nil
+		},
+	}
+}
+
+func formatRunList(runs []*api.JobRegisterPlanRun) string {
+	if len(runs) == 0 {
+		return "No job registration runs found"
+	}
+
+	out := make([]string, 0, len(runs)+1)
+	out = append(out, "ID|Job|Namespace|Num Regions|Failed")
+	for _, run := range runs {
+		out = append(out, fmt.Sprintf(
+			"%s|%s|%s|%v|%v",
+			run.ID, run.JobID, run.JobNamespace, len(run.Regions), run.Error != ""))
+	}
+
+	return helper.FormatList(out)
+}
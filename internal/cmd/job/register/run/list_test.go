@@ -0,0 +1,35 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package run
+
+import (
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Test_formatRunList(t *testing.T) {
+	must.Eq(t, "No job registration runs found", formatRunList(nil))
+
+	runID := ulid.Make()
+
+	output := formatRunList([]*api.JobRegisterPlanRun{
+		{
+			ID:           runID,
+			JobID:        "example",
+			JobNamespace: "platform",
+			Regions: map[string]*api.JobRegisterRegionPlanRun{
+				"euw1": {Region: "euw1"},
+				"euw2": {Region: "euw2", Error: "failed"},
+			},
+			Error: "region \"euw2\": failed",
+		},
+	})
+
+	must.Eq(t, "ID                          Job      Namespace  Num Regions  Failed\n"+
+		runID.String()+"  example  platform   2            true", output)
+}
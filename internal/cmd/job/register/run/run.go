@@ -0,0 +1,88 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package run
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	getCLIErrorMsg  = "failed to get job registration run"
+	listCLIErrorMsg = "failed to list job registration runs"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "run",
+		Category:        "register",
+		Usage:           "Inspect the history of Nomad job registration runs",
+		HideHelpCommand: true,
+		UsageText:       "attila job register run <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			getCommand(),
+			listCommand(),
+		},
+	}
+}
+
+// OutputRun writes the detail of a job registration run, including the result
+// of each region in name order.
+func OutputRun(cliCtx *cli.Context, run *api.JobRegisterPlanRun) {
+	out := []string{
+		fmt.Sprintf("ID|%s", run.ID),
+		fmt.Sprintf("Plan ID|%s", run.PlanID),
+		fmt.Sprintf("Num Regions|%v", len(run.Regions)),
+		fmt.Sprintf("Job ID|%s", run.JobID),
+		fmt.Sprintf("Job Namespace|%s", run.JobNamespace),
+		fmt.Sprintf("Error|%s", run.Error),
+	}
+	if run.Metadata != nil {
+		out = append(out, fmt.Sprintf("Create Time|%s", helper.FormatTime(run.Metadata.CreateTime)))
+	}
+
+	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(out))
+
+	for _, regionName := range slices.Sorted(maps.Keys(run.Regions)) {
+		regionRun := run.Regions[regionName]
+
+		_, _ = fmt.Fprint(cliCtx.App.Writer, color.New(color.Bold).Sprintf(
+			"\n\nRegion %q Run:\n", regionRun.Region))
+
+		var evalID, warnings string
+		if regionRun.Run != nil {
+			evalID, warnings = regionRun.Run.EvalID, regionRun.Run.Warnings
+		}
+
+		regionOutput := []string{
+			fmt.Sprintf("Eval ID|%s", evalID),
+			fmt.Sprintf("Warnings|%s", warnings),
+			fmt.Sprintf("Error|%s", regionRun.Error),
+		}
+		if regionRun.Health != nil {
+			regionOutput = append(regionOutput,
+				fmt.Sprintf("Health|%s", regionRun.Health.Status),
+				fmt.Sprintf("Deployment ID|%s", regionRun.Health.DeploymentID),
+				fmt.Sprintf("Health Error|%s", regionRun.Health.Error),
+			)
+		}
+		if regionRun.Rollback != nil {
+			regionOutput = append(regionOutput,
+				fmt.Sprintf("Rollback Status|%s", regionRun.Rollback.Status),
+				fmt.Sprintf("Rollback Error|%s", regionRun.Rollback.Error),
+			)
+		}
+
+		_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(regionOutput))
+	}
+
+	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
+}
@@ -0,0 +1,123 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/cmd/job/register/plan"
+	"github.com/rasorp/attila/internal/cmd/job/register/run"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const runCLIErrorMsg = "failed to run job"
+
+func runCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Plan and run a Nomad job registration",
+		Args:      true,
+		UsageText: "attila job run [options] [job-spec]",
+		Flags:     append(append(helper.ClientFlags(), runFlags()...), plan.RolloutFlags()...),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					runCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			parsedJobspec, submission, err := plan.ParseJobspec(cliCtx, cliCtx.Args().First())
+			if err != nil {
+				return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			createResp, _, err := client.JobRegisterPlans().Create(context.Background(), &api.JobRegisterPlanCreateReq{
+				Job:        parsedJobspec,
+				Submission: submission,
+			})
+			if err != nil {
+				return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
+			}
+
+			plan.OutputPlan(cliCtx, createResp.Plan)
+
+			if len(createResp.Plan.Regions) == 0 {
+				_, _ = fmt.Fprint(cliCtx.App.Writer, "\nNo regions were picked, so there is nothing to run.\n")
+				return nil
+			}
+
+			if !cliCtx.Bool("auto-approve") {
+				approved, err := helper.Confirm(cliCtx.App.Reader, cliCtx.App.Writer,
+					"\nDo you want to run this plan?")
+				if err != nil {
+					return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
+				}
+				if !approved {
+					_, _ = fmt.Fprintf(cliCtx.App.Writer,
+						"\nRun cancelled. The plan can be run later using \"attila job register plan run %s\".\n",
+						createResp.Plan.ID)
+					return nil
+				}
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, "\n")
+
+			// The job is stored with the plan, so the run does not include it.
+			runResp, err := plan.RunPlan(cliCtx, client, &api.JobsRegisterPlanRunReq{
+				ID:      createResp.Plan.ID,
+				Rollout: runRollout(plan.RolloutFromFlags(cliCtx), createResp.Plan.Rollout, cliCtx.Bool("monitor")),
+			})
+			if err != nil {
+				return cli.Exit(helper.FormatError(runCLIErrorMsg, err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, "\n")
+			run.OutputRun(cliCtx, runResp.Run)
+			return nil
+		},
+	}
+}
+
+func runFlags() []cli.Flag {
+	return append(plan.JobspecFlags(),
+		&cli.BoolFlag{
+			Name:  "auto-approve",
+			Usage: "Run the plan without asking for confirmation",
+		},
+		&cli.BoolFlag{
+			Name:  "monitor",
+			Usage: "Wait for the Nomad deployments of every region to succeed before the run completes",
+		},
+	)
+}
+
+// runRollout returns the rollout to run the plan with. Monitoring keeps the
+// rollout set by the flags, or else the rollout of the plan, and waits for the
+// final stage to be healthy, so the run reports the health of every region.
+func runRollout(flagRollout, planRollout *api.JobRegisterRollout, monitor bool) *api.JobRegisterRollout {
+	if !monitor {
+		return flagRollout
+	}
+
+	var rollout api.JobRegisterRollout
+
+	switch {
+	case flagRollout != nil:
+		rollout = *flagRollout
+	case planRollout != nil:
+		rollout = *planRollout
+	}
+
+	rollout.WaitForHealthy = true
+	return &rollout
+}
@@ -0,0 +1,64 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Test_runRollout(t *testing.T) {
+	flagRollout := &api.JobRegisterRollout{Strategy: "serial"}
+	planRollout := &api.JobRegisterRollout{Strategy: "batched", BatchSize: 2}
+
+	testCases := []struct {
+		name            string
+		inputFlag       *api.JobRegisterRollout
+		inputPlan       *api.JobRegisterRollout
+		inputMonitor    bool
+		expectedRollout *api.JobRegisterRollout
+	}{
+		{
+			name:            "no monitor uses flags",
+			inputFlag:       flagRollout,
+			inputPlan:       planRollout,
+			expectedRollout: flagRollout,
+		},
+		{
+			name:            "no monitor or flags leaves plan rollout",
+			inputPlan:       planRollout,
+			expectedRollout: nil,
+		},
+		{
+			name:            "monitor keeps flags",
+			inputFlag:       flagRollout,
+			inputPlan:       planRollout,
+			inputMonitor:    true,
+			expectedRollout: &api.JobRegisterRollout{Strategy: "serial", WaitForHealthy: true},
+		},
+		{
+			name:            "monitor keeps plan",
+			inputPlan:       planRollout,
+			inputMonitor:    true,
+			expectedRollout: &api.JobRegisterRollout{Strategy: "batched", BatchSize: 2, WaitForHealthy: true},
+		},
+		{
+			name:            "monitor without rollout",
+			inputMonitor:    true,
+			expectedRollout: &api.JobRegisterRollout{WaitForHealthy: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedRollout, runRollout(tc.inputFlag, tc.inputPlan, tc.inputMonitor))
+		})
+	}
+
+	// The rollout of the plan must not be modified.
+	must.False(t, planRollout.WaitForHealthy)
+}
@@ -0,0 +1,114 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const statusCLIErrorMsg = "failed to get job status"
+
+func statusCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "status",
+		Usage:     "Detail the status of a Nomad job across every region",
+		Args:      true,
+		UsageText: "attila job status [options] [job-id]",
+		Flags: append(helper.ClientFlags(),
+			&cli.StringFlag{
+				Name:  "namespace",
+				Value: "default",
+				Usage: "The Nomad namespace of the job",
+			},
+		),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					statusCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			statusResp, _, err := client.Jobs().Status(context.Background(), &api.JobStatusReq{
+				Namespace: cliCtx.String("namespace"),
+				ID:        cliCtx.Args().First(),
+			})
+			if err != nil {
+				return cli.Exit(helper.FormatError(statusCLIErrorMsg, err), 1)
+			}
+
+			var runID string
+			if statusResp.Status.RunID != nil {
+				runID = statusResp.Status.RunID.String()
+			}
+
+			out := []string{
+				fmt.Sprintf("Job ID|%s", statusResp.Status.JobID),
+				fmt.Sprintf("Job Namespace|%s", statusResp.Status.JobNamespace),
+				fmt.Sprintf("Last Run ID|%s", runID),
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(out))
+			_, _ = fmt.Fprint(cliCtx.App.Writer, "\n\n", formatStatusRegions(statusResp.Status), "\n")
+			return nil
+		},
+	}
+}
+
+func formatStatusRegions(status *api.JobStatus) string {
+	if len(status.Regions) == 0 {
+		return "No regions hold the job"
+	}
+
+	out := make([]string, 0, len(status.Regions)+1)
+	out = append(out, "Region|Job Status|Job Version|Allocations|Deployment Status|Flag|Error")
+
+	for _, regionName := range slices.Sorted(maps.Keys(status.Regions)) {
+		regionStatus := status.Regions[regionName]
+
+		var jobVersion string
+		if regionStatus.JobVersion != nil {
+			jobVersion = fmt.Sprint(*regionStatus.JobVersion)
+		}
+
+		allocations := make([]string, 0, len(regionStatus.Allocations))
+		for _, clientStatus := range slices.Sorted(maps.Keys(regionStatus.Allocations)) {
+			allocations = append(allocations,
+				fmt.Sprintf("%s=%v", clientStatus, regionStatus.Allocations[clientStatus]))
+		}
+
+		var flag string
+		switch {
+		case regionStatus.Missing:
+			flag = "missing"
+		case regionStatus.Mismatch:
+			flag = "mismatch"
+		}
+
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			regionStatus.Region,
+			regionStatus.JobStatus,
+			jobVersion,
+			strings.Join(allocations, ","),
+			regionStatus.DeploymentStatus,
+			flag,
+			regionStatus.Error,
+		))
+	}
+
+	return helper.FormatList(out)
+}
@@ -0,0 +1,34 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Test_formatStatusRegions(t *testing.T) {
+	must.Eq(t, "No regions hold the job", formatStatusRegions(&api.JobStatus{}))
+
+	output := formatStatusRegions(&api.JobStatus{
+		Regions: map[string]*api.JobStatusRegion{
+			"euw2": {Region: "euw2", Missing: true},
+			"euw1": {
+				Region:           "euw1",
+				JobStatus:        "running",
+				JobVersion:       new(uint64(2)),
+				Allocations:      map[string]int{"running": 2, "failed": 1},
+				DeploymentStatus: "successful",
+				Mismatch:         true,
+			},
+		},
+	})
+
+	must.Eq(t, "Region  Job Status  Job Version  Allocations         Deployment Status  Flag      Error\n"+
+		"euw1    running     2            failed=1,running=2  successful         mismatch  <none>\n"+
+		"euw2    <none>      <none>       <none>              <none>             missing   <none>", output)
+}
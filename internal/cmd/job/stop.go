@@ -0,0 +1,130 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const stopCLIErrorMsg = "failed to stop job"
+
+func stopCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "stop",
+		Usage:     "Stop a Nomad job in every region which holds it",
+		Args:      true,
+		UsageText: "attila job stop [options] [job-id]",
+		Flags: append(helper.ClientFlags(),
+			&cli.StringFlag{
+				Name:  "namespace",
+				Value: "default",
+				Usage: "The Nomad namespace of the job",
+			},
+			&cli.BoolFlag{
+				Name:  "purge",
+				Usage: "Purge the job from each region, rather than leaving it to be garbage collected",
+			},
+			&cli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Stop the job without asking for confirmation",
+			},
+		),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					stopCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			stopReq := api.JobStopReq{
+				Namespace: cliCtx.String("namespace"),
+				ID:        cliCtx.Args().First(),
+				Purge:     cliCtx.Bool("purge"),
+				Plan:      true,
+			}
+
+			planResp, _, err := client.Jobs().Stop(context.Background(), &stopReq)
+			if err != nil {
+				return cli.Exit(helper.FormatError(stopCLIErrorMsg, err), 1)
+			}
+
+			if len(planResp.Stop.Regions) == 0 {
+				_, _ = fmt.Fprint(cliCtx.App.Writer, "No regions hold the job, so there is nothing to stop.\n")
+				return nil
+			}
+
+			outputStop(cliCtx, planResp.Stop)
+
+			if !cliCtx.Bool("auto-approve") {
+				approved, err := helper.Confirm(cliCtx.App.Reader, cliCtx.App.Writer,
+					"\nDo you want to stop the job in these regions?")
+				if err != nil {
+					return cli.Exit(helper.FormatError(stopCLIErrorMsg, err), 1)
+				}
+				if !approved {
+					_, _ = fmt.Fprint(cliCtx.App.Writer, "\nStop cancelled.\n")
+					return nil
+				}
+			}
+
+			stopReq.Plan = false
+
+			stopResp, _, err := client.Jobs().Stop(context.Background(), &stopReq)
+			if err != nil {
+				return cli.Exit(helper.FormatError(stopCLIErrorMsg, err), 1)
+			}
+
+			_, _ = fmt.Fprint(cliCtx.App.Writer, "\n")
+			outputStop(cliCtx, stopResp.Stop)
+			return nil
+		},
+	}
+}
+
+// outputStop writes the detail of a job stop, including the result of each
+// region in name order.
+func outputStop(cliCtx *cli.Context, stop *api.JobStop) {
+	out := []string{
+		fmt.Sprintf("Job ID|%s", stop.JobID),
+		fmt.Sprintf("Job Namespace|%s", stop.JobNamespace),
+		fmt.Sprintf("Purge|%v", stop.Purge),
+		fmt.Sprintf("Num Regions|%v", len(stop.Regions)),
+		fmt.Sprintf("Error|%s", stop.Error),
+	}
+
+	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(out))
+	_, _ = fmt.Fprint(cliCtx.App.Writer, "\n\n", formatStopRegions(stop), "\n")
+}
+
+func formatStopRegions(stop *api.JobStop) string {
+	out := make([]string, 0, len(stop.Regions)+1)
+	out = append(out, "Region|Job Version|Job Status|Eval ID|Error")
+
+	for _, regionName := range slices.Sorted(maps.Keys(stop.Regions)) {
+		regionStop := stop.Regions[regionName]
+
+		var jobVersion string
+		if regionStop.JobVersion != nil {
+			jobVersion = fmt.Sprint(*regionStop.JobVersion)
+		}
+
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s",
+			regionStop.Region, jobVersion, regionStop.JobStatus, regionStop.EvalID, regionStop.Error))
+	}
+
+	return helper.FormatList(out)
+}
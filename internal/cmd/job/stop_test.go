@@ -0,0 +1,25 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/pkg/api"
+)
+
+func Test_formatStopRegions(t *testing.T) {
+	output := formatStopRegions(&api.JobStop{
+		Regions: map[string]*api.JobStopRegion{
+			"euw2": {Region: "euw2", JobVersion: new(uint64(1)), JobStatus: "running", Error: "failed"},
+			"euw1": {Region: "euw1", JobVersion: new(uint64(3)), JobStatus: "running", EvalID: "eval-1"},
+		},
+	})
+
+	must.Eq(t, "Region  Job Version  Job Status  Eval ID  Error\n"+
+		"euw1    3            running     eval-1   <none>\n"+
+		"euw2    1            running     <none>   failed", output)
+}
@@ -0,0 +1,20 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package keyring
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "keyring",
+		Usage:           "Manage the keyring used to encrypt state at rest",
+		HideHelpCommand: true,
+		UsageText:       "attila operator keyring <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			rotateCommand(),
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package keyring
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/internal/server"
+	storebackend "github.com/rasorp/attila/internal/store/backend"
+)
+
+func rotateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "rotate",
+		Usage:     "Re-encrypt file state objects using the active keyring key",
+		Category:  "keyring",
+		Args:      false,
+		UsageText: "attila operator keyring rotate [options]",
+		Description: `Reads the server config files and re-encrypts every object stored by the file
+state backend using the active keyring key. Once complete, keys which are no
+longer active can be removed from the config. The Attila server using the
+state directory must be stopped while the command runs.`,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "config",
+				Value:    cli.NewStringSlice(),
+				Usage:    "The path to a server config file",
+				Required: true,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+
+			cfg := server.DefaultConfig()
+
+			for _, configFile := range cliCtx.StringSlice("config") {
+
+				var serverCfg server.Config
+
+				if err := file.ParseConfig(configFile, &serverCfg); err != nil {
+					return cli.Exit(helper.FormatError("failed to rotate keyring", err), 1)
+				}
+
+				cfg = cfg.Merge(&serverCfg)
+			}
+
+			if err := cfg.State.Validate(); err != nil {
+				return cli.Exit(helper.FormatError("failed to rotate keyring", err), 1)
+			}
+
+			num, err := storebackend.RotateFileKeyring(cfg.State)
+			if err != nil {
+				return cli.Exit(helper.FormatError("failed to rotate keyring", err), 1)
+			}
+
+			_, _ = fmt.Fprintf(cliCtx.App.Writer, "successfully re-encrypted %v objects\n", num)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/operator/keyring"
+)
+
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:            "operator",
+		Usage:           "Perform maintenance tasks on Attila servers and state",
+		HideHelpCommand: true,
+		UsageText:       "attila operator <command> [options] [args]",
+		Subcommands: []*cli.Command{
+			keyring.Command(),
+		},
+	}
+}
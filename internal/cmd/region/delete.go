@@ -20,7 +20,7 @@ func deleteCommand() *cli.Command {
 		Category:  "region",
 		Args:      true,
 		UsageText: "attila region delete [options] [region-name]",
-		Flags:     helper.ClientFlags(),
+		Flags:     helper.WriteFlags(),
 		Action: func(cliCtx *cli.Context) error {
 
 			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
@@ -33,7 +33,7 @@ func deleteCommand() *cli.Command {
 
 			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
 
-			_, err := client.Regions().Delete(context.Background(), cliCtx.Args().First())
+			_, err := client.Regions().Delete(context.Background(), cliCtx.Args().First(), helper.WriteOptionsFromFlags(cliCtx)...)
 			if err != nil {
 				return cli.Exit(helper.FormatError("failed to delete Attila region", err), 1)
 			}
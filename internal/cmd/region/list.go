@@ -45,11 +45,11 @@ func formatRegionList(regions []*api.RegionStub) string {
 	}
 
 	out := make([]string, 0, len(regions)+1)
-	out = append(out, "Name|Group|TLS|Addresses")
+	out = append(out, "Name|Group|Auth|TLS|Addresses")
 	for _, region := range regions {
 		out = append(out, fmt.Sprintf(
-			"%s|%s|%v|%v",
-			region.Name, region.Group, region.TLSEnabled, strings.Join(region.Addresses, ", ")))
+			"%s|%s|%v|%v|%v",
+			region.Name, region.Group, region.AuthEnabled, region.TLSEnabled, strings.Join(region.Addresses, ", ")))
 	}
 
 	return helper.FormatList(out)
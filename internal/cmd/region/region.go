@@ -24,6 +24,7 @@ func Command() *cli.Command {
 			getCommand(),
 			listCommand(),
 			shellCommand(),
+			updateCommand(),
 		},
 	}
 }
@@ -33,6 +34,7 @@ func outputRegion(cliCtx *cli.Context, r *api.Region) {
 	outputKV := []string{
 		fmt.Sprintf("Name|%s", r.Name),
 		fmt.Sprintf("Group|%s", r.Group),
+		fmt.Sprintf("Auth Enabled|%v", r.Auth != nil && r.Auth.Token != ""),
 		fmt.Sprintf("TLS Enabled|%v", r.TLS != nil),
 	}
 
@@ -46,6 +48,7 @@ func outputRegion(cliCtx *cli.Context, r *api.Region) {
 	outputKV = append(outputKV,
 		fmt.Sprintf("Create Time|%s", helper.FormatTime(r.Metadata.CreateTime)),
 		fmt.Sprintf("Update Time|%s", helper.FormatTime(r.Metadata.UpdateTime)),
+		fmt.Sprintf("Modify Index|%v", r.Metadata.ModifyIndex),
 	)
 
 	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatKV(outputKV))
@@ -62,13 +65,4 @@ func outputRegion(cliCtx *cli.Context, r *api.Region) {
 	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n\n")
 	_, _ = fmt.Fprint(cliCtx.App.Writer, helper.FormatList(out))
 	_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n")
-
-	if r.TLS != nil {
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\nTLS CA Cert:")
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n%s", r.TLS.CACert)
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\nTLS Client Cert:")
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n%s", r.TLS.ClientCert)
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\nTLS Client Key:")
-		_, _ = fmt.Fprintf(cliCtx.App.Writer, "\n%s", r.TLS.ClientKey)
-	}
 }
@@ -42,7 +42,10 @@ func shellCommand() *cli.Command {
 
 			attilaClient := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
 
-			regionResp, _, err := attilaClient.Regions().Get(context.Background(), cliCtx.Args().First())
+			// The shell configures the Nomad CLI using the region TLS client
+			// key, so the secrets must be included within the response.
+			regionResp, _, err := attilaClient.Regions().Get(
+				context.Background(), cliCtx.Args().First(), api.WithSecrets())
 			if err != nil {
 				return cli.Exit(helper.FormatError("failed to run region shell", err), 1)
 			}
@@ -0,0 +1,58 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package region
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/pkg/api"
+)
+
+const (
+	updateCLIErrorMsg = "failed to update Attila region"
+)
+
+func updateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update an Attila region",
+		Category:  "region",
+		Args:      true,
+		UsageText: "attila region update [options] [region-spec]",
+		Flags:     helper.WriteFlags(),
+		Action: func(cliCtx *cli.Context) error {
+
+			if numArgs := cliCtx.Args().Len(); numArgs != 1 {
+				return cli.Exit(helper.FormatError(
+					updateCLIErrorMsg,
+					fmt.Errorf("expected 1 argument, got %v", numArgs)),
+					1,
+				)
+			}
+
+			var region api.Region
+
+			if err := file.ParseConfig(cliCtx.Args().First(), &region); err != nil {
+				return cli.Exit(helper.FormatError(updateCLIErrorMsg, err), 1)
+			}
+
+			client := api.NewClient(helper.ClientConfigFromFlags(cliCtx))
+
+			req := api.RegionUpdateReq{Region: &region}
+
+			regionUpdateResp, _, err := client.Regions().Update(context.Background(), &req, helper.WriteOptionsFromFlags(cliCtx)...)
+			if err != nil {
+				return cli.Exit(helper.FormatError(updateCLIErrorMsg, err), 1)
+			}
+
+			outputRegion(cliCtx, regionUpdateResp.Region)
+			return nil
+		},
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/rasorp/attila/internal/cmd/helper"
+	"github.com/rasorp/attila/internal/helper/fanout"
 	"github.com/rasorp/attila/internal/helper/file"
+	"github.com/rasorp/attila/internal/nomad/job"
 	"github.com/rasorp/attila/internal/server"
 	storebackend "github.com/rasorp/attila/internal/store/backend"
+	"github.com/rasorp/attila/internal/tracing"
 )
 
 func runCommand() *cli.Command {
@@ -30,7 +33,7 @@ func runCommand() *cli.Command {
 				return cli.Exit(helper.FormatError("failed to run an Attila server", err), 1)
 			}
 			srv.Start()
-			srv.WaitForSignals()
+			srv.WaitForSignals(func() (*server.Config, error) { return generateRunConfig(cliCtx) })
 			return nil
 		},
 	}
@@ -38,6 +41,11 @@ func runCommand() *cli.Command {
 
 func runFlags() []cli.Flag {
 	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "acl-enabled",
+			Value: false,
+			Usage: "Enable ACL enforcement of API requests",
+		},
 		&cli.StringSliceFlag{
 			Name:  "config",
 			Value: cli.NewStringSlice(),
@@ -53,6 +61,27 @@ func runFlags() []cli.Flag {
 			Value: cli.NewStringSlice(),
 			Usage: "The HTTP/HTTPS/UNIX bind address for the server to use",
 		},
+		&cli.StringFlag{
+			Name:  "http-request-timeout",
+			Usage: "The deadline applied to requests which call Nomad regions",
+		},
+		&cli.StringFlag{
+			Name:  "http-tls-cert-file",
+			Usage: "The path to the certificate used by HTTPS binds",
+		},
+		&cli.StringFlag{
+			Name:  "http-tls-key-file",
+			Usage: "The path to the private key used by HTTPS binds",
+		},
+		&cli.StringFlag{
+			Name:  "http-tls-client-ca-file",
+			Usage: "The path to the CA used to verify client certificates",
+		},
+		&cli.BoolFlag{
+			Name:  "http-tls-verify-client",
+			Value: false,
+			Usage: "Require HTTPS clients to present a certificate signed by the client CA",
+		},
 		&cli.StringFlag{
 			Name:  "log-level",
 			Value: "info",
@@ -73,6 +102,53 @@ func runFlags() []cli.Flag {
 			Value: false,
 			Usage: "Add file:line of the caller to each log entry",
 		},
+		&cli.BoolFlag{
+			Name:  "tracing-enabled",
+			Value: false,
+			Usage: "Enable the export of OpenTelemetry traces",
+		},
+		&cli.StringFlag{
+			Name:  "tracing-endpoint",
+			Usage: "The host and port of the OTLP HTTP receiver to export traces to",
+		},
+		&cli.BoolFlag{
+			Name:  "tracing-insecure",
+			Value: false,
+			Usage: "Disable TLS when exporting traces",
+		},
+		&cli.IntFlag{
+			Name:  "fan-out-workers",
+			Usage: "The maximum number of regions called in parallel for a job plan or run",
+		},
+		&cli.StringFlag{
+			Name:  "fan-out-region-timeout",
+			Usage: "The maximum time a single region call can take",
+		},
+		&cli.StringFlag{
+			Name:  "fan-out-mode",
+			Usage: "How region failures are handled, either fail_fast or collect_all",
+		},
+		&cli.StringFlag{
+			Name:  "plan-ttl",
+			Usage: "The time after its creation that a job registration plan expires",
+		},
+		&cli.StringFlag{
+			Name:  "plan-reap-interval",
+			Usage: "How often expired job registration plans are deleted",
+		},
+		&cli.Float64Flag{
+			Name:  "plan-topology-change-threshold",
+			Usage: "The fractional change in a region's topology which rejects running a plan",
+		},
+		&cli.BoolFlag{
+			Name:  "drift-enabled",
+			Value: true,
+			Usage: "Enable the periodic check of job registration run regions for drift",
+		},
+		&cli.StringFlag{
+			Name:  "drift-interval",
+			Usage: "How often the regions of job registration runs are checked for drift",
+		},
 		&cli.BoolFlag{
 			Name:  "state-memory-enabled",
 			Value: false,
@@ -100,16 +176,17 @@ func generateRunConfig(cliCtx *cli.Context) (*server.Config, error) {
 
 		var serverCfg server.Config
 
-		if err := file.ParseConfig(configFile, serverCfg); err != nil {
+		if err := file.ParseConfig(configFile, &serverCfg); err != nil {
 			return nil, err
 		}
 
 		defaultCfg = defaultCfg.Merge(&serverCfg)
 	}
 
-	//
-	if lvl := cliCtx.String("http-access-log-level"); lvl != "" {
-		defaultCfg.HTTP.AccessLogLevel = lvl
+	// Flags which have a default value are only applied when set, otherwise
+	// the default would always override the config files.
+	if cliCtx.IsSet("http-access-log-level") {
+		defaultCfg.HTTP.AccessLogLevel = cliCtx.String("http-access-log-level")
 	}
 	if len(cliCtx.StringSlice("http-bind-address")) > 0 {
 		defaultCfg.HTTP.Binds = make([]*server.BindConfig, len(cliCtx.StringSlice("http-bind-address")))
@@ -118,12 +195,28 @@ func generateRunConfig(cliCtx *cli.Context) (*server.Config, error) {
 		}
 	}
 
+	if requestTimeout := cliCtx.String("http-request-timeout"); requestTimeout != "" {
+		defaultCfg.HTTP.RequestTimeout = requestTimeout
+	}
+
+	tlsCfg := server.TLSConfig{
+		CertFile:     cliCtx.String("http-tls-cert-file"),
+		KeyFile:      cliCtx.String("http-tls-key-file"),
+		ClientCAFile: cliCtx.String("http-tls-client-ca-file"),
+	}
+	if verify := cliCtx.Bool("http-tls-verify-client"); verify {
+		tlsCfg.VerifyClient = &verify
+	}
+	if tlsCfg != (server.TLSConfig{}) {
+		defaultCfg.HTTP.TLS = defaultCfg.HTTP.TLS.Merge(&tlsCfg)
+	}
+
 	//
-	if lvl := cliCtx.String("log-level"); lvl != "" {
-		defaultCfg.Log.Level = lvl
+	if cliCtx.IsSet("log-level") {
+		defaultCfg.Log.Level = cliCtx.String("log-level")
 	}
-	if format := cliCtx.String("log-format"); format != "" {
-		defaultCfg.Log.Format = format
+	if cliCtx.IsSet("log-format") {
+		defaultCfg.Log.Format = cliCtx.String("log-format")
 	}
 	if colour := cliCtx.Bool("log-colour"); colour {
 		defaultCfg.Log.Colour = &colour
@@ -132,6 +225,45 @@ func generateRunConfig(cliCtx *cli.Context) (*server.Config, error) {
 		defaultCfg.Log.IncludeLine = &line
 	}
 
+	if aclEnabled := cliCtx.Bool("acl-enabled"); aclEnabled {
+		defaultCfg.ACL = &server.ACLConfig{Enable: &aclEnabled}
+	}
+
+	tracingCfg := tracing.Config{Endpoint: cliCtx.String("tracing-endpoint")}
+	if tracingEnabled := cliCtx.Bool("tracing-enabled"); tracingEnabled {
+		tracingCfg.Enable = &tracingEnabled
+	}
+	if tracingInsecure := cliCtx.Bool("tracing-insecure"); tracingInsecure {
+		tracingCfg.Insecure = &tracingInsecure
+	}
+	defaultCfg.Tracing = defaultCfg.Tracing.Merge(&tracingCfg)
+
+	fanOutCfg := fanout.Config{
+		RegionTimeout: cliCtx.String("fan-out-region-timeout"),
+		Mode:          cliCtx.String("fan-out-mode"),
+	}
+	if cliCtx.IsSet("fan-out-workers") {
+		fanOutCfg.Workers = new(cliCtx.Int("fan-out-workers"))
+	}
+	defaultCfg.FanOut = defaultCfg.FanOut.Merge(&fanOutCfg)
+
+	planCfg := job.PlanConfig{
+		TTL:          cliCtx.String("plan-ttl"),
+		ReapInterval: cliCtx.String("plan-reap-interval"),
+	}
+	if cliCtx.IsSet("plan-topology-change-threshold") {
+		planCfg.TopologyChangeThreshold = new(cliCtx.Float64("plan-topology-change-threshold"))
+	}
+	defaultCfg.Plan = defaultCfg.Plan.Merge(&planCfg)
+
+	driftCfg := job.DriftConfig{
+		Interval: cliCtx.String("drift-interval"),
+	}
+	if cliCtx.IsSet("drift-enabled") {
+		driftCfg.Enabled = new(cliCtx.Bool("drift-enabled"))
+	}
+	defaultCfg.Drift = defaultCfg.Drift.Merge(&driftCfg)
+
 	if memoryState := cliCtx.Bool("state-memory-enabled"); memoryState {
 		defaultCfg.State.Memory = &storebackend.MemoryConfig{Enable: &memoryState}
 	}
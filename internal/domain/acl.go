@@ -0,0 +1,161 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// ACLTokenTypeManagement is a token which has full access to all
+	// resources, including the ACL system itself. Policies are ignored.
+	ACLTokenTypeManagement = "management"
+
+	// ACLTokenTypeClient is a token whose access is determined by the policies
+	// it is linked to.
+	ACLTokenTypeClient = "client"
+)
+
+const (
+	// ACLAccessDeny explicitly denies access to a resource and takes
+	// precedence over any other policy linked to the same token.
+	ACLAccessDeny = "deny"
+
+	// ACLAccessRead allows the resource to be read.
+	ACLAccessRead = "read"
+
+	// ACLAccessWrite allows the resource to be read and written.
+	ACLAccessWrite = "write"
+)
+
+// ACLPolicy details the access granted to each resource type. An empty access
+// value means the policy does not grant any access to that resource.
+type ACLPolicy struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Region      string    `json:"region"`
+	Method      string    `json:"method"`
+	Rule        string    `json:"rule"`
+	Plan        string    `json:"plan"`
+	Topology    string    `json:"topology"`
+	Job         string    `json:"job"`
+	Metadata    *Metadata `json:"metadata"`
+}
+
+// Validate performs validation of the ACL policy. It is safe to call without
+// checking whether the policy object is nil.
+func (p *ACLPolicy) Validate() error {
+	if p == nil {
+		return errors.New("acl policy is empty")
+	}
+
+	var errs []error
+
+	if p.Name == "" {
+		errs = append(errs, errors.New("acl policy name required"))
+	}
+
+	for resource, access := range map[string]string{
+		"region":   p.Region,
+		"method":   p.Method,
+		"rule":     p.Rule,
+		"plan":     p.Plan,
+		"topology": p.Topology,
+		"job":      p.Job,
+	} {
+		switch access {
+		case "", ACLAccessDeny, ACLAccessRead, ACLAccessWrite:
+		default:
+			errs = append(errs, fmt.Errorf("acl policy %s access %q invalid", resource, access))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *ACLPolicy) Stub() *ACLPolicyStub {
+	return &ACLPolicyStub{
+		Name:        p.Name,
+		Description: p.Description,
+	}
+}
+
+type ACLPolicyStub struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ACLToken is used to authenticate requests. The accessor ID is a public
+// identifier which is safe to log and share, whereas the secret ID must be
+// kept private as it is the value sent with requests.
+type ACLToken struct {
+	AccessorID ulid.ULID `json:"accessor_id"`
+	SecretID   string    `json:"secret_id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Policies   []string  `json:"policies"`
+	Metadata   *Metadata `json:"metadata"`
+}
+
+// NewACLToken returns a token with freshly generated accessor and secret IDs.
+func NewACLToken(name, tokenType string, policies []string) *ACLToken {
+	return &ACLToken{
+		AccessorID: ulid.Make(),
+		SecretID:   rand.Text(),
+		Name:       name,
+		Type:       tokenType,
+		Policies:   policies,
+		Metadata:   NewMetadata(),
+	}
+}
+
+// Validate performs validation of the ACL token. It is safe to call without
+// checking whether the token object is nil.
+func (t *ACLToken) Validate() error {
+	if t == nil {
+		return errors.New("acl token is empty")
+	}
+
+	var errs []error
+
+	switch t.Type {
+	case ACLTokenTypeManagement:
+		if len(t.Policies) > 0 {
+			errs = append(errs, errors.New("acl management token cannot be linked to policies"))
+		}
+	case ACLTokenTypeClient:
+		if len(t.Policies) == 0 {
+			errs = append(errs, errors.New("acl client token requires at least one policy"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("acl token type %q invalid", t.Type))
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsManagement returns whether the token has full access to all resources.
+func (t *ACLToken) IsManagement() bool { return t.Type == ACLTokenTypeManagement }
+
+// Stub returns a summary of the token which does not include the secret ID,
+// so it is safe to return from list endpoints.
+func (t *ACLToken) Stub() *ACLTokenStub {
+	return &ACLTokenStub{
+		AccessorID: t.AccessorID,
+		Name:       t.Name,
+		Type:       t.Type,
+		Policies:   t.Policies,
+	}
+}
+
+type ACLTokenStub struct {
+	AccessorID ulid.ULID `json:"accessor_id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Policies   []string  `json:"policies"`
+}
@@ -0,0 +1,110 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestACLPolicy_Validate(t *testing.T) {
+
+	testCases := []struct {
+		name                string
+		inputPolicy         *ACLPolicy
+		outputErrorContains string
+	}{
+		{
+			name:                "nil policy",
+			inputPolicy:         nil,
+			outputErrorContains: "acl policy is empty",
+		},
+		{
+			name:                "empty name",
+			inputPolicy:         &ACLPolicy{Region: ACLAccessRead},
+			outputErrorContains: "acl policy name required",
+		},
+		{
+			name:                "invalid access",
+			inputPolicy:         &ACLPolicy{Name: "ops", Plan: "admin"},
+			outputErrorContains: `acl policy plan access "admin" invalid`,
+		},
+		{
+			name: "full valid",
+			inputPolicy: &ACLPolicy{
+				Name:     "ops",
+				Region:   ACLAccessDeny,
+				Method:   ACLAccessRead,
+				Rule:     ACLAccessRead,
+				Plan:     ACLAccessWrite,
+				Topology: "",
+				Job:      ACLAccessWrite,
+			},
+			outputErrorContains: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualOutput := tc.inputPolicy.Validate()
+			if tc.outputErrorContains != "" {
+				must.ErrorContains(t, actualOutput, tc.outputErrorContains)
+			} else {
+				must.NoError(t, actualOutput)
+			}
+		})
+	}
+}
+
+func TestACLToken_Validate(t *testing.T) {
+
+	testCases := []struct {
+		name                string
+		inputToken          *ACLToken
+		outputErrorContains string
+	}{
+		{
+			name:                "nil token",
+			inputToken:          nil,
+			outputErrorContains: "acl token is empty",
+		},
+		{
+			name:                "invalid type",
+			inputToken:          NewACLToken("test", "admin", nil),
+			outputErrorContains: `acl token type "admin" invalid`,
+		},
+		{
+			name:                "management with policies",
+			inputToken:          NewACLToken("test", ACLTokenTypeManagement, []string{"ops"}),
+			outputErrorContains: "acl management token cannot be linked to policies",
+		},
+		{
+			name:                "client without policies",
+			inputToken:          NewACLToken("test", ACLTokenTypeClient, nil),
+			outputErrorContains: "acl client token requires at least one policy",
+		},
+		{
+			name:                "valid management",
+			inputToken:          NewACLToken("test", ACLTokenTypeManagement, nil),
+			outputErrorContains: "",
+		},
+		{
+			name:                "valid client",
+			inputToken:          NewACLToken("test", ACLTokenTypeClient, []string{"ops"}),
+			outputErrorContains: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualOutput := tc.inputToken.Validate()
+			if tc.outputErrorContains != "" {
+				must.ErrorContains(t, actualOutput, tc.outputErrorContains)
+			} else {
+				must.NoError(t, actualOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// JobDriftStatusInSync indicates the region runs the job as it was
+	// registered by the last run.
+	JobDriftStatusInSync = "in_sync"
+
+	// JobDriftStatusDrifted indicates the job within the region has been
+	// modified or removed outside of Attila since the last run.
+	JobDriftStatusDrifted = "drifted"
+
+	// JobDriftStatusUnknown indicates the job could not be read from the
+	// region.
+	JobDriftStatusUnknown = "unknown"
+)
+
+// JobDrift is the result of comparing a job registered by a successful run with
+// the job running within one of the run's regions.
+type JobDrift struct {
+	JobID        string    `json:"job_id"`
+	JobNamespace string    `json:"job_namespace"`
+	Region       string    `json:"region"`
+	RunID        ulid.ULID `json:"run_id"`
+	Status       string    `json:"status"`
+
+	// Reason describes why the region has drifted, or why its status is
+	// unknown.
+	Reason string `json:"reason,omitempty"`
+
+	// JobVersion and JobSpecHash are the version and spec hash of the job when
+	// it was first found to match the run. Later checks compare the job
+	// running in the region with them.
+	JobVersion  *uint64 `json:"job_version,omitempty"`
+	JobSpecHash string  `json:"job_spec_hash,omitempty"`
+
+	// CheckTime is the time of the latest check, and DriftTime is the time the
+	// region was first found to have drifted.
+	CheckTime time.Time `json:"check_time"`
+	DriftTime time.Time `json:"drift_time,omitzero"`
+}
+
+// Drifted is a helper function that informs the caller if the region has
+// drifted from the run.
+func (j *JobDrift) Drifted() bool { return j.Status == JobDriftStatusDrifted }
@@ -4,20 +4,120 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
 	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 )
 
+var (
+	// ErrJobRegisterPlanJobMismatch is returned when a plan is run with a job
+	// which differs from the job that was planned.
+	ErrJobRegisterPlanJobMismatch = errors.New("job does not match the planned job")
+
+	// ErrJobRegisterPlanStale is returned when a plan is run after it has
+	// expired, or once the regions it planned have changed too much for the
+	// plan to be trusted.
+	ErrJobRegisterPlanStale = errors.New("job register plan is stale")
+)
+
 type JobRegisterPlan struct {
 	ID           ulid.ULID                         `json:"id"`
 	JobID        string                            `json:"job_id"`
 	JobNamespace string                            `json:"job_namespace"`
 	Regions      map[string]*JobRegisterRegionPlan `json:"regions"`
+
+	// Rollout is the rollout of the rules which picked the regions. It is used
+	// when the plan is run, unless the run request includes its own.
+	Rollout *JobRegisterRollout `json:"rollout,omitempty"`
+
+	// Job is the job which was planned. It is registered when the plan is run
+	// without a job.
+	Job *api.Job `json:"job,omitempty"`
+
+	// Submission is the original source and variables of the planned job. It
+	// is optional and is passed to Nomad when the job is registered, so the
+	// source can be viewed within Nomad.
+	Submission *api.JobSubmission `json:"submission,omitempty"`
+
+	// JobHash is the hash of the job which was planned. A run is rejected if
+	// the submitted job does not have the same hash.
+	JobHash string `json:"job_hash"`
+
+	// ExpireTime is the time after which the plan can no longer be run and
+	// will be deleted. It is zero if the plan does not expire.
+	ExpireTime time.Time `json:"expire_time,omitzero"`
+
+	Metadata *Metadata `json:"metadata"`
+}
+
+// Expired is a helper function that informs the caller if the plan has passed
+// its expiry time.
+func (j *JobRegisterPlan) Expired(now time.Time) bool {
+	return !j.ExpireTime.IsZero() && !now.Before(j.ExpireTime)
+}
+
+// HashJob returns the hex encoded SHA-256 hash of the JSON encoded job. It is
+// used to check the job submitted with a run matches the planned job.
+func HashJob(job *api.Job) (string, error) {
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(jobJSON)
+	return hex.EncodeToString(sum[:]), nil
 }
 
 type JobRegisterRegionPlan struct {
 	Region string               `json:"region"`
 	Plan   *api.JobPlanResponse `json:"plan"`
+
+	// JobVersion is the version of the job running in the region when the plan
+	// was created. It is nil if the job was not registered in the region, and
+	// is used to revert the region if a run fails.
+	JobVersion *uint64 `json:"job_version,omitempty"`
+
+	// Topology is the size of the region when the plan was created. It is nil
+	// if the topology of the region had not been collected, and is used to
+	// reject runs once the region has changed too much.
+	Topology *JobRegisterRegionTopology `json:"topology,omitempty"`
+}
+
+// JobRegisterRegionTopology is the size of a region, as reported by the
+// topology collection.
+type JobRegisterRegionTopology struct {
+	NumClients        int   `json:"num_clients"`
+	CPUAllocatable    int64 `json:"cpu_allocatable"`
+	MemoryAllocatable int64 `json:"memory_allocatable"`
+}
+
+// Change returns the largest fractional change between the topology and the
+// current topology, across the number of clients and allocatable resources. A
+// value which changes from zero is treated as a complete change.
+func (t *JobRegisterRegionTopology) Change(current *JobRegisterRegionTopology) float64 {
+	return max(
+		fractionalChange(int64(t.NumClients), int64(current.NumClients)),
+		fractionalChange(t.CPUAllocatable, current.CPUAllocatable),
+		fractionalChange(t.MemoryAllocatable, current.MemoryAllocatable),
+	)
+}
+
+func fractionalChange(old, current int64) float64 {
+	switch {
+	case old == current:
+		return 0
+	case old == 0:
+		return 1
+	}
+	diff := current - old
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff) / float64(old)
 }
 
 func NewJobRegisterPlan(jobID, jobNamespace string) *JobRegisterPlan {
@@ -26,35 +126,120 @@ func NewJobRegisterPlan(jobID, jobNamespace string) *JobRegisterPlan {
 		JobID:        jobID,
 		JobNamespace: jobNamespace,
 		Regions:      make(map[string]*JobRegisterRegionPlan),
+		Metadata:     NewMetadata(),
 	}
 }
 
-func (j *JobRegisterPlan) AddRegion(region *Region, nomadPlan *api.JobPlanResponse) {
+func (j *JobRegisterPlan) AddRegion(
+	region *Region, nomadPlan *api.JobPlanResponse, jobVersion *uint64, topology *JobRegisterRegionTopology) {
 	j.Regions[region.Name] = &JobRegisterRegionPlan{
-		Region: region.Name,
-		Plan:   nomadPlan,
+		Region:     region.Name,
+		Plan:       nomadPlan,
+		JobVersion: jobVersion,
+		Topology:   topology,
 	}
 }
 
 type JobRegisterPlanRun struct {
 	ID           ulid.ULID                            `json:"id"`
+	PlanID       ulid.ULID                            `json:"plan_id"`
 	JobID        string                               `json:"job_id"`
 	JobNamespace string                               `json:"job_namespace"`
 	Regions      map[string]*JobRegisterRegionPlanRun `json:"regions"`
+
+	// Error describes why the run failed. It is set when the run partially
+	// failed, so the outcome is recorded alongside the regional results.
+	Error string `json:"error,omitempty"`
+
+	Metadata *Metadata `json:"metadata"`
 }
 
 type JobRegisterRegionPlanRun struct {
 	Region string                   `json:"region"`
 	Run    *api.JobRegisterResponse `json:"run"`
-	Error  error                    `json:"error"`
+
+	// Error describes why the job could not be registered in the region. It
+	// is stored as a string, so the run can be persisted and read back.
+	Error string `json:"error,omitempty"`
+
+	// Rollback is the outcome of reverting the region after the run failed. It
+	// is only set when the run used the rollback failure mode.
+	Rollback *JobRegisterRegionRollback `json:"rollback,omitempty"`
+
+	// Health is the outcome of waiting for the Nomad deployment created by
+	// the registration. It is only set when the run waited for the region.
+	Health *JobRegisterRegionHealth `json:"health,omitempty"`
 }
 
-func NewJobRegisterPlanRun(jobID, jobNamespace string) *JobRegisterPlanRun {
+const (
+	// JobRegisterHealthStatusHealthy indicates the Nomad deployment succeeded,
+	// or the registration did not create a deployment.
+	JobRegisterHealthStatusHealthy = "healthy"
+
+	// JobRegisterHealthStatusUnhealthy indicates the Nomad evaluation or
+	// deployment failed or was cancelled.
+	JobRegisterHealthStatusUnhealthy = "unhealthy"
+
+	// JobRegisterHealthStatusTimeout indicates the Nomad deployment did not
+	// complete within the rollout deployment timeout.
+	JobRegisterHealthStatusTimeout = "timeout"
+
+	// JobRegisterHealthStatusUnknown indicates the Nomad evaluation or
+	// deployment could not be read.
+	JobRegisterHealthStatusUnknown = "unknown"
+)
+
+// JobRegisterRegionHealth details the outcome of waiting for the Nomad
+// deployment of a single region.
+type JobRegisterRegionHealth struct {
+	Status string `json:"status"`
+
+	// DeploymentID is the ID of the Nomad deployment which was followed. It is
+	// empty if the registration did not create a deployment, or the
+	// evaluation did not complete.
+	DeploymentID string `json:"deployment_id,omitempty"`
+
+	// Error describes why the region is not healthy.
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	// JobRegisterRollbackStatusReverted indicates the region was reverted to
+	// the job version it ran before the run.
+	JobRegisterRollbackStatusReverted = "reverted"
+
+	// JobRegisterRollbackStatusFailed indicates the Nomad revert call failed,
+	// so the region still runs the registered job.
+	JobRegisterRollbackStatusFailed = "failed"
+
+	// JobRegisterRollbackStatusDeregistered indicates the job was not
+	// registered in the region before the run, so it was deregistered.
+	JobRegisterRollbackStatusDeregistered = "deregistered"
+)
+
+// JobRegisterRegionRollback details the outcome of reverting a single region.
+type JobRegisterRegionRollback struct {
+	Status string `json:"status"`
+
+	// JobVersion is the job version the region was reverted to. It is not set
+	// when the job was deregistered.
+	JobVersion *uint64 `json:"job_version,omitempty"`
+
+	// Revert is the Nomad response to the revert call.
+	Revert *api.JobRegisterResponse `json:"revert,omitempty"`
+
+	// Error describes why the rollback failed.
+	Error string `json:"error,omitempty"`
+}
+
+func NewJobRegisterPlanRun(planID ulid.ULID, jobID, jobNamespace string) *JobRegisterPlanRun {
 	return &JobRegisterPlanRun{
 		ID:           ulid.Make(),
+		PlanID:       planID,
 		JobID:        jobID,
 		JobNamespace: jobNamespace,
 		Regions:      make(map[string]*JobRegisterRegionPlanRun),
+		Metadata:     NewMetadata(),
 	}
 }
 
@@ -62,10 +247,55 @@ func (j *JobRegisterPlanRun) AddRegion(regionName string, regResp *api.JobRegist
 	runResp := JobRegisterRegionPlanRun{Region: regionName}
 
 	if err != nil {
-		runResp.Error = err
+		runResp.Error = err.Error()
 	} else {
 		runResp.Run = regResp
 	}
 
 	j.Regions[regionName] = &runResp
 }
+
+// SetHealth records the deployment health of a region which has already been
+// added to the run.
+func (j *JobRegisterPlanRun) SetHealth(regionName string, health *JobRegisterRegionHealth) {
+	if regionRun, ok := j.Regions[regionName]; ok {
+		regionRun.Health = health
+	}
+}
+
+// SetRollback records the rollback outcome of a region which has already been
+// added to the run.
+func (j *JobRegisterPlanRun) SetRollback(regionName string, rollback *JobRegisterRegionRollback) {
+	if regionRun, ok := j.Regions[regionName]; ok {
+		regionRun.Rollback = rollback
+	}
+}
+
+// RegisteredJobModifyIndexes returns the Nomad job modify index of each region
+// the run left the job registered in. Regions which failed to register the job
+// are not included, nor are regions where the job was deregistered by the
+// rollback. Regions which were reverted use the index of the revert.
+func (j *JobRegisterPlanRun) RegisteredJobModifyIndexes() map[string]uint64 {
+	indexes := make(map[string]uint64, len(j.Regions))
+
+	for regionName, regionRun := range j.Regions {
+		if regionRun.Run == nil || regionRun.Error != "" {
+			continue
+		}
+		if rollback := regionRun.Rollback; rollback != nil &&
+			rollback.Status == JobRegisterRollbackStatusDeregistered {
+			continue
+		}
+
+		regResp := regionRun.Run
+
+		if rollback := regionRun.Rollback; rollback != nil &&
+			rollback.Status == JobRegisterRollbackStatusReverted && rollback.Revert != nil {
+			regResp = rollback.Revert
+		}
+
+		indexes[regionName] = regResp.JobModifyIndex
+	}
+
+	return indexes
+}
@@ -0,0 +1,89 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobRegisterPlan_Expired(t *testing.T) {
+	now := time.Now()
+
+	must.False(t, (&JobRegisterPlan{}).Expired(now))
+	must.False(t, (&JobRegisterPlan{ExpireTime: now.Add(time.Minute)}).Expired(now))
+	must.True(t, (&JobRegisterPlan{ExpireTime: now}).Expired(now))
+	must.True(t, (&JobRegisterPlan{ExpireTime: now.Add(-time.Minute)}).Expired(now))
+}
+
+func TestHashJob(t *testing.T) {
+	job := &api.Job{ID: new("example"), Namespace: new("default"), Priority: new(50)}
+
+	hash1, err := HashJob(job)
+	must.NoError(t, err)
+
+	hash2, err := HashJob(&api.Job{ID: new("example"), Namespace: new("default"), Priority: new(50)})
+	must.NoError(t, err)
+	must.Eq(t, hash1, hash2)
+
+	job.Priority = new(70)
+
+	hash3, err := HashJob(job)
+	must.NoError(t, err)
+	must.NotEq(t, hash1, hash3)
+}
+
+func TestJobRegisterRegionTopology_Change(t *testing.T) {
+	planned := &JobRegisterRegionTopology{NumClients: 10, CPUAllocatable: 1000, MemoryAllocatable: 4000}
+
+	testCases := []struct {
+		name           string
+		inputCurrent   *JobRegisterRegionTopology
+		expectedChange float64
+	}{
+		{
+			name:           "unchanged",
+			inputCurrent:   &JobRegisterRegionTopology{NumClients: 10, CPUAllocatable: 1000, MemoryAllocatable: 4000},
+			expectedChange: 0,
+		},
+		{
+			name:           "clients removed",
+			inputCurrent:   &JobRegisterRegionTopology{NumClients: 5, CPUAllocatable: 900, MemoryAllocatable: 4000},
+			expectedChange: 0.5,
+		},
+		{
+			name:           "memory added",
+			inputCurrent:   &JobRegisterRegionTopology{NumClients: 10, CPUAllocatable: 1000, MemoryAllocatable: 5000},
+			expectedChange: 0.25,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedChange, planned.Change(tc.inputCurrent))
+		})
+	}
+
+	must.Eq(t, 1, (&JobRegisterRegionTopology{}).Change(planned))
+}
+
+func TestJobRegisterPlanRun_RegisteredJobModifyIndexes(t *testing.T) {
+	run := NewJobRegisterPlanRun(ulid.Make(), "example", "default")
+	run.AddRegion("euw1", &api.JobRegisterResponse{JobModifyIndex: 10}, nil)
+	run.AddRegion("euw2", nil, errors.New("failed"))
+	run.AddRegion("euw3", &api.JobRegisterResponse{JobModifyIndex: 20}, nil)
+	run.SetRollback("euw3", &JobRegisterRegionRollback{
+		Status: JobRegisterRollbackStatusReverted,
+		Revert: &api.JobRegisterResponse{JobModifyIndex: 25},
+	})
+	run.AddRegion("euw4", &api.JobRegisterResponse{JobModifyIndex: 30}, nil)
+	run.SetRollback("euw4", &JobRegisterRegionRollback{Status: JobRegisterRollbackStatusDeregistered})
+
+	must.Eq(t, map[string]uint64{"euw1": 10, "euw3": 25}, run.RegisteredJobModifyIndexes())
+}
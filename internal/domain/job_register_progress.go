@@ -0,0 +1,49 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+const (
+	// JobRegisterProgressPhaseRegister is reported once the job has been
+	// registered in the region, with a status of "registered" or "failed".
+	JobRegisterProgressPhaseRegister = "register"
+
+	// JobRegisterProgressPhaseHealth is reported with a status of "waiting"
+	// when the run starts waiting for the Nomad deployment of the region, and
+	// again with the health status once the wait has finished.
+	JobRegisterProgressPhaseHealth = "health"
+
+	// JobRegisterProgressPhaseRollback is reported once the region has been
+	// rolled back, with the rollback status.
+	JobRegisterProgressPhaseRollback = "rollback"
+)
+
+const (
+	// JobRegisterProgressStatusRegistered indicates Nomad accepted the
+	// registration of the job.
+	JobRegisterProgressStatusRegistered = "registered"
+
+	// JobRegisterProgressStatusFailed indicates Nomad rejected the
+	// registration of the job, or could not be called.
+	JobRegisterProgressStatusFailed = "failed"
+
+	// JobRegisterProgressStatusWaiting indicates the run is waiting for the
+	// Nomad deployment of the region.
+	JobRegisterProgressStatusWaiting = "waiting"
+)
+
+// JobRegisterRegionProgress is reported each time the run of a single region
+// moves through a phase, allowing callers to follow a run which may take some
+// time, such as one waiting for deployments to become healthy.
+type JobRegisterRegionProgress struct {
+	Region string `json:"region"`
+	Phase  string `json:"phase"`
+	Status string `json:"status"`
+
+	// Error describes why the phase did not succeed.
+	Error string `json:"error,omitempty"`
+}
+
+// JobRegisterProgressFunc is called with each progress update of a run. It can
+// be called concurrently for different regions.
+type JobRegisterProgressFunc func(progress *JobRegisterRegionProgress)
@@ -0,0 +1,169 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// JobRegisterRolloutStrategyAllAtOnce registers the job in every region
+	// within a single stage.
+	JobRegisterRolloutStrategyAllAtOnce = "all-at-once"
+
+	// JobRegisterRolloutStrategySerial registers the job in one region at a
+	// time.
+	JobRegisterRolloutStrategySerial = "serial"
+
+	// JobRegisterRolloutStrategyCanaryThenRest registers the job in the
+	// configured number of canary regions, before registering it in the
+	// remaining regions within a single stage.
+	JobRegisterRolloutStrategyCanaryThenRest = "canary-then-rest"
+
+	// JobRegisterRolloutStrategyBatched registers the job in batches of the
+	// configured size.
+	JobRegisterRolloutStrategyBatched = "batched"
+)
+
+const (
+	// JobRegisterRolloutOnFailureNone leaves regions which were successfully
+	// registered unchanged when the run fails.
+	JobRegisterRolloutOnFailureNone = "none"
+
+	// JobRegisterRolloutOnFailureRollback reverts regions which were
+	// successfully registered to the job version they ran before the run, when
+	// the run fails. The job is deregistered from regions which did not run it
+	// before the run.
+	JobRegisterRolloutOnFailureRollback = "rollback"
+)
+
+// JobRegisterRolloutDefaultDeploymentTimeout is the time each stage waits for
+// its Nomad deployments to complete, when the rollout does not set a timeout.
+const JobRegisterRolloutDefaultDeploymentTimeout = 10 * time.Minute
+
+// JobRegisterRollout controls the order in which regions are registered when a
+// job registration plan is run. Regions are split into stages, and the Nomad
+// deployments of each stage must succeed before the next stage is started.
+type JobRegisterRollout struct {
+
+	// Strategy determines how regions are split into stages. It is one of
+	// "all-at-once", "serial", "canary-then-rest", or "batched", and defaults
+	// to "all-at-once" if not set.
+	Strategy string `json:"strategy"`
+
+	// CanaryRegions is the number of regions within the first stage of the
+	// "canary-then-rest" strategy.
+	CanaryRegions int `json:"canary_regions,omitempty"`
+
+	// BatchSize is the number of regions within each stage of the "batched"
+	// strategy.
+	BatchSize int `json:"batch_size,omitempty"`
+
+	// DeploymentTimeout is the maximum time a stage waits for its Nomad
+	// deployments to complete. It is parsed as a Go duration.
+	DeploymentTimeout string `json:"deployment_timeout,omitempty"`
+
+	// OnFailure controls what happens to the regions which were successfully
+	// registered when the run fails. It is either "none", the default, or
+	// "rollback".
+	OnFailure string `json:"on_failure,omitempty"`
+
+	// WaitForHealthy causes the final stage to also wait for its Nomad
+	// deployments to succeed, so the run only succeeds once every region is
+	// healthy. Stages before the final one always wait.
+	WaitForHealthy bool `json:"wait_for_healthy,omitempty"`
+}
+
+// Validate performs validation of the rollout. It is safe to call without
+// checking whether the rollout is nil, which indicates the default all-at-once
+// strategy.
+func (r *JobRegisterRollout) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+
+	switch r.Strategy {
+	case "", JobRegisterRolloutStrategyAllAtOnce, JobRegisterRolloutStrategySerial:
+	case JobRegisterRolloutStrategyCanaryThenRest:
+		if r.CanaryRegions < 1 {
+			errs = append(errs, errors.New("canary_regions must be at least 1"))
+		}
+	case JobRegisterRolloutStrategyBatched:
+		if r.BatchSize < 1 {
+			errs = append(errs, errors.New("batch_size must be at least 1"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported rollout strategy %q", r.Strategy))
+	}
+
+	switch r.OnFailure {
+	case "", JobRegisterRolloutOnFailureNone, JobRegisterRolloutOnFailureRollback:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported on_failure mode %q", r.OnFailure))
+	}
+
+	if r.DeploymentTimeout != "" {
+		if timeout, err := time.ParseDuration(r.DeploymentTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse deployment_timeout: %w", err))
+		} else if timeout <= 0 {
+			errs = append(errs, errors.New("deployment_timeout must be positive"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeploymentTimeoutDuration returns the parsed deployment timeout, or the
+// default if it is not set. The rollout must have been validated.
+func (r *JobRegisterRollout) DeploymentTimeoutDuration() time.Duration {
+	if r == nil || r.DeploymentTimeout == "" {
+		return JobRegisterRolloutDefaultDeploymentTimeout
+	}
+	timeout, _ := time.ParseDuration(r.DeploymentTimeout)
+	return timeout
+}
+
+// RollbackEnabled is a helper function that informs the caller if regions
+// should be reverted when the run fails.
+func (r *JobRegisterRollout) RollbackEnabled() bool {
+	return r != nil && r.OnFailure == JobRegisterRolloutOnFailureRollback
+}
+
+// WaitForHealthyEnabled is a helper function that informs the caller if the
+// final stage should wait for its Nomad deployments to succeed.
+func (r *JobRegisterRollout) WaitForHealthyEnabled() bool {
+	return r != nil && r.WaitForHealthy
+}
+
+// Stages splits the region names into the stages of the rollout, keeping
+// their order. A nil rollout uses the all-at-once strategy.
+func (r *JobRegisterRollout) Stages(regions []string) [][]string {
+	if len(regions) == 0 {
+		return nil
+	}
+
+	size := len(regions)
+
+	switch {
+	case r == nil:
+	case r.Strategy == JobRegisterRolloutStrategySerial:
+		size = 1
+	case r.Strategy == JobRegisterRolloutStrategyBatched:
+		size = max(r.BatchSize, 1)
+	case r.Strategy == JobRegisterRolloutStrategyCanaryThenRest:
+		if r.CanaryRegions > 0 && r.CanaryRegions < len(regions) {
+			return [][]string{regions[:r.CanaryRegions], regions[r.CanaryRegions:]}
+		}
+	}
+
+	var stages [][]string
+	for start := 0; start < len(regions); start += size {
+		stages = append(stages, regions[start:min(start+size, len(regions))])
+	}
+	return stages
+}
@@ -0,0 +1,120 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestJobRegisterRollout_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		inputRollout  *JobRegisterRollout
+		expectedError string
+	}{
+		{
+			name:         "nil rollout",
+			inputRollout: nil,
+		},
+		{
+			name:         "serial",
+			inputRollout: &JobRegisterRollout{Strategy: JobRegisterRolloutStrategySerial, DeploymentTimeout: "5m"},
+		},
+		{
+			name:          "unknown strategy",
+			inputRollout:  &JobRegisterRollout{Strategy: "random"},
+			expectedError: `unsupported rollout strategy "random"`,
+		},
+		{
+			name:          "canary without regions",
+			inputRollout:  &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyCanaryThenRest},
+			expectedError: "canary_regions must be at least 1",
+		},
+		{
+			name:          "batched without size",
+			inputRollout:  &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyBatched},
+			expectedError: "batch_size must be at least 1",
+		},
+		{
+			name:         "rollback without strategy",
+			inputRollout: &JobRegisterRollout{OnFailure: JobRegisterRolloutOnFailureRollback},
+		},
+		{
+			name:          "unknown on failure mode",
+			inputRollout:  &JobRegisterRollout{OnFailure: "retry"},
+			expectedError: `unsupported on_failure mode "retry"`,
+		},
+		{
+			name: "invalid deployment timeout",
+			inputRollout: &JobRegisterRollout{
+				Strategy:          JobRegisterRolloutStrategyAllAtOnce,
+				DeploymentTimeout: "later",
+			},
+			expectedError: "failed to parse deployment_timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualError := tc.inputRollout.Validate()
+
+			if tc.expectedError != "" {
+				must.ErrorContains(t, actualError, tc.expectedError)
+			} else {
+				must.NoError(t, actualError)
+			}
+		})
+	}
+}
+
+func TestJobRegisterRollout_Stages(t *testing.T) {
+	regions := []string{"a", "b", "c", "d", "e"}
+
+	testCases := []struct {
+		name           string
+		inputRollout   *JobRegisterRollout
+		expectedStages [][]string
+	}{
+		{
+			name:           "nil rollout",
+			inputRollout:   nil,
+			expectedStages: [][]string{{"a", "b", "c", "d", "e"}},
+		},
+		{
+			name:           "all at once",
+			inputRollout:   &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyAllAtOnce},
+			expectedStages: [][]string{{"a", "b", "c", "d", "e"}},
+		},
+		{
+			name:           "serial",
+			inputRollout:   &JobRegisterRollout{Strategy: JobRegisterRolloutStrategySerial},
+			expectedStages: [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}},
+		},
+		{
+			name:           "canary then rest",
+			inputRollout:   &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyCanaryThenRest, CanaryRegions: 2},
+			expectedStages: [][]string{{"a", "b"}, {"c", "d", "e"}},
+		},
+		{
+			name:           "canary covers all regions",
+			inputRollout:   &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyCanaryThenRest, CanaryRegions: 5},
+			expectedStages: [][]string{{"a", "b", "c", "d", "e"}},
+		},
+		{
+			name:           "batched",
+			inputRollout:   &JobRegisterRollout{Strategy: JobRegisterRolloutStrategyBatched, BatchSize: 2},
+			expectedStages: [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedStages, tc.inputRollout.Stages(regions))
+		})
+	}
+
+	must.Nil(t, (&JobRegisterRollout{Strategy: JobRegisterRolloutStrategySerial}).Stages(nil))
+}
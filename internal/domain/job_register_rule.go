@@ -5,6 +5,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
@@ -13,7 +14,12 @@ type JobRegisterRule struct {
 	Name           string                         `json:"name"`
 	RegionContexts []JobRegisterRuleRegionContext `json:"region_contexts"`
 	RegionPickers  []*jobsdk.RegionPickerConfig   `json:"region_pickers"`
-	Metadata       *Metadata                      `json:"metadata"`
+
+	// Rollout controls the order in which the regions picked by the rule are
+	// registered. If not set, all regions are registered at once.
+	Rollout *JobRegisterRollout `json:"rollout,omitempty"`
+
+	Metadata *Metadata `json:"metadata"`
 }
 
 // Validate performs validation of the job registration rule. It is safe
@@ -32,6 +38,10 @@ func (r *JobRegisterRule) Validate() error {
 		}
 	}
 
+	if err := r.Rollout.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("rollout; %w", err))
+	}
+
 	return errors.Join(errs...)
 }
 
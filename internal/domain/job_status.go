@@ -0,0 +1,51 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"github.com/oklog/ulid/v2"
+)
+
+// JobStatus is the status of a job merged across every region Attila manages.
+// It only includes the regions which hold the job, or which the last run of
+// the job registered it in.
+type JobStatus struct {
+	JobID        string                      `json:"job_id"`
+	JobNamespace string                      `json:"job_namespace"`
+	Regions      map[string]*JobStatusRegion `json:"regions"`
+
+	// RunID is the ID of the last job registration run of the job, which the
+	// regions are compared with. It is nil if the job has not been run by
+	// Attila, in which case no region is flagged.
+	RunID *ulid.ULID `json:"run_id,omitempty"`
+}
+
+// JobStatusRegion is the status of a job within a single region.
+type JobStatusRegion struct {
+	Region string `json:"region"`
+
+	// JobStatus and JobVersion are empty if the job is not held by the region.
+	JobStatus  string  `json:"job_status,omitempty"`
+	JobVersion *uint64 `json:"job_version,omitempty"`
+
+	// Allocations is the number of allocations of the job, keyed by their
+	// client status.
+	Allocations map[string]int `json:"allocations,omitempty"`
+
+	// DeploymentID and DeploymentStatus describe the latest Nomad deployment
+	// of the job. They are empty if the job has not created a deployment.
+	DeploymentID     string `json:"deployment_id,omitempty"`
+	DeploymentStatus string `json:"deployment_status,omitempty"`
+
+	// Missing indicates the last run registered the job in the region, but
+	// the region no longer holds it.
+	Missing bool `json:"missing"`
+
+	// Mismatch indicates the job held by the region has been modified since
+	// the last run, or the last run did not register the job in the region.
+	Mismatch bool `json:"mismatch"`
+
+	// Error describes why the status of the region could not be read.
+	Error string `json:"error,omitempty"`
+}
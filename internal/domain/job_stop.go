@@ -0,0 +1,85 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package domain
+
+import (
+	"github.com/hashicorp/nomad/api"
+	"github.com/oklog/ulid/v2"
+)
+
+// JobStop is the outcome of stopping a job within every region which holds
+// it. Each region is reported in the same way as a job registration run, so a
+// partial failure details which regions were stopped.
+type JobStop struct {
+	ID           ulid.ULID                 `json:"id"`
+	JobID        string                    `json:"job_id"`
+	JobNamespace string                    `json:"job_namespace"`
+	Regions      map[string]*JobStopRegion `json:"regions"`
+
+	// Purge indicates the job is purged from each region, rather than being
+	// stopped and left for Nomad to garbage collect.
+	Purge bool `json:"purge"`
+
+	// Plan indicates the regions holding the job were found, but the job was
+	// not stopped.
+	Plan bool `json:"plan"`
+
+	// Error describes why the stop failed. It is set when the stop partially
+	// failed, so the outcome is reported alongside the regional results.
+	Error string `json:"error,omitempty"`
+
+	Metadata *Metadata `json:"metadata"`
+}
+
+type JobStopRegion struct {
+	Region string `json:"region"`
+
+	// JobVersion and JobStatus describe the job held by the region before it
+	// was stopped.
+	JobVersion *uint64 `json:"job_version,omitempty"`
+	JobStatus  string  `json:"job_status"`
+
+	// EvalID is the ID of the Nomad evaluation created by stopping the job. It
+	// is not set when the stop was planned, or the call failed.
+	EvalID string `json:"eval_id,omitempty"`
+
+	// Error describes why the job could not be stopped in the region.
+	Error string `json:"error,omitempty"`
+}
+
+func NewJobStop(jobID, jobNamespace string, purge, plan bool) *JobStop {
+	return &JobStop{
+		ID:           ulid.Make(),
+		JobID:        jobID,
+		JobNamespace: jobNamespace,
+		Regions:      make(map[string]*JobStopRegion),
+		Purge:        purge,
+		Plan:         plan,
+		Metadata:     NewMetadata(),
+	}
+}
+
+// AddRegion adds a region which holds the job to the stop.
+func (j *JobStop) AddRegion(regionName string, job *api.Job) {
+	regionStop := JobStopRegion{Region: regionName, JobVersion: job.Version}
+	if job.Status != nil {
+		regionStop.JobStatus = *job.Status
+	}
+	j.Regions[regionName] = &regionStop
+}
+
+// SetStop records the outcome of stopping the job within a region which has
+// already been added to the stop.
+func (j *JobStop) SetStop(regionName, evalID string, err error) {
+	regionStop, ok := j.Regions[regionName]
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		regionStop.Error = err.Error()
+	} else {
+		regionStop.EvalID = evalID
+	}
+}
@@ -8,6 +8,11 @@ import "time"
 type Metadata struct {
 	CreateTime time.Time `json:"create_time"`
 	UpdateTime time.Time `json:"update_time"`
+
+	// ModifyIndex is the state index at which the object was last written. It
+	// is set by the state backend and increases monotonically, so it can be
+	// used to perform check-and-set writes.
+	ModifyIndex uint64 `json:"modify_index"`
 }
 
 func NewMetadata() *Metadata {
@@ -17,3 +22,50 @@ func NewMetadata() *Metadata {
 		UpdateTime: t,
 	}
 }
+
+// Updated returns a copy of the metadata with the update time set to the
+// current time, while retaining the original create time. It is safe to call
+// on a nil object, in which case fresh metadata is returned.
+func (m *Metadata) Updated() *Metadata { return m.UpdatedAt(time.Time{}) }
+
+// UpdatedAt returns a copy of the metadata with the update time set to the
+// passed time, or the current time if it is zero. It is safe to call on a nil
+// object, in which case fresh metadata is returned using the same time for
+// the create and update times.
+func (m *Metadata) UpdatedAt(t time.Time) *Metadata {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	if m == nil {
+		return &Metadata{CreateTime: t, UpdateTime: t}
+	}
+
+	updated := *m
+	updated.UpdateTime = t
+	return &updated
+}
+
+// WithModifyIndex returns a copy of the metadata with the modify index set to
+// the passed value. It is safe to call on a nil object, in which case fresh
+// metadata is returned with the index set.
+func (m *Metadata) WithModifyIndex(index uint64) *Metadata {
+	var indexed Metadata
+
+	if m == nil {
+		indexed = *NewMetadata()
+	} else {
+		indexed = *m
+	}
+
+	indexed.ModifyIndex = index
+	return &indexed
+}
+
+// GetModifyIndex returns the modify index of the metadata. It is safe to call
+// on a nil object, in which case zero is returned.
+func (m *Metadata) GetModifyIndex() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.ModifyIndex
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/hashicorp/nomad/api"
 )
 
+// RegionRedactedValue replaces secret region fields within API responses.
+// Secrets are write-only, so a client which reads a region and writes it back
+// without modification can send this value to keep the stored secret.
+const RegionRedactedValue = "<redacted>"
+
 type Region struct {
 	Name     string       `json:"name"`
 	Group    string       `json:"group"`
@@ -149,6 +154,53 @@ func (r *Region) GenerateNomadClient() (*api.Client, error) {
 	return api.NewClient(&cfg)
 }
 
+// Redacted returns a copy of the region with all secret fields replaced by the
+// redacted marker, so it is safe to return to callers and publish within
+// events. Secrets which are not set are left empty, so callers can still
+// identify whether they are configured.
+func (r *Region) Redacted() *Region {
+	if r == nil {
+		return nil
+	}
+
+	redacted := *r
+
+	if r.Auth != nil {
+		auth := *r.Auth
+		if auth.Token != "" {
+			auth.Token = RegionRedactedValue
+		}
+		redacted.Auth = &auth
+	}
+
+	if r.TLS != nil {
+		tls := *r.TLS
+		if tls.ClientKey != "" {
+			tls.ClientKey = RegionRedactedValue
+		}
+		redacted.TLS = &tls
+	}
+
+	return &redacted
+}
+
+// RestoreRedacted replaces any secret fields which hold the redacted marker
+// with the value from the existing region. This allows a redacted region to be
+// read, modified, and written back without losing the stored secrets.
+func (r *Region) RestoreRedacted(existing *Region) {
+	if r == nil || existing == nil {
+		return
+	}
+
+	if r.Auth != nil && r.Auth.Token == RegionRedactedValue && existing.Auth != nil {
+		r.Auth.Token = existing.Auth.Token
+	}
+
+	if r.TLS != nil && r.TLS.ClientKey == RegionRedactedValue && existing.TLS != nil {
+		r.TLS.ClientKey = existing.TLS.ClientKey
+	}
+}
+
 func (r *Region) Stub() *RegionStub {
 	addrs := make([]string, len(r.API))
 	for i, addr := range r.API {
@@ -156,16 +208,18 @@ func (r *Region) Stub() *RegionStub {
 	}
 
 	return &RegionStub{
-		Name:       r.Name,
-		Group:      r.Group,
-		Addresses:  addrs,
-		TLSEnabled: r.TLS != nil,
+		Name:        r.Name,
+		Group:       r.Group,
+		Addresses:   addrs,
+		AuthEnabled: r.Auth != nil && r.Auth.Token != "",
+		TLSEnabled:  r.TLS != nil,
 	}
 }
 
 type RegionStub struct {
-	Name       string   `json:"name"`
-	Group      string   `json:"group"`
-	Addresses  []string `json:"addresses"`
-	TLSEnabled bool     `json:"tls_enabled"`
+	Name        string   `json:"name"`
+	Group       string   `json:"group"`
+	Addresses   []string `json:"addresses"`
+	AuthEnabled bool     `json:"auth_enabled"`
+	TLSEnabled  bool     `json:"tls_enabled"`
 }
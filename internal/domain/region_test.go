@@ -127,3 +127,32 @@ func TestRegion_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestRegion_Redacted(t *testing.T) {
+	region := &Region{
+		Name: "euw1",
+		Auth: &RegionAuth{Token: "secret-token"},
+		TLS:  &RegionTLS{CACert: "ca", ClientCert: "cert", ClientKey: "key"},
+	}
+
+	redacted := region.Redacted()
+	must.Eq(t, RegionRedactedValue, redacted.Auth.Token)
+	must.Eq(t, RegionRedactedValue, redacted.TLS.ClientKey)
+	must.Eq(t, "ca", redacted.TLS.CACert)
+	must.Eq(t, "cert", redacted.TLS.ClientCert)
+
+	// The original region must not be modified.
+	must.Eq(t, "secret-token", region.Auth.Token)
+	must.Eq(t, "key", region.TLS.ClientKey)
+
+	// Restoring the redacted region should result in the original secrets.
+	redacted.RestoreRedacted(region)
+	must.Eq(t, region, redacted)
+
+	// Secrets which are not set should not be replaced by the marker, so it
+	// is clear they are not configured.
+	unset := (&Region{Name: "euw1", Auth: &RegionAuth{}}).Redacted()
+	must.Eq(t, "", unset.Auth.Token)
+	must.Nil(t, unset.TLS)
+	must.False(t, unset.Stub().AuthEnabled)
+}
@@ -0,0 +1,144 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// subscriptionBufferSize is the number of events buffered for each subscriber.
+// A subscriber which falls this far behind is closed, so a slow consumer
+// cannot block publishers.
+const subscriptionBufferSize = 128
+
+// Broker fans out published events to all interested subscribers.
+type Broker struct {
+	logger *zap.Logger
+
+	// subs tracks all active subscriptions. Access should use the lock for
+	// concurrent safety.
+	subs     map[*Subscription]struct{}
+	subsLock sync.RWMutex
+}
+
+func NewBroker(logger *zap.Logger) *Broker {
+	return &Broker{
+		logger: logger.Named("event_broker"),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends the events to all subscriptions which match them. It never
+// blocks; a subscription which cannot keep up is closed.
+func (b *Broker) Publish(events ...*Event) {
+	b.subsLock.RLock()
+	defer b.subsLock.RUnlock()
+
+	for _, e := range events {
+		if e.Time.IsZero() {
+			e.Time = time.Now().UTC()
+		}
+
+		for sub := range b.subs {
+			if !sub.matches(e) {
+				continue
+			}
+
+			select {
+			case sub.eventCh <- e:
+			default:
+				b.logger.Warn("closing slow event subscription",
+					zap.Int("buffer_size", subscriptionBufferSize))
+				go sub.Close()
+			}
+		}
+	}
+}
+
+// Shutdown closes all active subscriptions, which allows long-lived stream
+// handlers to return.
+func (b *Broker) Shutdown() {
+	b.subsLock.RLock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.subsLock.RUnlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// SubscribeReq details the events a subscriber wants to receive.
+type SubscribeReq struct {
+
+	// Topics maps each topic to the keys the subscriber is interested in. An
+	// empty map subscribes to all events, while TopicAll and KeyAll can be used
+	// as wildcards. A topic with no keys matches all keys.
+	Topics map[Topic][]string
+}
+
+// Subscribe creates a new subscription. The caller must call Close once it no
+// longer wants to receive events.
+func (b *Broker) Subscribe(req *SubscribeReq) *Subscription {
+	sub := Subscription{
+		broker:  b,
+		topics:  req.Topics,
+		eventCh: make(chan *Event, subscriptionBufferSize),
+	}
+
+	b.subsLock.Lock()
+	b.subs[&sub] = struct{}{}
+	b.subsLock.Unlock()
+
+	return &sub
+}
+
+// Subscription receives the events which match its filter.
+type Subscription struct {
+	broker    *Broker
+	topics    map[Topic][]string
+	eventCh   chan *Event
+	closeOnce sync.Once
+}
+
+// Events returns the channel on which matching events are received. The
+// channel is closed when the subscription is closed, either by the caller or
+// by the broker because the subscriber could not keep up.
+func (s *Subscription) Events() <-chan *Event { return s.eventCh }
+
+// Close removes the subscription from the broker and closes the events
+// channel. It is safe to call multiple times.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.broker.subsLock.Lock()
+		delete(s.broker.subs, s)
+		s.broker.subsLock.Unlock()
+
+		close(s.eventCh)
+	})
+}
+
+func (s *Subscription) matches(e *Event) bool {
+	if len(s.topics) == 0 {
+		return true
+	}
+
+	for _, topic := range []Topic{e.Topic, TopicAll} {
+		keys, ok := s.topics[topic]
+		if !ok {
+			continue
+		}
+		if len(keys) == 0 || slices.Contains(keys, KeyAll) || slices.Contains(keys, e.Key) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+)
+
+func TestBroker_Subscribe(t *testing.T) {
+	broker := NewBroker(zap.NewNop())
+
+	allSub := broker.Subscribe(&SubscribeReq{})
+	defer allSub.Close()
+
+	regionSub := broker.Subscribe(&SubscribeReq{Topics: map[Topic][]string{TopicRegion: {KeyAll}}})
+	defer regionSub.Close()
+
+	keySub := broker.Subscribe(&SubscribeReq{Topics: map[Topic][]string{
+		TopicAll:            {"foo"},
+		TopicJobRegisterRun: {"bar"},
+	}})
+	defer keySub.Close()
+
+	broker.Publish(
+		&Event{Topic: TopicRegion, Type: TypeCreated, Key: "foo"},
+		&Event{Topic: TopicJobRegisterPlan, Type: TypeCreated, Key: "bar"},
+		&Event{Topic: TopicJobRegisterRun, Type: TypeFinished, Key: "bar"},
+	)
+
+	testCases := []struct {
+		name          string
+		sub           *Subscription
+		expectedTopic []Topic
+	}{
+		{
+			name:          "all",
+			sub:           allSub,
+			expectedTopic: []Topic{TopicRegion, TopicJobRegisterPlan, TopicJobRegisterRun},
+		},
+		{
+			name:          "topic",
+			sub:           regionSub,
+			expectedTopic: []Topic{TopicRegion},
+		},
+		{
+			name:          "key",
+			sub:           keySub,
+			expectedTopic: []Topic{TopicRegion, TopicJobRegisterRun},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, len(tc.expectedTopic), len(tc.sub.eventCh))
+
+			for _, topic := range tc.expectedTopic {
+				e := <-tc.sub.Events()
+				must.Eq(t, topic, e.Topic)
+				must.False(t, e.Time.IsZero())
+			}
+		})
+	}
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	broker := NewBroker(zap.NewNop())
+
+	sub := broker.Subscribe(&SubscribeReq{})
+
+	for range subscriptionBufferSize + 1 {
+		broker.Publish(&Event{Topic: TopicRegion, Type: TypeCreated, Key: "foo"})
+	}
+
+	// The buffered events can still be read, after which the channel is
+	// closed, as the subscriber was unable to keep up.
+	var received int
+	for range sub.Events() {
+		received++
+	}
+	must.Eq(t, subscriptionBufferSize, received)
+
+	broker.subsLock.RLock()
+	must.MapEmpty(t, broker.subs)
+	broker.subsLock.RUnlock()
+}
+
+func TestBroker_Shutdown(t *testing.T) {
+	broker := NewBroker(zap.NewNop())
+
+	sub := broker.Subscribe(&SubscribeReq{})
+	broker.Shutdown()
+
+	_, ok := <-sub.Events()
+	must.False(t, ok)
+
+	// Closing the subscription after shutdown should be a no-op.
+	sub.Close()
+}
@@ -0,0 +1,64 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/server/nomad"
+	"github.com/rasorp/attila/internal/store"
+)
+
+// Controller wraps a nomad.Controller and publishes an event to the broker each
+// time a job registration run makes progress or finishes.
+type Controller struct {
+	nomad.Controller
+	broker *Broker
+}
+
+func NewController(controller nomad.Controller, broker *Broker) nomad.Controller {
+	return &Controller{Controller: controller, broker: broker}
+}
+
+// JobRegistrationRun performs the run using the wrapped controller. A progress
+// event keyed by the plan ID is published as each region moves through the run,
+// before being passed to the progress function if it is set. A finished event
+// is published whenever a run result is available, which includes partial
+// failures.
+func (c *Controller) JobRegistrationRun(
+	ctx context.Context,
+	planID ulid.ULID,
+	job *api.Job,
+	rollout *domain.JobRegisterRollout,
+	progress domain.JobRegisterProgressFunc,
+	state store.State,
+) (*domain.JobRegisterPlanRun, error) {
+
+	publishProgress := func(regionProgress *domain.JobRegisterRegionProgress) {
+		c.broker.Publish(&Event{
+			Topic:   TopicJobRegisterRun,
+			Type:    TypeProgress,
+			Key:     planID.String(),
+			Payload: regionProgress,
+		})
+		if progress != nil {
+			progress(regionProgress)
+		}
+	}
+
+	run, err := c.Controller.JobRegistrationRun(ctx, planID, job, rollout, publishProgress, state)
+	if run != nil {
+		c.broker.Publish(&Event{
+			Topic:   TopicJobRegisterRun,
+			Type:    TypeFinished,
+			Key:     planID.String(),
+			Payload: run,
+		})
+	}
+	return run, err
+}
@@ -0,0 +1,70 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// Topic identifies the type of object an event relates to.
+type Topic string
+
+const (
+	TopicRegion            Topic = "Region"
+	TopicJobRegisterMethod Topic = "JobRegisterMethod"
+	TopicJobRegisterRule   Topic = "JobRegisterRule"
+	TopicJobRegisterPlan   Topic = "JobRegisterPlan"
+	TopicJobRegisterRun    Topic = "JobRegisterRun"
+
+	// TopicAll can be used when subscribing to receive events for all topics.
+	TopicAll Topic = "*"
+)
+
+// KeyAll can be used when subscribing to receive events for all keys of a
+// topic.
+const KeyAll = "*"
+
+// ParseTopic converts the passed string into a topic, returning an error if
+// it is not a known topic.
+func ParseTopic(s string) (Topic, error) {
+	switch topic := Topic(s); topic {
+	case TopicAll, TopicRegion, TopicJobRegisterMethod, TopicJobRegisterRule,
+		TopicJobRegisterPlan, TopicJobRegisterRun:
+		return topic, nil
+	default:
+		return "", fmt.Errorf("unknown event topic %q", s)
+	}
+}
+
+// Type identifies the action which caused the event.
+type Type string
+
+const (
+	TypeCreated  Type = "Created"
+	TypeUpdated  Type = "Updated"
+	TypeDeleted  Type = "Deleted"
+	TypeFinished Type = "Finished"
+
+	// TypeProgress is used for events which report the progress of a job
+	// registration run, before it has finished.
+	TypeProgress Type = "Progress"
+)
+
+// Event is a single change published to subscribers.
+type Event struct {
+	Topic Topic     `json:"topic"`
+	Type  Type      `json:"type"`
+	Key   string    `json:"key"`
+	Time  time.Time `json:"time"`
+
+	// Index is the modify index of the object at the time of the event. It is
+	// not set for events which do not relate to a state write, or where the
+	// object has been deleted.
+	Index uint64 `json:"index,omitempty"`
+
+	// Payload is the object the event relates to. It is not set when the
+	// object has been deleted.
+	Payload any `json:"payload,omitempty"`
+}
@@ -0,0 +1,225 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"github.com/rasorp/attila/internal/store"
+)
+
+// State wraps a store.State and publishes an event to the broker for every
+// successful write. Reads are passed directly to the wrapped state.
+type State struct {
+	store.State
+	broker *Broker
+}
+
+// replicatedState is returned by NewState when the wrapped state is
+// replicated, so callers can continue to detect this via a type assertion.
+type replicatedState struct {
+	*State
+	store.Replicated
+}
+
+// NewState wraps the passed state, so writes are published to the broker.
+func NewState(state store.State, broker *Broker) store.State {
+	s := State{State: state, broker: broker}
+
+	if replicated, ok := state.(store.Replicated); ok {
+		return &replicatedState{State: &s, Replicated: replicated}
+	}
+	return &s
+}
+
+func (s *State) Region() store.RegionState {
+	return &regionState{RegionState: s.State.Region(), broker: s.broker}
+}
+
+func (s *State) JobRegister() store.JobRegisterState {
+	return &jobRegisterState{JobRegisterState: s.State.JobRegister(), broker: s.broker}
+}
+
+// regionState publishes region events with secrets redacted, as subscribers
+// cannot request them in the same way as API callers.
+type regionState struct {
+	store.RegionState
+	broker *Broker
+}
+
+func (r *regionState) Create(req *store.RegionCreateReq) (*store.RegionCreateResp, *store.ErrorResp) {
+	resp, errResp := r.RegionState.Create(req)
+	if errResp == nil {
+		r.broker.Publish(&Event{
+			Topic:   TopicRegion,
+			Type:    TypeCreated,
+			Key:     resp.Region.Name,
+			Index:   resp.Region.Metadata.GetModifyIndex(),
+			Payload: resp.Region.Redacted(),
+		})
+	}
+	return resp, errResp
+}
+
+func (r *regionState) Delete(req *store.RegionDeleteReq) (*store.RegionDeleteResp, *store.ErrorResp) {
+	resp, errResp := r.RegionState.Delete(req)
+	if errResp == nil {
+		r.broker.Publish(&Event{Topic: TopicRegion, Type: TypeDeleted, Key: req.RegionName})
+	}
+	return resp, errResp
+}
+
+func (r *regionState) Update(req *store.RegionUpdateReq) (*store.RegionUpdateResp, *store.ErrorResp) {
+	resp, errResp := r.RegionState.Update(req)
+	if errResp == nil {
+		r.broker.Publish(&Event{
+			Topic:   TopicRegion,
+			Type:    TypeUpdated,
+			Key:     resp.Region.Name,
+			Index:   resp.Region.Metadata.GetModifyIndex(),
+			Payload: resp.Region.Redacted(),
+		})
+	}
+	return resp, errResp
+}
+
+type jobRegisterState struct {
+	store.JobRegisterState
+	broker *Broker
+}
+
+func (j *jobRegisterState) Method() store.JobRegisterMethodState {
+	return &jobRegisterMethodState{JobRegisterMethodState: j.JobRegisterState.Method(), broker: j.broker}
+}
+
+func (j *jobRegisterState) Plan() store.JobRegisterPlanState {
+	return &jobRegisterPlanState{JobRegisterPlanState: j.JobRegisterState.Plan(), broker: j.broker}
+}
+
+func (j *jobRegisterState) Rule() store.JobRegisterRuleState {
+	return &jobRegisterRuleState{JobRegisterRuleState: j.JobRegisterState.Rule(), broker: j.broker}
+}
+
+type jobRegisterMethodState struct {
+	store.JobRegisterMethodState
+	broker *Broker
+}
+
+func (j *jobRegisterMethodState) Create(
+	req *store.JobRegisterMethodCreateReq) (*store.JobRegisterMethodCreateResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterMethodState.Create(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{
+			Topic:   TopicJobRegisterMethod,
+			Type:    TypeCreated,
+			Key:     resp.Method.Name,
+			Index:   resp.Method.Metadata.GetModifyIndex(),
+			Payload: resp.Method,
+		})
+	}
+	return resp, errResp
+}
+
+func (j *jobRegisterMethodState) Delete(
+	req *store.JobRegisterMethodDeleteReq) (*store.JobRegisterMethodDeleteResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterMethodState.Delete(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{Topic: TopicJobRegisterMethod, Type: TypeDeleted, Key: req.Name})
+	}
+	return resp, errResp
+}
+
+func (j *jobRegisterMethodState) Update(
+	req *store.JobRegisterMethodUpdateReq) (*store.JobRegisterMethodUpdateResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterMethodState.Update(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{
+			Topic:   TopicJobRegisterMethod,
+			Type:    TypeUpdated,
+			Key:     resp.Method.Name,
+			Index:   resp.Method.Metadata.GetModifyIndex(),
+			Payload: resp.Method,
+		})
+	}
+	return resp, errResp
+}
+
+type jobRegisterRuleState struct {
+	store.JobRegisterRuleState
+	broker *Broker
+}
+
+func (j *jobRegisterRuleState) Create(
+	req *store.JobRegisterRuleCreateReq) (*store.JobRegisterRuleCreateResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterRuleState.Create(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{
+			Topic:   TopicJobRegisterRule,
+			Type:    TypeCreated,
+			Key:     resp.Rule.Name,
+			Index:   resp.Rule.Metadata.GetModifyIndex(),
+			Payload: resp.Rule,
+		})
+	}
+	return resp, errResp
+}
+
+func (j *jobRegisterRuleState) Delete(
+	req *store.JobRegisterRuleDeleteReq) (*store.JobRegisterRuleDeleteResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterRuleState.Delete(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{Topic: TopicJobRegisterRule, Type: TypeDeleted, Key: req.Name})
+	}
+	return resp, errResp
+}
+
+func (j *jobRegisterRuleState) Update(
+	req *store.JobRegisterRuleUpdateReq) (*store.JobRegisterRuleUpdateResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterRuleState.Update(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{
+			Topic:   TopicJobRegisterRule,
+			Type:    TypeUpdated,
+			Key:     resp.Rule.Name,
+			Index:   resp.Rule.Metadata.GetModifyIndex(),
+			Payload: resp.Rule,
+		})
+	}
+	return resp, errResp
+}
+
+type jobRegisterPlanState struct {
+	store.JobRegisterPlanState
+	broker *Broker
+}
+
+func (j *jobRegisterPlanState) Create(
+	req *store.JobRegisterPlanCreateReq) (*store.JobRegisterPlanCreateResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterPlanState.Create(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{
+			Topic:   TopicJobRegisterPlan,
+			Type:    TypeCreated,
+			Key:     resp.Plan.ID.String(),
+			Index:   resp.Plan.Metadata.GetModifyIndex(),
+			Payload: resp.Plan,
+		})
+	}
+	return resp, errResp
+}
+
+func (j *jobRegisterPlanState) Delete(
+	req *store.JobRegisterPlanDeleteReq) (*store.JobRegisterPlanDeleteResp, *store.ErrorResp) {
+
+	resp, errResp := j.JobRegisterPlanState.Delete(req)
+	if errResp == nil {
+		j.broker.Publish(&Event{Topic: TopicJobRegisterPlan, Type: TypeDeleted, Key: req.ID.String()})
+	}
+	return resp, errResp
+}
@@ -0,0 +1,69 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+func TestState(t *testing.T) {
+	broker := NewBroker(zap.NewNop())
+
+	sub := broker.Subscribe(&SubscribeReq{})
+	defer sub.Close()
+
+	memState, err := mem.New()
+	must.NoError(t, err)
+
+	state := NewState(memState, broker)
+
+	mockRegion := mock.Region()
+
+	_, errResp := state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	e := <-sub.Events()
+	must.Eq(t, TopicRegion, e.Topic)
+	must.Eq(t, TypeCreated, e.Type)
+	must.Eq(t, mockRegion.Name, e.Key)
+	must.Eq(t, 1, e.Index)
+	must.Eq(t, any(mockRegion.Redacted()), e.Payload)
+	must.Eq(t, domain.RegionRedactedValue, e.Payload.(*domain.Region).Auth.Token)
+
+	// Failed writes should not publish an event.
+	_, errResp = state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.NotNil(t, errResp)
+	must.Eq(t, 0, len(sub.eventCh))
+
+	mockPlan := mock.JobRegistrationPlan()
+
+	_, errResp = state.JobRegister().Plan().Create(&store.JobRegisterPlanCreateReq{Plan: mockPlan})
+	must.Nil(t, errResp)
+	_, errResp = state.JobRegister().Plan().Delete(&store.JobRegisterPlanDeleteReq{ID: mockPlan.ID})
+	must.Nil(t, errResp)
+
+	e = <-sub.Events()
+	must.Eq(t, TopicJobRegisterPlan, e.Topic)
+	must.Eq(t, TypeCreated, e.Type)
+	must.Eq(t, mockPlan.ID.String(), e.Key)
+
+	e = <-sub.Events()
+	must.Eq(t, TopicJobRegisterPlan, e.Topic)
+	must.Eq(t, TypeDeleted, e.Type)
+	must.Nil(t, e.Payload)
+
+	// Reads should pass through to the wrapped state.
+	getResp, errResp := state.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion.Name, getResp.Region.Name)
+	must.Eq(t, 0, len(sub.eventCh))
+}
@@ -0,0 +1,153 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// ModeFailFast stops starting calls once any call has failed and returns
+	// the first failure.
+	ModeFailFast = "fail_fast"
+
+	// ModeCollectAll runs every call regardless of failures and returns all
+	// of them.
+	ModeCollectAll = "collect_all"
+)
+
+const (
+	defaultWorkers       = 8
+	defaultRegionTimeout = time.Minute
+)
+
+// Config is the fan-out configuration block. It controls how Attila calls
+// Nomad regions in parallel when building region contexts, planning, and
+// registering jobs.
+type Config struct {
+
+	// Workers is the maximum number of regions called at the same time for a
+	// single plan or run.
+	Workers *int `hcl:"workers,optional"`
+
+	// RegionTimeout is the maximum time a single region call can take. It is
+	// parsed as a Go duration.
+	RegionTimeout string `hcl:"region_timeout,optional"`
+
+	// Mode controls how region failures are handled and is either "fail_fast"
+	// or "collect_all".
+	Mode string `hcl:"mode,optional"`
+}
+
+// DefaultConfig returns the default fan-out config.
+func DefaultConfig() *Config {
+	return &Config{
+		Workers:       new(defaultWorkers),
+		RegionTimeout: defaultRegionTimeout.String(),
+		Mode:          ModeFailFast,
+	}
+}
+
+// Validate performs validation of the fan-out configuration block. The
+// function can be called safely without checking if the object is nil.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if c.Workers != nil && *c.Workers < 1 {
+		errs = append(errs, fmt.Errorf("fan_out workers must be at least 1, got %v", *c.Workers))
+	}
+
+	if c.RegionTimeout != "" {
+		if timeout, err := time.ParseDuration(c.RegionTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse fan_out region_timeout: %w", err))
+		} else if timeout <= 0 {
+			errs = append(errs, errors.New("fan_out region_timeout must be positive"))
+		}
+	}
+
+	switch c.Mode {
+	case "", ModeFailFast, ModeCollectAll:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported fan_out mode %q", c.Mode))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) Merge(z *Config) *Config {
+
+	if c == nil {
+		return z
+	}
+	if z == nil {
+		return c
+	}
+
+	result := *c
+
+	if z.Workers != nil {
+		result.Workers = z.Workers
+	}
+	if z.RegionTimeout != "" {
+		result.RegionTimeout = z.RegionTimeout
+	}
+	if z.Mode != "" {
+		result.Mode = z.Mode
+	}
+
+	return &result
+}
+
+// CollectAll is a helper function that informs the caller if all calls should
+// be run regardless of failures.
+func (c *Config) CollectAll() bool {
+	return c != nil && c.Mode == ModeCollectAll
+}
+
+// WithRegionTimeout returns a copy of the config which uses the passed region
+// timeout. This allows calls which are expected to take longer than a typical
+// Nomad API call, such as waiting on a deployment, to share the other settings.
+func (c *Config) WithRegionTimeout(timeout time.Duration) *Config {
+	var result Config
+	if c != nil {
+		result = *c
+	}
+	result.RegionTimeout = timeout.String()
+	return &result
+}
+
+// WithMode returns a copy of the config which uses the passed mode. This allows
+// calls which must always be attempted, such as rolling back regions, to share
+// the other settings.
+func (c *Config) WithMode(mode string) *Config {
+	var result Config
+	if c != nil {
+		result = *c
+	}
+	result.Mode = mode
+	return &result
+}
+
+func (c *Config) workers() int {
+	if c == nil || c.Workers == nil || *c.Workers < 1 {
+		return defaultWorkers
+	}
+	return *c.Workers
+}
+
+func (c *Config) regionTimeout() time.Duration {
+	if c == nil {
+		return defaultRegionTimeout
+	}
+	if timeout, err := time.ParseDuration(c.RegionTimeout); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultRegionTimeout
+}
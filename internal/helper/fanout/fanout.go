@@ -0,0 +1,99 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fanout runs calls against multiple Nomad regions in parallel, with a
+// bounded number of workers and a timeout for each region.
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Func is called once for each index passed to Run. The context is cancelled
+// once the region timeout has elapsed.
+type Func func(ctx context.Context, i int) error
+
+// Run calls fn once for each index from 0 to n-1, using at most the configured
+// number of workers. Callers should store the result of each call by index,
+// so the outcome does not depend on the order in which calls complete.
+//
+// In fail-fast mode, no further calls are started once any call has failed,
+// although those already running are allowed to finish. The failure with the
+// lowest index is returned. In collect-all mode, every call is run and all
+// failures are joined in index order.
+func Run(ctx context.Context, cfg *Config, n int, fn Func) error {
+	if n == 0 {
+		return nil
+	}
+
+	var (
+		collectAll = cfg.CollectAll()
+		timeout    = cfg.regionTimeout()
+		errs       = make([]error, n)
+
+		lock   sync.Mutex
+		next   int
+		failed bool
+		wg     sync.WaitGroup
+	)
+
+	// claim returns the next index to call, or false if there are no more
+	// indexes or a failure means no more calls should be started.
+	claim := func() (int, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if next >= n || (failed && !collectAll) {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+
+	for range min(cfg.workers(), n) {
+		wg.Go(func() {
+			for {
+				i, ok := claim()
+				if !ok {
+					return
+				}
+				if err := call(ctx, timeout, i, fn); err != nil {
+					lock.Lock()
+					errs[i] = err
+					failed = true
+					lock.Unlock()
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if collectAll {
+		return errors.Join(errs...)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func call(ctx context.Context, timeout time.Duration, i int, fn Func) error {
+
+	// Do not start calls once the parent context has been cancelled, such as
+	// when the HTTP client has gone away.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(ctx, i)
+}
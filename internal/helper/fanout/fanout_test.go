@@ -0,0 +1,126 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestRun(t *testing.T) {
+	cfg := &Config{Workers: new(3)}
+
+	var (
+		running    atomic.Int32
+		maxRunning atomic.Int32
+	)
+
+	results := make([]int, 10)
+
+	err := Run(context.Background(), cfg, len(results), func(_ context.Context, i int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		results[i] = i * i
+		return nil
+	})
+	must.NoError(t, err)
+	must.Eq(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+	must.LessEq(t, 3, maxRunning.Load())
+	must.Greater(t, 1, maxRunning.Load())
+}
+
+func TestRun_failFast(t *testing.T) {
+	cfg := &Config{Workers: new(1), Mode: ModeFailFast}
+
+	var calls atomic.Int32
+
+	err := Run(context.Background(), cfg, 5, func(_ context.Context, i int) error {
+		calls.Add(1)
+		if i == 1 {
+			return errors.New("region 1 failed")
+		}
+		return nil
+	})
+	must.EqError(t, err, "region 1 failed")
+	must.Eq(t, 2, calls.Load())
+}
+
+func TestRun_collectAll(t *testing.T) {
+	cfg := &Config{Workers: new(4), Mode: ModeCollectAll}
+
+	var calls atomic.Int32
+
+	err := Run(context.Background(), cfg, 5, func(_ context.Context, i int) error {
+		calls.Add(1)
+
+		// Fail the later region first, to check errors are ordered by index
+		// rather than completion.
+		if i == 3 {
+			return fmt.Errorf("region %d failed", i)
+		}
+		if i == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return fmt.Errorf("region %d failed", i)
+		}
+		return nil
+	})
+	must.EqError(t, err, "region 1 failed\nregion 3 failed")
+	must.Eq(t, 5, calls.Load())
+}
+
+func TestRun_regionTimeout(t *testing.T) {
+	cfg := &Config{RegionTimeout: "10ms"}
+
+	err := Run(context.Background(), cfg, 2, func(ctx context.Context, i int) error {
+		if i == 0 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	must.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRun_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Run(ctx, nil, 3, func(context.Context, int) error {
+		t.Fatal("call should not be started")
+		return nil
+	})
+	must.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	must.NoError(t, DefaultConfig().Validate())
+	must.NoError(t, (*Config)(nil).Validate())
+
+	cfg := &Config{Workers: new(0), RegionTimeout: "soon", Mode: "eventually"}
+	err := cfg.Validate()
+	must.ErrorContains(t, err, "fan_out workers must be at least 1")
+	must.ErrorContains(t, err, "failed to parse fan_out region_timeout")
+	must.ErrorContains(t, err, `unsupported fan_out mode "eventually"`)
+}
+
+func TestConfig_Merge(t *testing.T) {
+	merged := DefaultConfig().Merge(&Config{Workers: new(16), Mode: ModeCollectAll})
+	must.Eq(t, &Config{Workers: new(16), RegionTimeout: "1m0s", Mode: ModeCollectAll}, merged)
+	must.True(t, merged.CollectAll())
+}
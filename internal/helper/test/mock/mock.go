@@ -4,6 +4,7 @@
 package mock
 
 import (
+	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 
 	"github.com/rasorp/attila/internal/domain"
@@ -69,6 +70,12 @@ func JobRegistrationPlan() *domain.JobRegisterPlan {
 	}
 }
 
+func JobRegistrationRun() *domain.JobRegisterPlanRun {
+	run := domain.NewJobRegisterPlanRun(ulid.Make(), "example", "default")
+	run.AddRegion("euw1", &api.JobRegisterResponse{EvalID: ulid.Make().String()}, nil)
+	return run
+}
+
 func JobRegistrationRule() *domain.JobRegisterRule {
 	return &domain.JobRegisterRule{
 		Name: "mock-" + ulid.Make().String(),
@@ -96,3 +103,12 @@ func JobRegistrationRule() *domain.JobRegisterRule {
 		},
 	}
 }
+
+func ACLPolicy() *domain.ACLPolicy {
+	return &domain.ACLPolicy{
+		Name:     "mock-" + ulid.Make().String(),
+		Region:   domain.ACLAccessRead,
+		Plan:     domain.ACLAccessWrite,
+		Topology: domain.ACLAccessRead,
+	}
+}
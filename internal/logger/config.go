@@ -58,6 +58,9 @@ func (c *Config) Merge(z *Config) *Config {
 	if c == nil {
 		return z
 	}
+	if z == nil {
+		return c
+	}
 
 	result := *c
 
@@ -5,7 +5,9 @@ package logger
 
 import (
 	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -17,6 +19,51 @@ const (
 )
 
 func New(cfg *Config) (*zap.Logger, error) {
+	l, _, err := NewReloadable(cfg)
+	return l, err
+}
+
+// NewReloadable returns a logger along with a Reloader, which can be used to
+// change the level and format of the logger and all loggers derived from it.
+func NewReloadable(cfg *Config) (*zap.Logger, *Reloader, error) {
+
+	core, err := newCore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := Reloader{core: &atomic.Pointer[zapcore.Core]{}}
+	r.core.Store(&core)
+
+	// Accumulate our options; currently we only support adding the log line
+	// detail. Options are applied to the logger rather than the core, so
+	// cannot be changed by a reload.
+	var opts []zap.Option
+
+	if *cfg.IncludeLine {
+		opts = append(opts, zap.AddCaller())
+	}
+
+	return zap.New(&reloadableCore{core: r.core}, opts...), &r, nil
+}
+
+// Reloader changes the configuration of a logger created by NewReloadable.
+type Reloader struct {
+	core *atomic.Pointer[zapcore.Core]
+}
+
+// Reload applies the level, format, and colour of the passed config. The
+// include line setting cannot be changed without creating a new logger.
+func (r *Reloader) Reload(cfg *Config) error {
+	core, err := newCore(cfg)
+	if err != nil {
+		return err
+	}
+	r.core.Store(&core)
+	return nil
+}
+
+func newCore(cfg *Config) (zapcore.Core, error) {
 
 	// Zap does not sanitize the input string, so do that here to avoid
 	// case-sensetive config parameters.
@@ -34,17 +81,36 @@ func New(cfg *Config) (*zap.Logger, error) {
 		encoder = newJSONEncoder()
 	}
 
-	// Accumulate our options; currently we only support adding the log line
-	// detail.
-	var opts []zap.Option
+	return zapcore.NewCore(encoder, os.Stderr, logLevel), nil
+}
 
-	if *cfg.IncludeLine {
-		opts = append(opts, zap.AddCaller())
+// reloadableCore delegates to the core currently stored by the Reloader. The
+// fields added via With are retained, so they can be applied to whichever
+// core is in use when an entry is written.
+type reloadableCore struct {
+	core   *atomic.Pointer[zapcore.Core]
+	fields []zapcore.Field
+}
+
+func (r *reloadableCore) Enabled(lvl zapcore.Level) bool { return (*r.core.Load()).Enabled(lvl) }
+
+func (r *reloadableCore) With(fields []zapcore.Field) zapcore.Core {
+	return &reloadableCore{core: r.core, fields: append(slices.Clip(r.fields), fields...)}
+}
+
+func (r *reloadableCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if r.Enabled(entry.Level) {
+		return checked.AddCore(entry, r)
 	}
+	return checked
+}
 
-	return zap.New(zapcore.NewCore(encoder, os.Stderr, logLevel), opts...), nil
+func (r *reloadableCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return (*r.core.Load()).With(r.fields).Write(entry, fields)
 }
 
+func (r *reloadableCore) Sync() error { return (*r.core.Load()).Sync() }
+
 func newHumanEncoder(colour bool) zapcore.Encoder {
 
 	cfg := zap.NewProductionEncoderConfig()
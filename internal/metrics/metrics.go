@@ -0,0 +1,394 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics provides counters, gauges, and histograms which can be
+// written in the Prometheus text exposition format. Metrics are registered
+// against a Registry, which is usually the Default registry, so packages can
+// define and update their metrics without having them passed around.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the HTTP content type of the text exposition format written
+// by Registry.WriteText.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the histogram buckets, in seconds, suitable for most
+// request and operation durations.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// Default is the registry used by the Attila server and the packages it runs.
+var Default = NewRegistry()
+
+// family is implemented by each metric type, so the registry can write them
+// without knowing their type.
+type family interface {
+	metricDesc() *desc
+	write(w *bufio.Writer)
+}
+
+// Registry holds a set of metrics, keyed by their name.
+type Registry struct {
+	lock     sync.RWMutex
+	families map[string]family
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// register adds the family to the registry. If a metric with the same name is
+// already registered, it is returned instead, which allows a package to
+// register its metrics more than once, such as when multiple servers run
+// within the same process during testing. Registering a different metric type
+// under an existing name is a programming error and panics.
+func (r *Registry) register(f family) family {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if existing, ok := r.families[f.metricDesc().name]; ok {
+		if existing.metricDesc().metricType != f.metricDesc().metricType {
+			panic(fmt.Sprintf("metric %q already registered as %s", f.metricDesc().name, existing.metricDesc().metricType))
+		}
+		return existing
+	}
+
+	r.families[f.metricDesc().name] = f
+	return f
+}
+
+// NewCounter registers and returns a counter with the passed label names.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return r.register(&Counter{
+		desc:   newDesc(name, help, "counter", labels),
+		series: make(map[string]*valueSeries),
+	}).(*Counter)
+}
+
+// NewGauge registers and returns a gauge with the passed label names.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return r.register(&Gauge{
+		desc:   newDesc(name, help, "gauge", labels),
+		series: make(map[string]*valueSeries),
+	}).(*Gauge)
+}
+
+// NewGaugeFunc registers a gauge whose values are computed by fn each time the
+// registry is written. The function is passed a callback which it calls for
+// each series. Registering a gauge function with an existing name replaces the
+// function.
+func (r *Registry) NewGaugeFunc(name, help string, labels []string, fn GaugeFn) {
+	gaugeFunc := r.register(&GaugeFunc{desc: newDesc(name, help, "gauge", labels)}).(*GaugeFunc)
+
+	gaugeFunc.lock.Lock()
+	gaugeFunc.fn = fn
+	gaugeFunc.lock.Unlock()
+}
+
+// NewHistogram registers and returns a histogram with the passed buckets and
+// label names. The buckets must be sorted in increasing order.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return r.register(&Histogram{
+		desc:    newDesc(name, help, "histogram", labels),
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}).(*Histogram)
+}
+
+// WriteText writes all registered metrics in the Prometheus text exposition
+// format. Metrics are sorted by name, so the output is stable.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.lock.RLock()
+	families := make([]family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.lock.RUnlock()
+
+	slices.SortFunc(families, func(a, b family) int { return strings.Compare(a.metricDesc().name, b.metricDesc().name) })
+
+	buf := bufio.NewWriter(w)
+
+	for _, f := range families {
+		d := f.metricDesc()
+		_, _ = fmt.Fprintf(buf, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+		_, _ = fmt.Fprintf(buf, "# TYPE %s %s\n", d.name, d.metricType)
+		f.write(buf)
+	}
+
+	return buf.Flush()
+}
+
+// desc details the name and labels of a metric.
+type desc struct {
+	name       string
+	help       string
+	metricType string
+	labels     []string
+}
+
+func newDesc(name, help, metricType string, labels []string) *desc {
+	return &desc{name: name, help: help, metricType: metricType, labels: labels}
+}
+
+func (d *desc) metricDesc() *desc { return d }
+
+// key returns the series key for the label values. It panics if the number of
+// values does not match the number of label names, as this is a programming
+// error.
+func (d *desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metric %q expects %d label values, got %d", d.name, len(d.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// formatLabels returns the label set of a series, including any extra label,
+// such as the histogram bucket bound.
+func (d *desc) formatLabels(labelValues []string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(labelValues)+1)
+
+	for i, value := range labelValues {
+		pairs = append(pairs, d.labels[i]+`="`+escapeLabelValue(value)+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+escapeLabelValue(extraValue)+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// valueSeries is a single series of a counter or gauge.
+type valueSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// Counter is a metric which only increases.
+type Counter struct {
+	*desc
+
+	lock   sync.Mutex
+	series map[string]*valueSeries
+}
+
+// Inc increments the series identified by the label values by one.
+func (c *Counter) Inc(labelValues ...string) { c.Add(1, labelValues...) }
+
+// Add increases the series identified by the label values. Negative values are
+// ignored, as counters cannot decrease.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+
+	key := c.key(labelValues)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &valueSeries{labelValues: slices.Clone(labelValues)}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	writeValueSeries(w, c.desc, c.series)
+}
+
+// Gauge is a metric which can be set to any value.
+type Gauge struct {
+	*desc
+
+	lock   sync.Mutex
+	series map[string]*valueSeries
+}
+
+// Set sets the value of the series identified by the label values.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	s, ok := g.series[key]
+	if !ok {
+		s = &valueSeries{labelValues: slices.Clone(labelValues)}
+		g.series[key] = s
+	}
+	s.value = v
+}
+
+// Delete removes the series identified by the label values, so it is no longer
+// reported.
+func (g *Gauge) Delete(labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.series, key)
+}
+
+// DeleteMatching removes all series for which the passed function returns
+// true. This is useful when a subset of the labels identifies an object which
+// no longer exists.
+func (g *Gauge) DeleteMatching(fn func(labelValues []string) bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for key, s := range g.series {
+		if fn(s.labelValues) {
+			delete(g.series, key)
+		}
+	}
+}
+
+func (g *Gauge) write(w *bufio.Writer) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	writeValueSeries(w, g.desc, g.series)
+}
+
+// GaugeFn computes the values of a GaugeFunc. It calls set once per series.
+type GaugeFn func(set func(v float64, labelValues ...string))
+
+// GaugeFunc is a gauge whose values are computed when the registry is written.
+type GaugeFunc struct {
+	*desc
+
+	lock sync.Mutex
+	fn   GaugeFn
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.lock.Lock()
+	fn := g.fn
+	g.lock.Unlock()
+
+	if fn == nil {
+		return
+	}
+
+	series := make(map[string]*valueSeries)
+
+	fn(func(v float64, labelValues ...string) {
+		series[g.key(labelValues)] = &valueSeries{labelValues: slices.Clone(labelValues), value: v}
+	})
+
+	writeValueSeries(w, g.desc, series)
+}
+
+func writeValueSeries(w *bufio.Writer, d *desc, series map[string]*valueSeries) {
+	for _, key := range sortedKeys(series) {
+		s := series[key]
+		_, _ = fmt.Fprintf(w, "%s%s %s\n", d.name, d.formatLabels(s.labelValues, "", ""), formatFloat(s.value))
+	}
+}
+
+// histogramSeries is a single series of a histogram. The counts are per
+// bucket and are made cumulative when written.
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// Histogram samples observations, such as durations, into buckets.
+type Histogram struct {
+	*desc
+	buckets []float64
+
+	lock   sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// Observe adds the value to the series identified by the label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: slices.Clone(labelValues),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n",
+				h.name, h.formatLabels(s.labelValues, "le", formatFloat(bound)), cumulative)
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.formatLabels(s.labelValues, "le", "+Inf"), s.count)
+		_, _ = fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.formatLabels(s.labelValues, "", ""), formatFloat(s.sum))
+		_, _ = fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.formatLabels(s.labelValues, "", ""), s.count)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string       { return helpReplacer.Replace(s) }
+func escapeLabelValue(s string) string { return labelReplacer.Replace(s) }
@@ -0,0 +1,88 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	registry := NewRegistry()
+
+	counter := registry.NewCounter("test_requests_total", "Test requests.", "code")
+	counter.Inc("200")
+	counter.Add(2, "200")
+	counter.Inc("500")
+	counter.Add(-1, "500")
+
+	gauge := registry.NewGauge("test_temperature", "Test \"temperature\"\nreadings.", "room")
+	gauge.Set(21.5, `kitchen "main"`)
+	gauge.Set(18, "hall")
+	gauge.Delete("hall")
+
+	registry.NewGaugeFunc("test_objects", "Test objects.", []string{"type"},
+		func(set func(v float64, labelValues ...string)) {
+			set(3, "region")
+		})
+
+	histogram := registry.NewHistogram("test_duration_seconds", "Test durations.", []float64{0.1, 1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+
+	var buf bytes.Buffer
+	must.NoError(t, registry.WriteText(&buf))
+
+	expected := `# HELP test_duration_seconds Test durations.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 5.55
+test_duration_seconds_count 3
+# HELP test_objects Test objects.
+# TYPE test_objects gauge
+test_objects{type="region"} 3
+# HELP test_requests_total Test requests.
+# TYPE test_requests_total counter
+test_requests_total{code="200"} 3
+test_requests_total{code="500"} 1
+# HELP test_temperature Test "temperature"\nreadings.
+# TYPE test_temperature gauge
+test_temperature{room="kitchen \"main\""} 21.5
+`
+	must.Eq(t, expected, buf.String())
+}
+
+func TestRegistry_register(t *testing.T) {
+	registry := NewRegistry()
+
+	// Registering the same name twice returns the original metric, so both
+	// callers update the same series.
+	counter1 := registry.NewCounter("test_total", "Test.", "label")
+	counter2 := registry.NewCounter("test_total", "Test.", "label")
+	must.Eq(t, counter1, counter2)
+
+	// Registering a different type under the same name panics.
+	defer func() { must.NotNil(t, recover()) }()
+	registry.NewGauge("test_total", "Test.", "label")
+}
+
+func TestGauge_DeleteMatching(t *testing.T) {
+	registry := NewRegistry()
+
+	gauge := registry.NewGauge("test_gauge", "Test.", "region", "pool")
+	gauge.Set(1, "euw1", "default")
+	gauge.Set(2, "euw1", "gpu")
+	gauge.Set(3, "use1", "default")
+
+	gauge.DeleteMatching(func(labelValues []string) bool { return labelValues[0] == "euw1" })
+
+	var buf bytes.Buffer
+	must.NoError(t, registry.WriteText(&buf))
+	must.Eq(t, "# HELP test_gauge Test.\n# TYPE test_gauge gauge\ntest_gauge{region=\"use1\",pool=\"default\"} 3\n", buf.String())
+}
@@ -5,6 +5,8 @@ package client
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 
 	"github.com/hashicorp/nomad/api"
@@ -43,6 +45,14 @@ func (c *Clients) Get(name string) (*api.Client, error) {
 	return regionClient, nil
 }
 
+// Names returns the names of the regions which have a client, in name order.
+func (c *Clients) Names() []string {
+	c.clientsLock.RLock()
+	defer c.clientsLock.RUnlock()
+
+	return slices.Sorted(maps.Keys(c.clients))
+}
+
 func (c *Clients) Num() int {
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
@@ -4,11 +4,14 @@
 package nomad
 
 import (
+	"context"
+
 	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 	"go.uber.org/zap"
 
 	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
 	"github.com/rasorp/attila/internal/nomad/client"
 	"github.com/rasorp/attila/internal/nomad/job"
 	"github.com/rasorp/attila/internal/nomad/topology"
@@ -20,9 +23,21 @@ type Controller struct {
 	logger   *zap.Logger
 	clients  *client.Clients
 	topology nomad.TopologyController
+
+	// drift holds the result of the latest drift check of each region which
+	// a successful run registered a job in.
+	drift *job.DriftReconciler
+
+	// fanOut controls how job registration plans and runs call regions in
+	// parallel.
+	fanOut *fanout.Config
+
+	// planCfg controls the expiry of job registration plans and when runs of
+	// stale plans are rejected.
+	planCfg *job.PlanConfig
 }
 
-func NewController(logger *zap.Logger) nomad.Controller {
+func NewController(logger *zap.Logger, fanOut *fanout.Config, planCfg *job.PlanConfig) nomad.Controller {
 	clientStore := client.New(logger)
 	topologyController := topology.New(logger, clientStore)
 
@@ -30,6 +45,9 @@ func NewController(logger *zap.Logger) nomad.Controller {
 		logger:   logger,
 		clients:  clientStore,
 		topology: topologyController,
+		drift:    job.NewDriftReconciler(logger, clientStore, fanOut),
+		fanOut:   fanOut,
+		planCfg:  planCfg,
 	}
 }
 
@@ -45,23 +63,71 @@ func (c *Controller) RegionSet(name string, client *api.Client) {
 
 func (c *Controller) RegionNum() int { return c.clients.Num() }
 
-func (c *Controller) JobRegistrationPlanCreate(apiJob *api.Job, state store.State) (*domain.JobRegisterPlan, error) {
+func (c *Controller) StopCollectors() { c.topology.StopCollectors() }
+
+func (c *Controller) JobRegistrationPlanCreate(
+	ctx context.Context, apiJob *api.Job, state store.State) (*domain.JobRegisterPlan, error) {
 	return job.NewPlanner(c.logger, &job.PlannerReq{
-		Clients: c.clients,
-		Job:     apiJob,
-		State:   state,
-	}).Run()
+		Clients:  c.clients,
+		Config:   c.planCfg,
+		FanOut:   c.fanOut,
+		Job:      apiJob,
+		State:    state,
+		Topology: c.topology,
+	}).Run(ctx)
 }
 
-func (c *Controller) JobRegistrationRun(planID ulid.ULID, apiJob *api.Job, state store.State) (*domain.JobRegisterPlanRun, error) {
+func (c *Controller) JobRegistrationRun(
+	ctx context.Context,
+	planID ulid.ULID,
+	apiJob *api.Job,
+	rollout *domain.JobRegisterRollout,
+	progress domain.JobRegisterProgressFunc,
+	state store.State,
+) (*domain.JobRegisterPlanRun, error) {
 	return job.NewRegister(c.logger, &job.RegisterReq{
-		Clients: c.clients,
-		Job:     apiJob,
-		PlanID:  planID,
-		State:   state,
-	}).Run()
+		Clients:  c.clients,
+		Config:   c.planCfg,
+		FanOut:   c.fanOut,
+		Job:      apiJob,
+		PlanID:   planID,
+		Progress: progress,
+		Rollout:  rollout,
+		State:    state,
+		Topology: c.topology,
+	}).Run(ctx)
+}
+
+func (c *Controller) JobStop(
+	ctx context.Context, namespace, jobID string, purge, plan bool, state store.State) (*domain.JobStop, error) {
+	return job.NewStopper(c.logger, &job.StopperReq{
+		Clients:   c.clients,
+		FanOut:    c.fanOut,
+		JobID:     jobID,
+		Namespace: namespace,
+		Plan:      plan,
+		Purge:     purge,
+		State:     state,
+	}).Run(ctx)
 }
 
+func (c *Controller) JobStatus(
+	ctx context.Context, namespace, jobID string, state store.State) (*domain.JobStatus, error) {
+	return job.NewStatusAggregator(c.logger, &job.StatusAggregatorReq{
+		Clients:   c.clients,
+		FanOut:    c.fanOut,
+		JobID:     jobID,
+		Namespace: namespace,
+		State:     state,
+	}).Run(ctx)
+}
+
+func (c *Controller) JobDriftReconcile(ctx context.Context, state store.State) error {
+	return c.drift.Reconcile(ctx, state)
+}
+
+func (c *Controller) GetJobDrifts() []*domain.JobDrift { return c.drift.Drifts() }
+
 func (c *Controller) GetTopologies() []*nomad.Overview {
 	return c.topology.GetTopologies()
 }
@@ -69,3 +135,7 @@ func (c *Controller) GetTopologies() []*nomad.Overview {
 func (c *Controller) GetTopology(name string) *nomad.Topology {
 	return c.topology.GetTopology(name)
 }
+
+func (c *Controller) GetTopologyIndex() (uint64, <-chan struct{}) {
+	return c.topology.GetTopologyIndex()
+}
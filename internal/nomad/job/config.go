@@ -0,0 +1,209 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPlanTTL                     = 24 * time.Hour
+	defaultPlanReapInterval            = time.Minute
+	defaultPlanTopologyChangeThreshold = 0.25
+	defaultDriftInterval               = time.Minute
+)
+
+// PlanConfig is the job registration plan configuration block. It controls how
+// long plans can be run for, and how much the regions of a plan can change
+// before running it is rejected.
+type PlanConfig struct {
+
+	// TTL is the time after its creation that a plan expires. Expired plans
+	// cannot be run and are deleted by the server. It is parsed as a Go
+	// duration.
+	TTL string `hcl:"ttl,optional"`
+
+	// ReapInterval is how often the server deletes expired plans. It is parsed
+	// as a Go duration.
+	ReapInterval string `hcl:"reap_interval,optional"`
+
+	// TopologyChangeThreshold is the largest fractional change in the number
+	// of clients, or allocatable CPU or memory, of a region between the plan
+	// and the run. Runs of plans with a region which has changed by more are
+	// rejected. Setting it to zero disables the check.
+	TopologyChangeThreshold *float64 `hcl:"topology_change_threshold,optional"`
+}
+
+// DefaultPlanConfig returns the default job registration plan config.
+func DefaultPlanConfig() *PlanConfig {
+	return &PlanConfig{
+		TTL:                     defaultPlanTTL.String(),
+		ReapInterval:            defaultPlanReapInterval.String(),
+		TopologyChangeThreshold: new(defaultPlanTopologyChangeThreshold),
+	}
+}
+
+// Validate performs validation of the plan configuration block. The function
+// can be called safely without checking if the object is nil.
+func (c *PlanConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if c.TTL != "" {
+		if ttl, err := time.ParseDuration(c.TTL); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse plan ttl: %w", err))
+		} else if ttl <= 0 {
+			errs = append(errs, errors.New("plan ttl must be positive"))
+		}
+	}
+
+	if c.ReapInterval != "" {
+		if interval, err := time.ParseDuration(c.ReapInterval); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse plan reap_interval: %w", err))
+		} else if interval <= 0 {
+			errs = append(errs, errors.New("plan reap_interval must be positive"))
+		}
+	}
+
+	if c.TopologyChangeThreshold != nil && *c.TopologyChangeThreshold < 0 {
+		errs = append(errs, fmt.Errorf(
+			"plan topology_change_threshold must not be negative, got %v", *c.TopologyChangeThreshold))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *PlanConfig) Merge(z *PlanConfig) *PlanConfig {
+
+	if c == nil {
+		return z
+	}
+	if z == nil {
+		return c
+	}
+
+	result := *c
+
+	if z.TTL != "" {
+		result.TTL = z.TTL
+	}
+	if z.ReapInterval != "" {
+		result.ReapInterval = z.ReapInterval
+	}
+	if z.TopologyChangeThreshold != nil {
+		result.TopologyChangeThreshold = z.TopologyChangeThreshold
+	}
+
+	return &result
+}
+
+// TTLDuration returns the parsed plan TTL, or the default if it is not set.
+func (c *PlanConfig) TTLDuration() time.Duration {
+	if c == nil {
+		return defaultPlanTTL
+	}
+	return positiveDurationOr(c.TTL, defaultPlanTTL)
+}
+
+// ReapIntervalDuration returns the parsed reap interval, or the default if it
+// is not set.
+func (c *PlanConfig) ReapIntervalDuration() time.Duration {
+	if c == nil {
+		return defaultPlanReapInterval
+	}
+	return positiveDurationOr(c.ReapInterval, defaultPlanReapInterval)
+}
+
+func (c *PlanConfig) topologyChangeThreshold() float64 {
+	if c == nil || c.TopologyChangeThreshold == nil {
+		return defaultPlanTopologyChangeThreshold
+	}
+	return *c.TopologyChangeThreshold
+}
+
+// DriftConfig is the job drift detection configuration block. It controls how
+// often the jobs registered by Attila are compared with the jobs running within
+// each region.
+type DriftConfig struct {
+
+	// Enabled controls whether the server runs the drift reconciler.
+	Enabled *bool `hcl:"enabled,optional"`
+
+	// Interval is how often the server checks each region for drift. It is
+	// parsed as a Go duration.
+	Interval string `hcl:"interval,optional"`
+}
+
+// DefaultDriftConfig returns the default job drift detection config.
+func DefaultDriftConfig() *DriftConfig {
+	return &DriftConfig{
+		Enabled:  new(true),
+		Interval: defaultDriftInterval.String(),
+	}
+}
+
+// Validate performs validation of the drift configuration block. The function
+// can be called safely without checking if the object is nil.
+func (c *DriftConfig) Validate() error {
+	if c == nil || c.Interval == "" {
+		return nil
+	}
+
+	interval, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return fmt.Errorf("failed to parse drift interval: %w", err)
+	}
+	if interval <= 0 {
+		return errors.New("drift interval must be positive")
+	}
+	return nil
+}
+
+func (c *DriftConfig) Merge(z *DriftConfig) *DriftConfig {
+
+	if c == nil {
+		return z
+	}
+	if z == nil {
+		return c
+	}
+
+	result := *c
+
+	if z.Enabled != nil {
+		result.Enabled = z.Enabled
+	}
+	if z.Interval != "" {
+		result.Interval = z.Interval
+	}
+
+	return &result
+}
+
+// IsEnabled returns whether the drift reconciler should be run. It is enabled
+// unless it has been explicitly disabled.
+func (c *DriftConfig) IsEnabled() bool {
+	return c == nil || c.Enabled == nil || *c.Enabled
+}
+
+// IntervalDuration returns the parsed drift interval, or the default if it is
+// not set.
+func (c *DriftConfig) IntervalDuration() time.Duration {
+	if c == nil {
+		return defaultDriftInterval
+	}
+	return positiveDurationOr(c.Interval, defaultDriftInterval)
+}
+
+func positiveDurationOr(s string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,50 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestPlanConfig_Validate(t *testing.T) {
+	must.NoError(t, DefaultPlanConfig().Validate())
+	must.NoError(t, (*PlanConfig)(nil).Validate())
+
+	cfg := &PlanConfig{TTL: "later", ReapInterval: "-1m", TopologyChangeThreshold: new(-0.5)}
+	err := cfg.Validate()
+	must.ErrorContains(t, err, "failed to parse plan ttl")
+	must.ErrorContains(t, err, "plan reap_interval must be positive")
+	must.ErrorContains(t, err, "plan topology_change_threshold must not be negative")
+}
+
+func TestPlanConfig_Merge(t *testing.T) {
+	merged := DefaultPlanConfig().Merge(&PlanConfig{TTL: "1h", TopologyChangeThreshold: new(0.0)})
+	must.Eq(t, &PlanConfig{TTL: "1h", ReapInterval: "1m0s", TopologyChangeThreshold: new(0.0)}, merged)
+	must.Eq(t, time.Hour, merged.TTLDuration())
+	must.Eq(t, time.Minute, merged.ReapIntervalDuration())
+	must.Eq(t, 0, merged.topologyChangeThreshold())
+
+	must.Eq(t, defaultPlanTTL, (*PlanConfig)(nil).TTLDuration())
+	must.Eq(t, defaultPlanTopologyChangeThreshold, (*PlanConfig)(nil).topologyChangeThreshold())
+}
+
+func TestDriftConfig_Validate(t *testing.T) {
+	must.NoError(t, DefaultDriftConfig().Validate())
+	must.NoError(t, (*DriftConfig)(nil).Validate())
+	must.ErrorContains(t, (&DriftConfig{Interval: "often"}).Validate(), "failed to parse drift interval")
+	must.ErrorContains(t, (&DriftConfig{Interval: "0s"}).Validate(), "drift interval must be positive")
+}
+
+func TestDriftConfig_Merge(t *testing.T) {
+	merged := DefaultDriftConfig().Merge(&DriftConfig{Enabled: new(false)})
+	must.Eq(t, &DriftConfig{Enabled: new(false), Interval: "1m0s"}, merged)
+	must.False(t, merged.IsEnabled())
+	must.Eq(t, time.Minute, merged.IntervalDuration())
+
+	must.True(t, (*DriftConfig)(nil).IsEnabled())
+	must.Eq(t, defaultDriftInterval, (*DriftConfig)(nil).IntervalDuration())
+}
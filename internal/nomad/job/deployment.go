@@ -0,0 +1,160 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/tracing"
+)
+
+// waitForDeployment waits for the Nomad deployment created by registering the
+// job in the region to succeed. The evaluation created by the registration is
+// followed until it completes, as this is when the scheduler links it to the
+// deployment. Registrations which do not create a deployment, such as those of
+// batch jobs or unchanged jobs, do not wait.
+//
+// Both the evaluation and deployment are read using blocking queries, so
+// changes are seen as soon as they happen without polling. The ID of the
+// deployment is returned once known, even if waiting for it failed.
+func (r *Register) waitForDeployment(
+	ctx context.Context, regionName string, registerResp *api.JobRegisterResponse) (deploymentID string, err error) {
+
+	// Periodic and parameterized jobs do not create an evaluation when they
+	// are registered.
+	if registerResp == nil || registerResp.EvalID == "" {
+		return "", nil
+	}
+
+	apiClient, err := r.clients.Get(regionName)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, span := tracing.Start(ctx, "nomad.deployment.wait", trace.WithAttributes(
+		attribute.String("attila.region.name", regionName),
+		attribute.String("attila.plan.id", r.planID.String()),
+		attribute.String("nomad.eval.id", registerResp.EvalID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	var (
+		eval      *api.Evaluation
+		waitIndex uint64
+	)
+
+	for eval == nil || eval.Status != api.EvalStatusComplete {
+
+		var meta *api.QueryMeta
+
+		eval, meta, err = apiClient.Evaluations().Info(
+			registerResp.EvalID, (&api.QueryOptions{WaitIndex: waitIndex}).WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to read evaluation %q: %w", registerResp.EvalID, err)
+		}
+
+		switch eval.Status {
+		case api.EvalStatusFailed, api.EvalStatusCancelled:
+			return "", statusError("evaluation", eval.ID, eval.Status, eval.StatusDescription)
+		}
+
+		waitIndex = meta.LastIndex
+	}
+
+	if eval.DeploymentID == "" {
+		return "", nil
+	}
+
+	span.SetAttributes(attribute.String("nomad.deployment.id", eval.DeploymentID))
+
+	r.logger.Info(
+		"waiting for regional job deployment",
+		zap.String("region_name", regionName),
+		zap.String("deployment_id", eval.DeploymentID),
+	)
+
+	waitIndex = 0
+
+	for {
+		deployment, meta, err := apiClient.Deployments().Info(
+			eval.DeploymentID, (&api.QueryOptions{WaitIndex: waitIndex}).WithContext(ctx))
+		if err != nil {
+			return eval.DeploymentID, fmt.Errorf("failed to read deployment %q: %w", eval.DeploymentID, err)
+		}
+
+		switch deployment.Status {
+		case api.DeploymentStatusSuccessful:
+			r.logger.Info(
+				"regional job deployment successful",
+				zap.String("region_name", regionName),
+				zap.String("deployment_id", deployment.ID),
+			)
+			return deployment.ID, nil
+		case api.DeploymentStatusFailed, api.DeploymentStatusCancelled:
+			return deployment.ID, statusError("deployment", deployment.ID, deployment.Status, deployment.StatusDescription)
+		}
+
+		waitIndex = meta.LastIndex
+	}
+}
+
+// regionHealth converts the outcome of waiting for the deployment of a region
+// into its health. The context is the one used to wait, so a wait which ran
+// out of time can be told apart from one which could not read Nomad.
+func regionHealth(ctx context.Context, deploymentID string, err error) *domain.JobRegisterRegionHealth {
+	health := domain.JobRegisterRegionHealth{
+		Status:       domain.JobRegisterHealthStatusHealthy,
+		DeploymentID: deploymentID,
+	}
+
+	if err == nil {
+		return &health
+	}
+
+	var statusErr *terminalStatusError
+
+	switch {
+	case errors.As(err, &statusErr):
+		health.Status = domain.JobRegisterHealthStatusUnhealthy
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		health.Status = domain.JobRegisterHealthStatusTimeout
+	default:
+		health.Status = domain.JobRegisterHealthStatusUnknown
+	}
+
+	health.Error = err.Error()
+	return &health
+}
+
+// terminalStatusError is the error for a Nomad object which reached a terminal
+// status other than success.
+type terminalStatusError struct {
+	kind        string
+	id          string
+	status      string
+	description string
+}
+
+// statusError returns the error for a Nomad object which reached a terminal
+// status other than success.
+func statusError(kind, id, status, description string) error {
+	return &terminalStatusError{kind: kind, id: id, status: status, description: description}
+}
+
+// Error satisfies the error interface. Nomad does not always set a status
+// description.
+func (e *terminalStatusError) Error() string {
+	if e.description == "" {
+		return fmt.Sprintf("%s %q %s", e.kind, e.id, e.status)
+	}
+	return fmt.Sprintf("%s %q %s: %s", e.kind, e.id, e.status, e.description)
+}
@@ -0,0 +1,328 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
+)
+
+// DriftReconciler compares the jobs registered by successful runs with the jobs
+// running within each region, so changes made directly to a region are
+// noticed. The drift of each region is held in memory and rebuilt from the
+// recorded runs, so it is only available on the server which runs the Nomad
+// controllers.
+type DriftReconciler struct {
+	logger *zap.Logger
+
+	clients *client.Clients
+	fanOut  *fanout.Config
+
+	// drifts is the result of the latest check of each region, keyed by the
+	// job and region. All access should use the lock, as it is replaced by
+	// each reconcile while being read by the HTTP API.
+	drifts map[driftKey]*domain.JobDrift
+	lock   sync.RWMutex
+}
+
+type driftKey struct {
+	namespace string
+	jobID     string
+	region    string
+}
+
+// driftTarget is a region which a successful run registered the job in, along
+// with the Nomad job modify index the run left the job at.
+type driftTarget struct {
+	run            *domain.JobRegisterPlanRun
+	region         string
+	jobModifyIndex uint64
+}
+
+func NewDriftReconciler(logger *zap.Logger, clients *client.Clients, fanOut *fanout.Config) *DriftReconciler {
+	return &DriftReconciler{
+		logger:  logger.Named("job_drift"),
+		clients: clients,
+		fanOut:  fanOut,
+		drifts:  make(map[driftKey]*domain.JobDrift),
+	}
+}
+
+// Reconcile checks every region of the latest run of each job, if the run was
+// successful. Jobs whose latest run failed are not checked, as the intended
+// state of their regions is not known.
+//
+// A region is first checked against the job modify index the run left it at,
+// and the version and spec hash of the job are then recorded. Later checks
+// compare the job with the recorded version and spec hash. Once a region has
+// drifted, it is not checked again until the job is run again. A region which
+// cannot be read is reported as unknown and is checked again on the next pass.
+func (d *DriftReconciler) Reconcile(ctx context.Context, state store.State) error {
+	listResp, err := state.JobRegister().Run().List(nil)
+	if err != nil {
+		return err
+	}
+
+	targets := driftTargets(listResp.Runs)
+
+	d.lock.RLock()
+	previous := d.drifts
+	d.lock.RUnlock()
+
+	now := time.Now()
+	results := make([]*domain.JobDrift, len(targets))
+
+	// Region errors are recorded within the results, so no call fails and
+	// the fan-out mode has no effect.
+	_ = fanout.Run(ctx, d.fanOut, len(targets), func(ctx context.Context, i int) error {
+		results[i] = d.checkRegion(ctx, targets[i], previous[targets[i].key()], now)
+		return nil
+	})
+
+	drifts := make(map[driftKey]*domain.JobDrift, len(targets))
+	driftedRegions := make(map[string]int)
+
+	for i, target := range targets {
+		key := target.key()
+
+		prev := previous[key]
+		if prev != nil && prev.RunID != target.run.ID {
+			prev = nil
+		}
+
+		// A region which was not checked, because the context was cancelled,
+		// keeps the result of its previous check.
+		result := results[i]
+		if result == nil {
+			if prev == nil {
+				continue
+			}
+			result = prev
+		}
+
+		if result != prev {
+			driftChecksTotal.Inc(target.region, result.Status)
+		}
+
+		if result.Drifted() && (prev == nil || !prev.Drifted()) {
+			d.logger.Warn("job drifted from registration run",
+				zap.String("job_id", result.JobID),
+				zap.String("job_namespace", result.JobNamespace),
+				zap.String("region_name", result.Region),
+				zap.String("run_id", result.RunID.String()),
+				zap.String("reason", result.Reason),
+			)
+		}
+
+		numDrifted := driftedRegions[target.region]
+		if result.Drifted() {
+			numDrifted++
+		}
+		driftedRegions[target.region] = numDrifted
+
+		drifts[key] = result
+	}
+
+	d.lock.Lock()
+	d.drifts = drifts
+	d.lock.Unlock()
+
+	driftedJobs.DeleteMatching(func([]string) bool { return true })
+	for region, num := range driftedRegions {
+		driftedJobs.Set(float64(num), region)
+	}
+
+	return nil
+}
+
+// Drifts returns the result of the latest check of each region, ordered by
+// job namespace, job ID, and region.
+func (d *DriftReconciler) Drifts() []*domain.JobDrift {
+	d.lock.RLock()
+	drifts := slices.Collect(maps.Values(d.drifts))
+	d.lock.RUnlock()
+
+	slices.SortFunc(drifts, func(a, b *domain.JobDrift) int {
+		return cmp.Or(
+			strings.Compare(a.JobNamespace, b.JobNamespace),
+			strings.Compare(a.JobID, b.JobID),
+			strings.Compare(a.Region, b.Region),
+		)
+	})
+
+	return drifts
+}
+
+// checkRegion compares the job running within the region with the run and the
+// previous check of the region. A new result is always returned, so results
+// shared with the HTTP API are never modified.
+func (d *DriftReconciler) checkRegion(
+	ctx context.Context, target *driftTarget, prev *domain.JobDrift, now time.Time) *domain.JobDrift {
+
+	// Results from a previous run of the job do not apply to this one.
+	if prev != nil && prev.RunID != target.run.ID {
+		prev = nil
+	}
+
+	if prev != nil && prev.Drifted() {
+		return prev
+	}
+
+	drift := domain.JobDrift{
+		JobID:        target.run.JobID,
+		JobNamespace: target.run.JobNamespace,
+		Region:       target.region,
+		RunID:        target.run.ID,
+		CheckTime:    now,
+	}
+	if prev != nil {
+		drift.JobVersion, drift.JobSpecHash = prev.JobVersion, prev.JobSpecHash
+	}
+
+	job, err := d.readJob(ctx, target)
+
+	switch {
+	case err != nil && isNotFound(err):
+		drift.Status, drift.Reason = domain.JobDriftStatusDrifted, "job not found in region"
+
+	case err != nil:
+		drift.Status, drift.Reason = domain.JobDriftStatusUnknown, err.Error()
+
+	default:
+		drift.Status, drift.Reason = compareJob(&drift, job, target.jobModifyIndex)
+	}
+
+	if drift.Drifted() {
+		drift.DriftTime = now
+	}
+
+	return &drift
+}
+
+func (d *DriftReconciler) readJob(ctx context.Context, target *driftTarget) (*api.Job, error) {
+	nomadClient, err := d.clients.Get(target.region)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "nomad.job.drift", trace.WithAttributes(
+		attribute.String("attila.region.name", target.region),
+		attribute.String("attila.run.id", target.run.ID.String()),
+		attribute.String("nomad.job.id", target.run.JobID),
+		attribute.String("nomad.job.namespace", target.run.JobNamespace),
+	))
+
+	queryOpts := (&api.QueryOptions{Namespace: target.run.JobNamespace}).WithContext(ctx)
+
+	job, _, err := nomadClient.Jobs().Info(target.run.JobID, queryOpts)
+	tracing.End(span, err)
+
+	return job, err
+}
+
+// compareJob returns the drift status of the job and the reason it drifted. If
+// the drift does not have a recorded version and spec hash, the job is checked
+// against the modify index the run left it at, and its version and spec hash
+// are recorded once it matches.
+func compareJob(drift *domain.JobDrift, job *api.Job, jobModifyIndex uint64) (string, string) {
+
+	specHash, err := jobSpecHash(job)
+	if err != nil {
+		return domain.JobDriftStatusUnknown, fmt.Sprintf("failed to hash job: %v", err)
+	}
+
+	if drift.JobSpecHash == "" {
+		if job.JobModifyIndex == nil || *job.JobModifyIndex != jobModifyIndex {
+			return domain.JobDriftStatusDrifted, "job modified since run"
+		}
+		drift.JobVersion, drift.JobSpecHash = job.Version, specHash
+		return domain.JobDriftStatusInSync, ""
+	}
+
+	if drift.JobVersion != nil && job.Version != nil && *drift.JobVersion != *job.Version {
+		return domain.JobDriftStatusDrifted,
+			fmt.Sprintf("job version changed from %v to %v", *drift.JobVersion, *job.Version)
+	}
+
+	if specHash != drift.JobSpecHash {
+		return domain.JobDriftStatusDrifted, "job spec changed"
+	}
+
+	return domain.JobDriftStatusInSync, ""
+}
+
+// jobSpecHash returns the hash of the job with the fields Nomad sets as the
+// job is registered and runs removed, so only changes to the spec change the
+// hash.
+func jobSpecHash(job *api.Job) (string, error) {
+	spec := *job
+	spec.Status = nil
+	spec.StatusDescription = nil
+	spec.Stable = nil
+	spec.Version = nil
+	spec.SubmitTime = nil
+	spec.CreateIndex = nil
+	spec.ModifyIndex = nil
+	spec.JobModifyIndex = nil
+	return domain.HashJob(&spec)
+}
+
+// driftTargets returns the regions of the latest run of each job, if the run
+// was successful, ordered by job and region.
+func driftTargets(runs []*domain.JobRegisterPlanRun) []*driftTarget {
+
+	type jobKey struct{ namespace, jobID string }
+
+	latestRuns := make(map[jobKey]*domain.JobRegisterPlanRun)
+
+	// Run IDs are ULIDs, so they sort by the time the run was created.
+	for _, run := range runs {
+		key := jobKey{namespace: run.JobNamespace, jobID: run.JobID}
+		if latest, ok := latestRuns[key]; !ok || run.ID.Compare(latest.ID) > 0 {
+			latestRuns[key] = run
+		}
+	}
+
+	var targets []*driftTarget
+
+	for _, run := range latestRuns {
+		if run.Error != "" {
+			continue
+		}
+		for region, jobModifyIndex := range run.RegisteredJobModifyIndexes() {
+			targets = append(targets, &driftTarget{run: run, region: region, jobModifyIndex: jobModifyIndex})
+		}
+	}
+
+	slices.SortFunc(targets, func(a, b *driftTarget) int {
+		return cmp.Or(
+			strings.Compare(a.run.JobNamespace, b.run.JobNamespace),
+			strings.Compare(a.run.JobID, b.run.JobID),
+			strings.Compare(a.region, b.region),
+		)
+	})
+
+	return targets
+}
+
+func (t *driftTarget) key() driftKey {
+	return driftKey{namespace: t.run.JobNamespace, jobID: t.run.JobID, region: t.region}
+}
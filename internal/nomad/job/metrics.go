@@ -0,0 +1,66 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"time"
+
+	"github.com/rasorp/attila/internal/metrics"
+)
+
+const (
+	metricsOutcomeSuccess = "success"
+	metricsOutcomeFailure = "failure"
+)
+
+var (
+	planCreateDuration = metrics.Default.NewHistogram(
+		"attila_job_plan_create_duration_seconds",
+		"The time taken to perform the Nomad job plan for a region, by outcome.",
+		metrics.DefaultBuckets,
+		"region", "outcome",
+	)
+	planRunDuration = metrics.Default.NewHistogram(
+		"attila_job_plan_run_duration_seconds",
+		"The time taken to register a planned job within a region, by outcome.",
+		metrics.DefaultBuckets,
+		"region", "outcome",
+	)
+	stopDuration = metrics.Default.NewHistogram(
+		"attila_job_stop_duration_seconds",
+		"The time taken to stop a job within a region, by outcome.",
+		metrics.DefaultBuckets,
+		"region", "outcome",
+	)
+	rollbacksTotal = metrics.Default.NewCounter(
+		"attila_job_rollbacks_total",
+		"The number of regions rolled back after a failed job registration run, by status.",
+		"region", "status",
+	)
+	driftChecksTotal = metrics.Default.NewCounter(
+		"attila_job_drift_checks_total",
+		"The number of regional job drift checks performed by the drift reconciler, by status.",
+		"region", "status",
+	)
+	driftedJobs = metrics.Default.NewGauge(
+		"attila_job_drifted",
+		"The number of jobs which have drifted from their last registration run, by region.",
+		"region",
+	)
+	deploymentHealthTotal = metrics.Default.NewCounter(
+		"attila_job_deployment_health_total",
+		"The number of regional job deployments waited for during job registration runs, by health status.",
+		"region", "status",
+	)
+)
+
+// observeOutcome records the time since startTime against the histogram,
+// labelled with the region and whether the operation succeeded.
+func observeOutcome(h *metrics.Histogram, region string, startTime time.Time, err error) {
+	outcome := metricsOutcomeSuccess
+	if err != nil {
+		outcome = metricsOutcomeFailure
+	}
+	h.Observe(time.Since(startTime).Seconds(), region, outcome)
+}
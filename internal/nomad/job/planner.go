@@ -4,18 +4,25 @@
 package job
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
 	"github.com/rasorp/attila/internal/nomad/client"
 	"github.com/rasorp/attila/internal/register/method/selector"
 	"github.com/rasorp/attila/internal/register/region/picker"
 	pickercontext "github.com/rasorp/attila/internal/register/region/picker/context"
+	"github.com/rasorp/attila/internal/server/nomad"
 	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
 
@@ -23,33 +30,55 @@ import (
 type Planner struct {
 	logger *zap.Logger
 
-	clients *client.Clients
-	job     *api.Job
-	state   store.State
+	clients  *client.Clients
+	config   *PlanConfig
+	fanOut   *fanout.Config
+	job      *api.Job
+	state    store.State
+	topology nomad.TopologyController
 
 	plan *domain.JobRegisterPlan
 }
 
 type PlannerReq struct {
-	Clients *client.Clients
-	Job     *api.Job
-	State   store.State
+	Clients  *client.Clients
+	Config   *PlanConfig
+	FanOut   *fanout.Config
+	Job      *api.Job
+	State    store.State
+	Topology nomad.TopologyController
 }
 
 func NewPlanner(logger *zap.Logger, req *PlannerReq) *Planner {
 	return &Planner{
 		clients: req.Clients,
+		config:  req.Config,
+		fanOut:  req.FanOut,
 		job:     req.Job,
 		logger: logger.With(
 			zap.String("job_id", *req.Job.ID),
 			zap.String("job_namespace", *req.Job.Namespace),
 		).Named("job_plan"),
-		plan:  domain.NewJobRegisterPlan(*req.Job.ID, *req.Job.Namespace),
-		state: req.State,
+		plan:     domain.NewJobRegisterPlan(*req.Job.ID, *req.Job.Namespace),
+		state:    req.State,
+		topology: req.Topology,
 	}
 }
 
-func (p *Planner) Run() (*domain.JobRegisterPlan, error) {
+func (p *Planner) Run(ctx context.Context) (*domain.JobRegisterPlan, error) {
+
+	// Hash the job before it is passed anywhere else, so the hash is of the
+	// job exactly as it was submitted and can be compared with the job
+	// submitted when the plan is run.
+	jobHash, hashErr := domain.HashJob(p.job)
+	if hashErr != nil {
+		return nil, fmt.Errorf("failed to hash job: %w", hashErr)
+	}
+
+	p.plan.Job = p.job
+	p.plan.JobHash = jobHash
+	p.plan.ExpireTime = p.plan.Metadata.CreateTime.Add(p.config.TTLDuration())
+
 	listResp, err := p.state.JobRegister().Method().List(nil)
 	if err != nil {
 		return nil, err
@@ -70,7 +99,7 @@ func (p *Planner) Run() (*domain.JobRegisterPlan, error) {
 			return nil, fmt.Errorf("failed to build method selector: %w", err)
 		}
 
-		result, err := sel.Run(&selector.RunRequest{Job: p.job})
+		result, err := sel.Run(ctx, &selector.RunRequest{Job: p.job})
 		if err != nil {
 			return nil, fmt.Errorf("failed to run method selector: %w", err)
 		}
@@ -91,18 +120,31 @@ func (p *Planner) Run() (*domain.JobRegisterPlan, error) {
 		}
 	}
 
+	// The rollout of the plan is taken from its rules. A plan only has a single
+	// rollout, so rules which set different rollouts cannot be planned
+	// together.
+	for _, rule := range rules {
+		if rule.Rollout == nil {
+			continue
+		}
+		if p.plan.Rollout != nil && *p.plan.Rollout != *rule.Rollout {
+			return nil, fmt.Errorf("job registration rule %q rollout conflicts with another matching rule", rule.Name)
+		}
+		p.plan.Rollout = rule.Rollout
+	}
+
 	regionListResp, err := p.state.Region().List(nil)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, rule := range rules {
-		pickedRegions, err := p.runRegisterPlanPicker(rule, regionListResp.Regions)
+		pickedRegions, err := p.runRegisterPlanPicker(ctx, rule, regionListResp.Regions)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := p.generatePlanResult(rule.Name, pickedRegions); err != nil {
+		if err := p.generatePlanResult(ctx, rule.Name, pickedRegions); err != nil {
 			return nil, err
 		}
 	}
@@ -113,7 +155,7 @@ func (p *Planner) Run() (*domain.JobRegisterPlan, error) {
 // runRegisterPlanPicker executes the job registration plan strategy pipeline and
 // returns the set of regions selected for plan generation.
 func (p *Planner) runRegisterPlanPicker(
-	rule *domain.JobRegisterRule, regions []*domain.Region) ([]*domain.Region, error) {
+	ctx context.Context, rule *domain.JobRegisterRule, regions []*domain.Region) ([]*domain.Region, error) {
 	p.logger.Debug(
 		"performing execution of rule region picker",
 		zap.String("rule_name", rule.Name),
@@ -124,18 +166,19 @@ func (p *Planner) runRegisterPlanPicker(
 		return regions, nil
 	}
 
-	candidates, err := pickercontext.BuildCandidates(regions, func(region *domain.Region) (map[string]any, error) {
-		regionClient, err := p.clients.Get(region.Name)
-		if err != nil {
-			return nil, err
-		}
+	candidates, err := pickercontext.BuildCandidates(ctx, p.fanOut, regions,
+		func(ctx context.Context, region *domain.Region) (map[string]any, error) {
+			regionClient, err := p.clients.Get(region.Name)
+			if err != nil {
+				return nil, err
+			}
 
-		ctx := make(map[string]any)
-		if err := populateRegionContext(rule, regionClient, ctx); err != nil {
-			return nil, err
-		}
-		return ctx, nil
-	})
+			regionCtx := make(map[string]any)
+			if err := populateRegionContext(ctx, region.Name, rule, regionClient, regionCtx); err != nil {
+				return nil, err
+			}
+			return regionCtx, nil
+		})
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +188,7 @@ func (p *Planner) runRegisterPlanPicker(
 		return nil, err
 	}
 
-	pickedCandidates, err := picker.Process(&jobsdk.RegionPickerRunRequest{
+	pickedCandidates, err := picker.Process(ctx, &jobsdk.RegionPickerRunRequest{
 		Job:              p.job,
 		Rule:             domainJobRegisterRuleToPickerRule(rule),
 		RegionCandidates: candidates,
@@ -171,26 +214,59 @@ func (p *Planner) runRegisterPlanPicker(
 	return pickedRegions, nil
 }
 
-// generatePlanResult iterates the selected region slice and perform a Nomad
-// job plan for each. The Nomad plan and region name will then be added to the
-// Attila plan result.
+// generatePlanResult performs a Nomad job plan for each selected region. The
+// regions are planned in parallel according to the fan-out config, and the
+// Nomad plans are added to the Attila plan result in region order once all
+// have completed.
 //
 // Any failure in calling the Nomad API will result in a failure of the whole
-// function.
-func (p *Planner) generatePlanResult(ruleName string, regions []*domain.Region) error {
-	for _, pickedRegion := range regions {
+// function. When the fan-out mode collects all failures, every region is
+// planned and the returned error includes each failure.
+func (p *Planner) generatePlanResult(ctx context.Context, ruleName string, regions []*domain.Region) error {
+
+	planResps := make([]*api.JobPlanResponse, len(regions))
+	jobVersions := make([]*uint64, len(regions))
+
+	err := fanout.Run(ctx, p.fanOut, len(regions), func(ctx context.Context, i int) error {
+		pickedRegion := regions[i]
+
 		nomadClient, err := p.clients.Get(pickedRegion.Name)
 		if err != nil {
 			return fmt.Errorf("failed to get Nomad client, %w", err)
 		}
 
-		// TODO(jrasem): add support for job plan diff.
-		planResp, _, err := nomadClient.Jobs().PlanOpts(p.job, nil, nil)
+		startTime := time.Now()
+
+		ctx, span := tracing.Start(ctx, "nomad.job.plan", trace.WithAttributes(
+			attribute.String("attila.region.name", pickedRegion.Name),
+			attribute.String("attila.rule.name", ruleName),
+			attribute.String("nomad.job.id", *p.job.ID),
+			attribute.String("nomad.job.namespace", *p.job.Namespace),
+		))
+
+		planResp, _, err := nomadClient.Jobs().PlanOpts(
+			p.job, &api.PlanOptions{Diff: true}, (&api.WriteOptions{}).WithContext(ctx))
+		observeOutcome(planCreateDuration, pickedRegion.Name, startTime, err)
+		tracing.End(span, err)
 		if err != nil {
-			return fmt.Errorf("failed to call Nomad job plan, %w", err)
+			return fmt.Errorf("failed to call Nomad job plan for region %q, %w", pickedRegion.Name, err)
 		}
 
-		p.plan.AddRegion(pickedRegion, planResp)
+		jobVersion, err := p.currentJobVersion(ctx, nomadClient, planResp)
+		if err != nil {
+			return fmt.Errorf("failed to read Nomad job for region %q, %w", pickedRegion.Name, err)
+		}
+
+		planResps[i] = planResp
+		jobVersions[i] = jobVersion
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, pickedRegion := range regions {
+		p.plan.AddRegion(pickedRegion, planResps[i], jobVersions[i], regionTopology(p.topology, pickedRegion.Name))
 
 		p.logger.Info(
 			"region picked by rule picker",
@@ -202,6 +278,49 @@ func (p *Planner) generatePlanResult(ruleName string, regions []*domain.Region)
 	return nil
 }
 
+// currentJobVersion returns the version of the job currently registered in the
+// region, so it can be reverted to if a run fails. Nil is returned if the job
+// is not registered, or has been modified since the plan was performed, as the
+// run will then be rejected by its enforced modify index.
+func (p *Planner) currentJobVersion(
+	ctx context.Context, nomadClient *api.Client, planResp *api.JobPlanResponse) (*uint64, error) {
+
+	if planResp.JobModifyIndex == 0 {
+		return nil, nil
+	}
+
+	queryOpts := (&api.QueryOptions{Namespace: *p.job.Namespace}).WithContext(ctx)
+
+	job, _, err := nomadClient.Jobs().Info(*p.job.ID, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+	if job.JobModifyIndex == nil || *job.JobModifyIndex != planResp.JobModifyIndex {
+		return nil, nil
+	}
+
+	return job.Version, nil
+}
+
+// regionTopology returns the size of the named region from its most recent
+// topology collection. Nil is returned if the region has not been collected.
+func regionTopology(controller nomad.TopologyController, regionName string) *domain.JobRegisterRegionTopology {
+	if controller == nil {
+		return nil
+	}
+
+	topology := controller.GetTopology(regionName)
+	if topology == nil || topology.Overview == nil {
+		return nil
+	}
+
+	return &domain.JobRegisterRegionTopology{
+		NumClients:        topology.Overview.NumClients,
+		CPUAllocatable:    topology.Overview.CPUAllocatable,
+		MemoryAllocatable: topology.Overview.MemoryAllocatable,
+	}
+}
+
 func domainJobRegisterRuleToPickerRule(rule *domain.JobRegisterRule) *jobsdk.RegionPickerRule {
 
 	regionContexts := make([]string, 0, len(rule.RegionContexts))
@@ -225,23 +344,40 @@ func domainJobRegisterRuleToPickerRule(rule *domain.JobRegisterRule) *jobsdk.Reg
 	}
 }
 
+// populateRegionContext fetches each region context kind the rule requires from
+// the region and adds it to the passed region context. Each fetch is traced
+// individually, as these are Nomad API calls made for every candidate region.
 func populateRegionContext(
-	rule *domain.JobRegisterRule, client *api.Client, ctx map[string]any) error {
+	ctx context.Context, regionName string, rule *domain.JobRegisterRule, client *api.Client, regionCtx map[string]any) error {
 	for _, regionContext := range rule.RegionContexts {
+
+		ctx, span := tracing.Start(ctx, "region_context.fetch", trace.WithAttributes(
+			attribute.String("attila.region.name", regionName),
+			attribute.String("attila.region_context.kind", regionContext.Kind),
+		))
+
+		queryOpts := (&api.QueryOptions{}).WithContext(ctx)
+
+		var err error
+
 		switch regionContext.Kind {
 		case domain.JobRegisterRuleContextKindNamespace:
-			namespaceList, _, err := client.Namespaces().List(nil)
-			if err != nil {
-				return err
+			var namespaceList []*api.Namespace
+			if namespaceList, _, err = client.Namespaces().List(queryOpts); err == nil {
+				regionCtx["region_namespace"] = namespaceList
 			}
-			ctx["region_namespace"] = namespaceList
 
 		case domain.JobRegisterRuleContextKindNodepool:
-			nodepoolList, _, err := client.NodePools().List(nil)
-			if err != nil {
-				return err
+			var nodepoolList []*api.NodePool
+			if nodepoolList, _, err = client.NodePools().List(queryOpts); err == nil {
+				regionCtx["region_nodepool"] = nodepoolList
 			}
-			ctx["region_nodepool"] = nodepoolList
+		}
+
+		tracing.End(span, err)
+
+		if err != nil {
+			return err
 		}
 	}
 
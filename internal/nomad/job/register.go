@@ -4,37 +4,61 @@
 package job
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 
 	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
 	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/server/nomad"
 	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
 )
 
 type Register struct {
 	logger *zap.Logger
 
-	clients *client.Clients
-	job     *api.Job
-	state   store.State
-	planID  ulid.ULID
+	clients  *client.Clients
+	config   *PlanConfig
+	fanOut   *fanout.Config
+	job      *api.Job
+	state    store.State
+	planID   ulid.ULID
+	progress domain.JobRegisterProgressFunc
+	rollout  *domain.JobRegisterRollout
+	topology nomad.TopologyController
 
 	runResult *domain.JobRegisterPlanRun
 }
 
 type RegisterReq struct {
-	Clients *client.Clients
-	Job     *api.Job
-	PlanID  ulid.ULID
-	State   store.State
+	Clients  *client.Clients
+	Config   *PlanConfig
+	FanOut   *fanout.Config
+	Job      *api.Job
+	PlanID   ulid.ULID
+	Progress domain.JobRegisterProgressFunc
+	Rollout  *domain.JobRegisterRollout
+	State    store.State
+	Topology nomad.TopologyController
 }
 
 func NewRegister(logger *zap.Logger, req *RegisterReq) *Register {
 	return &Register{
 		clients: req.Clients,
+		config:  req.Config,
+		fanOut:  req.FanOut,
 		job:     req.Job,
 		logger: logger.With(
 			zap.String("job_id", *req.Job.ID),
@@ -42,35 +66,226 @@ func NewRegister(logger *zap.Logger, req *RegisterReq) *Register {
 			zap.String("plan_id", req.PlanID.String()),
 		).Named("job_register"),
 		planID:    req.PlanID,
-		runResult: domain.NewJobRegisterPlanRun(*req.Job.ID, *req.Job.Namespace),
+		progress:  req.Progress,
+		rollout:   req.Rollout,
+		runResult: domain.NewJobRegisterPlanRun(req.PlanID, *req.Job.ID, *req.Job.Namespace),
 		state:     req.State,
+		topology:  req.Topology,
 	}
 }
 
-func (r *Register) Run() (*domain.JobRegisterPlanRun, error) {
+// Run registers the job in each region of the plan. The plan is first checked,
+// so it is not run with a different job, or once it is stale. Regions are split
+// into stages according to the rollout, which is taken from the request or else
+// the plan. The regions within a stage are registered in parallel according to
+// the fan-out config, and the next stage is only started once the Nomad
+// deployments of the current stage have succeeded. If the rollout waits for
+// healthy deployments, the final stage also waits, so the run only succeeds
+// once every region is healthy.
+//
+// In fail-fast mode, a failure in any region stops further regions from being
+// registered. When the fan-out mode collects all failures, every region within
+// the failed stage is registered. Later stages are never started once a stage
+// has failed. If the rollout uses the rollback failure mode, the regions which
+// were successfully registered are reverted. Once any region has been
+// registered, the run result is always returned alongside the error, with its
+// error set, so partial failures are recorded. The run result is only nil when
+// the plan cannot be run.
+func (r *Register) Run(ctx context.Context) (*domain.JobRegisterPlanRun, error) {
 	planResp, err := r.state.JobRegister().Plan().Get(&store.JobRegisterPlanGetReq{ID: r.planID})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, plannedRegion := range planResp.Plan.Regions {
-		if err := r.runPlannedRegion(plannedRegion); err != nil {
-			return nil, err
+	if err := r.checkPlan(planResp.Plan); err != nil {
+		return nil, err
+	}
+
+	rollout := r.rollout
+	if rollout == nil {
+		rollout = planResp.Plan.Rollout
+	}
+
+	// Order the regions by name, so the stages do not depend on map
+	// iteration.
+	stages := rollout.Stages(slices.Sorted(maps.Keys(planResp.Plan.Regions)))
+
+	for i, stage := range stages {
+
+		r.logger.Info(
+			"job register rollout stage started",
+			zap.Int("stage", i+1),
+			zap.Int("num_stages", len(stages)),
+			zap.Strings("region_names", stage),
+		)
+
+		results, runErr := r.runStage(ctx, planResp.Plan, stage)
+
+		// Wait for the deployments of this stage before starting the next, so
+		// a failing deployment is not rolled out any further. There is nothing
+		// to gate once the final stage has been registered, unless the caller
+		// wants to know the regions are healthy.
+		if runErr == nil && (i < len(stages)-1 || rollout.WaitForHealthyEnabled()) {
+			runErr = r.waitForStage(ctx, rollout, stage, results)
+		}
+
+		if runErr != nil {
+			if rollout.RollbackEnabled() {
+				runErr = errors.Join(runErr, r.rollback(ctx, planResp.Plan))
+			}
+
+			r.runResult.Error = runErr.Error()
+			return r.runResult, runErr
 		}
 	}
 
 	return r.runResult, nil
 }
 
-func (r *Register) runPlannedRegion(regionPlan *domain.JobRegisterRegionPlan) error {
+// checkPlan rejects running the plan with a job which differs from the planned
+// job, once the plan has expired, or once the topology of any planned region
+// has changed by more than the configured threshold. Regions without a
+// topology from either the plan or the latest collection are not checked.
+func (r *Register) checkPlan(plan *domain.JobRegisterPlan) error {
+
+	jobHash, err := domain.HashJob(r.job)
+	if err != nil {
+		return fmt.Errorf("failed to hash job: %w", err)
+	}
+	if jobHash != plan.JobHash {
+		return domain.ErrJobRegisterPlanJobMismatch
+	}
+
+	if plan.Expired(time.Now()) {
+		return fmt.Errorf("%w: plan expired at %s",
+			domain.ErrJobRegisterPlanStale, plan.ExpireTime.Format(time.RFC3339))
+	}
+
+	threshold := r.config.topologyChangeThreshold()
+	if threshold == 0 {
+		return nil
+	}
+
+	for _, regionName := range slices.Sorted(maps.Keys(plan.Regions)) {
+
+		planned := plan.Regions[regionName].Topology
+		if planned == nil {
+			continue
+		}
+
+		current := regionTopology(r.topology, regionName)
+		if current == nil {
+			continue
+		}
+
+		if change := planned.Change(current); change > threshold {
+			return fmt.Errorf("%w: region %q topology changed by %.0f%%, above the threshold of %.0f%%",
+				domain.ErrJobRegisterPlanStale, regionName, change*100, threshold*100)
+		}
+	}
+
+	return nil
+}
+
+// runStage registers the job in each region of the stage and adds the results
+// to the run result.
+func (r *Register) runStage(
+	ctx context.Context, plan *domain.JobRegisterPlan, stage []string) ([]*regionRunResult, error) {
+
+	results := make([]*regionRunResult, len(stage))
+
+	err := fanout.Run(ctx, r.fanOut, len(stage), func(ctx context.Context, i int) error {
+		results[i] = r.runPlannedRegion(ctx, plan.Regions[stage[i]], plan.Submission)
+
+		if results[i].err != nil {
+			r.reportProgress(stage[i], domain.JobRegisterProgressPhaseRegister,
+				domain.JobRegisterProgressStatusFailed, results[i].err.Error())
+		} else {
+			r.reportProgress(stage[i], domain.JobRegisterProgressPhaseRegister,
+				domain.JobRegisterProgressStatusRegistered, "")
+		}
+
+		return results[i].err
+	})
+
+	for i, regionName := range stage {
+		if results[i] != nil {
+			r.runResult.AddRegion(regionName, results[i].resp, results[i].err)
+		}
+	}
+
+	return results, err
+}
+
+// waitForStage waits for the Nomad deployment of the job in each region of the
+// stage to succeed. The rollout deployment timeout applies to each region, and
+// the health of each region which was waited for is added to the run result.
+func (r *Register) waitForStage(
+	ctx context.Context, rollout *domain.JobRegisterRollout, stage []string, results []*regionRunResult) error {
+
+	fanOut := r.fanOut.WithRegionTimeout(rollout.DeploymentTimeoutDuration())
+
+	healths := make([]*domain.JobRegisterRegionHealth, len(stage))
+
+	err := fanout.Run(ctx, fanOut, len(stage), func(ctx context.Context, i int) error {
+		r.reportProgress(stage[i], domain.JobRegisterProgressPhaseHealth,
+			domain.JobRegisterProgressStatusWaiting, "")
+
+		deploymentID, err := r.waitForDeployment(ctx, stage[i], results[i].resp)
+		healths[i] = regionHealth(ctx, deploymentID, err)
+
+		r.reportProgress(stage[i], domain.JobRegisterProgressPhaseHealth, healths[i].Status, healths[i].Error)
+
+		if err != nil {
+			return fmt.Errorf("region %q: %w", stage[i], err)
+		}
+		return nil
+	})
+
+	for i, regionName := range stage {
+		if healths[i] != nil {
+			r.runResult.SetHealth(regionName, healths[i])
+			deploymentHealthTotal.Inc(regionName, healths[i].Status)
+		}
+	}
+
+	return err
+}
+
+// reportProgress passes the progress of a region to the progress function, if
+// the caller set one.
+func (r *Register) reportProgress(regionName, phase, status, errMsg string) {
+	if r.progress == nil {
+		return
+	}
+	r.progress(&domain.JobRegisterRegionProgress{
+		Region: regionName,
+		Phase:  phase,
+		Status: status,
+		Error:  errMsg,
+	})
+}
+
+// regionRunResult is the outcome of registering the job in a single region.
+type regionRunResult struct {
+	resp *api.JobRegisterResponse
+	err  error
+}
+
+// runPlannedRegion registers the job in the region of the plan. The submission
+// is the original source of the job, which is passed to Nomad if the plan has
+// one.
+func (r *Register) runPlannedRegion(
+	ctx context.Context, regionPlan *domain.JobRegisterRegionPlan, submission *api.JobSubmission) *regionRunResult {
 	apiClient, err := r.clients.Get(regionPlan.Region)
 	if err != nil {
-		return err
+		return &regionRunResult{err: err}
 	}
 
 	registerOpts := api.RegisterOptions{
 		EnforceIndex: true,
 		ModifyIndex:  regionPlan.Plan.JobModifyIndex,
+		Submission:   submission,
 	}
 
 	r.logger.Info(
@@ -79,8 +294,18 @@ func (r *Register) runPlannedRegion(regionPlan *domain.JobRegisterRegionPlan) er
 		zap.Uint64("job_modify_index", registerOpts.ModifyIndex),
 	)
 
-	registerResp, _, err := apiClient.Jobs().RegisterOpts(r.job, &registerOpts, nil)
-	r.runResult.AddRegion(regionPlan.Region, registerResp, err)
+	startTime := time.Now()
+
+	ctx, span := tracing.Start(ctx, "nomad.job.register", trace.WithAttributes(
+		attribute.String("attila.region.name", regionPlan.Region),
+		attribute.String("attila.plan.id", r.planID.String()),
+		attribute.String("nomad.job.id", *r.job.ID),
+		attribute.String("nomad.job.namespace", *r.job.Namespace),
+	))
+
+	registerResp, _, err := apiClient.Jobs().RegisterOpts(r.job, &registerOpts, (&api.WriteOptions{}).WithContext(ctx))
+	observeOutcome(planRunDuration, regionPlan.Region, startTime, err)
+	tracing.End(span, err)
 
 	if err != nil {
 		r.logger.Error(
@@ -89,7 +314,7 @@ func (r *Register) runPlannedRegion(regionPlan *domain.JobRegisterRegionPlan) er
 			zap.Uint64("job_modify_index", registerOpts.ModifyIndex),
 			zap.Error(err),
 		)
-		return err
+		return &regionRunResult{err: err}
 	}
 
 	r.logger.Info(
@@ -98,5 +323,5 @@ func (r *Register) runPlannedRegion(regionPlan *domain.JobRegisterRegionPlan) er
 		zap.Uint64("job_modify_index", registerOpts.ModifyIndex),
 	)
 
-	return nil
+	return &regionRunResult{resp: registerResp}
 }
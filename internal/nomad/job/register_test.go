@@ -0,0 +1,302 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+const (
+	// fakeJobModifyIndex is the job modify index the fake Nomad API returns
+	// when the job is registered.
+	fakeJobModifyIndex = 10
+
+	// fakeJobVersion is the job version the fake Nomad API reports once the
+	// job is registered.
+	fakeJobVersion = 4
+)
+
+// fakeCalls records the calls made to every fake Nomad region, in the order
+// they were made.
+type fakeCalls struct {
+	lock  sync.Mutex
+	calls []string
+}
+
+func (f *fakeCalls) add(call string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeCalls) get() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// fakeRegion is a fake Nomad API for a single region. The registration creates
+// an evaluation which is linked to a deployment, which succeeds unless the
+// region is set to fail it.
+type fakeRegion struct {
+	name string
+
+	// priorVersion is the job version running in the region when the plan was
+	// created, and is nil if the job was not registered.
+	priorVersion *uint64
+
+	// failRegister, failDeployment, and failDeregister make the registration,
+	// deployment, or deregistration of the job within the region fail.
+	failRegister   bool
+	failDeployment bool
+	failDeregister bool
+
+	// jobModifyIndex is the job modify index returned when reading the job,
+	// which defaults to the index of the registration.
+	jobModifyIndex uint64
+
+	revertReq *api.JobRevertRequest
+}
+
+func (f *fakeRegion) handler(t *testing.T, calls *fakeCalls) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+
+		switch {
+		case r.URL.Path == "/v1/jobs":
+			calls.add("register " + f.name)
+			if f.failRegister {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			resp = api.JobRegisterResponse{EvalID: "eval-1", JobModifyIndex: fakeJobModifyIndex}
+		case r.URL.Path == "/v1/evaluation/eval-1":
+			resp = api.Evaluation{ID: "eval-1", Status: api.EvalStatusComplete, DeploymentID: "deploy-1"}
+		case r.URL.Path == "/v1/deployment/deploy-1":
+			calls.add("deployment " + f.name)
+			status := api.DeploymentStatusSuccessful
+			if f.failDeployment {
+				status = api.DeploymentStatusFailed
+			}
+			resp = api.Deployment{ID: "deploy-1", Status: status}
+		case r.URL.Path == "/v1/job/example" && r.Method == http.MethodGet:
+			jobModifyIndex := f.jobModifyIndex
+			if jobModifyIndex == 0 {
+				jobModifyIndex = fakeJobModifyIndex
+			}
+			resp = api.Job{
+				ID:             new("example"),
+				Version:        new(uint64(fakeJobVersion)),
+				JobModifyIndex: new(jobModifyIndex),
+			}
+		case r.URL.Path == "/v1/job/example" && r.Method == http.MethodDelete:
+			calls.add("deregister " + f.name)
+			if f.failDeregister {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			resp = api.JobDeregisterResponse{EvalID: "eval-2"}
+		case r.URL.Path == "/v1/job/example/revert":
+			calls.add("revert " + f.name)
+			f.revertReq = &api.JobRevertRequest{}
+			must.NoError(t, json.NewDecoder(r.Body).Decode(f.revertReq))
+			resp = api.JobRegisterResponse{JobModifyIndex: fakeJobModifyIndex + 1}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// testRegister stores a plan for the regions and returns the register which
+// runs it, using a fake Nomad API for each region. The calls made to the
+// regions are recorded in the returned calls.
+func testRegister(
+	t *testing.T, regions []*fakeRegion, rollout *domain.JobRegisterRollout, fanOut *fanout.Config) (*Register, *fakeCalls) {
+	t.Helper()
+
+	state, err := mem.NewStore()
+	must.NoError(t, err)
+
+	job := &api.Job{ID: new("example"), Namespace: new("default")}
+
+	jobHash, err := domain.HashJob(job)
+	must.NoError(t, err)
+
+	plan := domain.JobRegisterPlan{
+		ID:           ulid.Make(),
+		JobID:        *job.ID,
+		JobNamespace: *job.Namespace,
+		Regions:      make(map[string]*domain.JobRegisterRegionPlan),
+		JobHash:      jobHash,
+		Metadata:     domain.NewMetadata(),
+	}
+
+	calls := &fakeCalls{}
+	clients := client.New(zap.NewNop())
+
+	for _, region := range regions {
+		nomadServer := httptest.NewServer(region.handler(t, calls))
+		t.Cleanup(nomadServer.Close)
+
+		nomadClient, err := api.NewClient(&api.Config{Address: nomadServer.URL})
+		must.NoError(t, err)
+		clients.Set(region.name, nomadClient)
+
+		plan.Regions[region.name] = &domain.JobRegisterRegionPlan{
+			Region:     region.name,
+			Plan:       &api.JobPlanResponse{JobModifyIndex: 5},
+			JobVersion: region.priorVersion,
+		}
+	}
+
+	_, stateErr := state.JobRegister().Plan().Create(&store.JobRegisterPlanCreateReq{Plan: &plan})
+	must.Nil(t, stateErr)
+
+	return NewRegister(zap.NewNop(), &RegisterReq{
+		Clients: clients,
+		FanOut:  fanOut,
+		Job:     job,
+		PlanID:  plan.ID,
+		Rollout: rollout,
+		State:   state,
+	}), calls
+}
+
+func TestRegister_Run(t *testing.T) {
+
+	testCases := []struct {
+		name            string
+		inputRegions    []*fakeRegion
+		inputRollout    *domain.JobRegisterRollout
+		inputFanOut     *fanout.Config
+		expectedCalls   []string
+		expectedRegions []string
+		expectedErr     bool
+	}{
+		{
+			name: "serial stages in name order",
+			inputRegions: []*fakeRegion{
+				{name: "euw2"}, {name: "euw1"}, {name: "euw3"},
+			},
+			inputRollout: &domain.JobRegisterRollout{Strategy: domain.JobRegisterRolloutStrategySerial},
+			expectedCalls: []string{
+				"register euw1", "deployment euw1",
+				"register euw2", "deployment euw2",
+				"register euw3",
+			},
+			expectedRegions: []string{"euw1", "euw2", "euw3"},
+		},
+		{
+			name: "serial stages wait for healthy",
+			inputRegions: []*fakeRegion{
+				{name: "euw1"}, {name: "euw2"},
+			},
+			inputRollout: &domain.JobRegisterRollout{
+				Strategy:       domain.JobRegisterRolloutStrategySerial,
+				WaitForHealthy: true,
+			},
+			expectedCalls: []string{
+				"register euw1", "deployment euw1",
+				"register euw2", "deployment euw2",
+			},
+			expectedRegions: []string{"euw1", "euw2"},
+		},
+		{
+			name: "failed deployment stops later stages",
+			inputRegions: []*fakeRegion{
+				{name: "euw1"}, {name: "euw2", failDeployment: true}, {name: "euw3"},
+			},
+			inputRollout: &domain.JobRegisterRollout{Strategy: domain.JobRegisterRolloutStrategySerial},
+			expectedCalls: []string{
+				"register euw1", "deployment euw1",
+				"register euw2", "deployment euw2",
+			},
+			expectedRegions: []string{"euw1", "euw2"},
+			expectedErr:     true,
+		},
+		{
+			name: "failed registration stops later stages",
+			inputRegions: []*fakeRegion{
+				{name: "euw1", failRegister: true}, {name: "euw2"}, {name: "euw3"},
+			},
+			inputRollout: &domain.JobRegisterRollout{
+				Strategy:      domain.JobRegisterRolloutStrategyCanaryThenRest,
+				CanaryRegions: 1,
+			},
+			inputFanOut:     &fanout.Config{Mode: fanout.ModeCollectAll},
+			expectedCalls:   []string{"register euw1"},
+			expectedRegions: []string{"euw1"},
+			expectedErr:     true,
+		},
+		{
+			name: "fail fast",
+			inputRegions: []*fakeRegion{
+				{name: "euw1"}, {name: "euw2", failRegister: true}, {name: "euw3"},
+			},
+			inputFanOut:     &fanout.Config{Workers: new(1), Mode: fanout.ModeFailFast},
+			expectedCalls:   []string{"register euw1", "register euw2"},
+			expectedRegions: []string{"euw1", "euw2"},
+			expectedErr:     true,
+		},
+		{
+			name: "collect all",
+			inputRegions: []*fakeRegion{
+				{name: "euw1"}, {name: "euw2", failRegister: true}, {name: "euw3"},
+			},
+			inputFanOut:     &fanout.Config{Workers: new(1), Mode: fanout.ModeCollectAll},
+			expectedCalls:   []string{"register euw1", "register euw2", "register euw3"},
+			expectedRegions: []string{"euw1", "euw2", "euw3"},
+			expectedErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			register, calls := testRegister(t, tc.inputRegions, tc.inputRollout, tc.inputFanOut)
+
+			result, err := register.Run(context.Background())
+			if tc.expectedErr {
+				must.Error(t, err)
+				must.Eq(t, err.Error(), result.Error)
+			} else {
+				must.NoError(t, err)
+				must.Eq(t, "", result.Error)
+			}
+
+			must.Eq(t, tc.expectedCalls, calls.get())
+			must.Eq(t, tc.expectedRegions, slices.Sorted(maps.Keys(result.Regions)))
+
+			for _, region := range tc.inputRegions {
+				regionRun, ok := result.Regions[region.name]
+				if !ok {
+					continue
+				}
+				must.Eq(t, region.failRegister, regionRun.Error != "")
+				must.Nil(t, regionRun.Rollback)
+			}
+		})
+	}
+}
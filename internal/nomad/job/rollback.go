@@ -0,0 +1,190 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/tracing"
+)
+
+// rollback reverts each region which was successfully registered during the
+// run to the job version recorded within the plan, using the Nomad job revert
+// API. Regions where the job was not registered before the run have it
+// deregistered instead. The outcome of each region is added to the run result
+// and the returned error details each region which could not be rolled back.
+func (r *Register) rollback(ctx context.Context, plan *domain.JobRegisterPlan) error {
+
+	// The run may have failed because the request was cancelled or exceeded
+	// its deadline, which must not prevent the rollback.
+	ctx = context.WithoutCancel(ctx)
+
+	var regionNames []string
+
+	for _, regionName := range slices.Sorted(maps.Keys(r.runResult.Regions)) {
+		if r.runResult.Regions[regionName].Error == "" {
+			regionNames = append(regionNames, regionName)
+		}
+	}
+
+	r.logger.Warn("rolling back job register run", zap.Strings("region_names", regionNames))
+
+	rollbacks := make([]*domain.JobRegisterRegionRollback, len(regionNames))
+
+	// Every region is rolled back, even if another region fails to roll back.
+	fanOut := r.fanOut.WithMode(fanout.ModeCollectAll)
+
+	err := fanout.Run(ctx, fanOut, len(regionNames), func(ctx context.Context, i int) error {
+		rollbacks[i] = r.rollbackRegion(ctx, plan.Regions[regionNames[i]], r.runResult.Regions[regionNames[i]].Run)
+
+		r.reportProgress(regionNames[i], domain.JobRegisterProgressPhaseRollback,
+			rollbacks[i].Status, rollbacks[i].Error)
+
+		if rollbacks[i].Status == domain.JobRegisterRollbackStatusFailed {
+			return fmt.Errorf("failed to roll back region %q: %s", regionNames[i], rollbacks[i].Error)
+		}
+		return nil
+	})
+
+	for i, regionName := range regionNames {
+		r.runResult.SetRollback(regionName, rollbacks[i])
+		rollbacksTotal.Inc(regionName, rollbacks[i].Status)
+	}
+
+	return err
+}
+
+// rollbackRegion rolls back the job within a single region, using the
+// response of registering the job during the run. The job is only rolled back
+// if it has not been modified since it was registered, so changes made by
+// others are not overwritten. The revert is made conditional on the job
+// version registered by the run, which covers modifications made while the
+// rollback is in progress.
+func (r *Register) rollbackRegion(
+	ctx context.Context,
+	regionPlan *domain.JobRegisterRegionPlan,
+	registerResp *api.JobRegisterResponse,
+) *domain.JobRegisterRegionRollback {
+
+	apiClient, err := r.clients.Get(regionPlan.Region)
+	if err != nil {
+		return r.rollbackFailed(regionPlan, err)
+	}
+
+	registeredVersion, err := r.registeredJobVersion(ctx, apiClient, registerResp)
+	if err != nil {
+		return r.rollbackFailed(regionPlan, err)
+	}
+
+	if regionPlan.JobVersion == nil {
+		return r.deregisterRegion(ctx, apiClient, regionPlan)
+	}
+
+	ctx, span := tracing.Start(ctx, "nomad.job.revert", trace.WithAttributes(
+		attribute.String("attila.region.name", regionPlan.Region),
+		attribute.String("attila.plan.id", r.planID.String()),
+		attribute.String("nomad.job.id", *r.job.ID),
+		attribute.String("nomad.job.namespace", *r.job.Namespace),
+		attribute.Int64("nomad.job.version", int64(*regionPlan.JobVersion)),
+	))
+
+	writeOpts := (&api.WriteOptions{Namespace: *r.job.Namespace}).WithContext(ctx)
+
+	revertResp, _, err := apiClient.Jobs().Revert(
+		*r.job.ID, *regionPlan.JobVersion, &registeredVersion, writeOpts, "", "")
+	tracing.End(span, err)
+
+	if err != nil {
+		return r.rollbackFailed(regionPlan, err)
+	}
+
+	r.logger.Info(
+		"regional job rollback successful",
+		zap.String("region_name", regionPlan.Region),
+		zap.Uint64("job_version", *regionPlan.JobVersion),
+	)
+
+	return &domain.JobRegisterRegionRollback{
+		Status:     domain.JobRegisterRollbackStatusReverted,
+		JobVersion: regionPlan.JobVersion,
+		Revert:     revertResp,
+	}
+}
+
+// registeredJobVersion returns the version of the job registered by the run,
+// after checking the job has not been modified since. Nomad keeps the job
+// modify index when it updates the job without creating a new version, such
+// as when marking it stable, so it identifies the registration.
+func (r *Register) registeredJobVersion(
+	ctx context.Context, apiClient *api.Client, registerResp *api.JobRegisterResponse) (uint64, error) {
+
+	queryOpts := (&api.QueryOptions{Namespace: *r.job.Namespace}).WithContext(ctx)
+
+	job, _, err := apiClient.Jobs().Info(*r.job.ID, queryOpts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read job: %w", err)
+	}
+
+	if *job.JobModifyIndex != registerResp.JobModifyIndex {
+		return 0, fmt.Errorf("job modified at index %v after the run registered it at index %v",
+			*job.JobModifyIndex, registerResp.JobModifyIndex)
+	}
+
+	return *job.Version, nil
+}
+
+// deregisterRegion rolls back the job within a region where it was not
+// registered before the run, by deregistering it. The job is stopped rather
+// than purged, so its history can still be inspected.
+func (r *Register) deregisterRegion(
+	ctx context.Context, apiClient *api.Client, regionPlan *domain.JobRegisterRegionPlan) *domain.JobRegisterRegionRollback {
+
+	ctx, span := tracing.Start(ctx, "nomad.job.deregister", trace.WithAttributes(
+		attribute.String("attila.region.name", regionPlan.Region),
+		attribute.String("attila.plan.id", r.planID.String()),
+		attribute.String("nomad.job.id", *r.job.ID),
+		attribute.String("nomad.job.namespace", *r.job.Namespace),
+	))
+
+	writeOpts := (&api.WriteOptions{Namespace: *r.job.Namespace}).WithContext(ctx)
+
+	_, _, err := apiClient.Jobs().Deregister(*r.job.ID, false, writeOpts)
+	tracing.End(span, err)
+
+	if err != nil {
+		return r.rollbackFailed(regionPlan, err)
+	}
+
+	r.logger.Info("regional job rollback deregistered job", zap.String("region_name", regionPlan.Region))
+
+	return &domain.JobRegisterRegionRollback{Status: domain.JobRegisterRollbackStatusDeregistered}
+}
+
+// rollbackFailed logs and returns the rollback outcome of a region which could
+// not be rolled back, so it still runs the job registered by the run.
+func (r *Register) rollbackFailed(
+	regionPlan *domain.JobRegisterRegionPlan, err error) *domain.JobRegisterRegionRollback {
+
+	r.logger.Error(
+		"regional job rollback failed",
+		zap.String("region_name", regionPlan.Region),
+		zap.Error(err),
+	)
+
+	return &domain.JobRegisterRegionRollback{
+		Status:     domain.JobRegisterRollbackStatusFailed,
+		JobVersion: regionPlan.JobVersion,
+		Error:      err.Error(),
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+)
+
+func TestRegister_rollback(t *testing.T) {
+
+	rollout := domain.JobRegisterRollout{
+		Strategy:  domain.JobRegisterRolloutStrategySerial,
+		OnFailure: domain.JobRegisterRolloutOnFailureRollback,
+	}
+
+	// Roll back a single region at a time, so the calls are made in order.
+	fanOut := fanout.Config{Workers: new(1)}
+
+	t.Run("revert and deregister", func(t *testing.T) {
+
+		// The first region ran the job before the run, the second did not and
+		// its deployment fails, so the third region is never registered.
+		regions := []*fakeRegion{
+			{name: "euw1", priorVersion: new(uint64(2))},
+			{name: "euw2", failDeployment: true},
+			{name: "euw3", priorVersion: new(uint64(1))},
+		}
+
+		register, calls := testRegister(t, regions, &rollout, &fanOut)
+
+		result, err := register.Run(context.Background())
+		must.Error(t, err)
+		must.Eq(t, err.Error(), result.Error)
+
+		must.Eq(t, []string{
+			"register euw1", "deployment euw1",
+			"register euw2", "deployment euw2",
+			"revert euw1", "deregister euw2",
+		}, calls.get())
+
+		// The revert only succeeds if the job still runs the version
+		// registered by the run.
+		must.Eq(t, 2, regions[0].revertReq.JobVersion)
+		must.Eq(t, new(uint64(fakeJobVersion)), regions[0].revertReq.EnforcePriorVersion)
+
+		must.Eq(t, &domain.JobRegisterRegionRollback{
+			Status:     domain.JobRegisterRollbackStatusReverted,
+			JobVersion: new(uint64(2)),
+			Revert:     result.Regions["euw1"].Rollback.Revert,
+		}, result.Regions["euw1"].Rollback)
+		must.Eq(t, fakeJobModifyIndex+1, result.Regions["euw1"].Rollback.Revert.JobModifyIndex)
+
+		must.Eq(t, &domain.JobRegisterRegionRollback{
+			Status: domain.JobRegisterRollbackStatusDeregistered,
+		}, result.Regions["euw2"].Rollback)
+
+		must.MapNotContainsKey(t, result.Regions, "euw3")
+		must.Eq(t, map[string]uint64{"euw1": fakeJobModifyIndex + 1}, result.RegisteredJobModifyIndexes())
+	})
+
+	t.Run("job modified", func(t *testing.T) {
+
+		// The job was modified within the first region after the run
+		// registered it, so it is not rolled back.
+		regions := []*fakeRegion{
+			{name: "euw1", priorVersion: new(uint64(2)), jobModifyIndex: fakeJobModifyIndex + 5},
+			{name: "euw2", failRegister: true},
+		}
+
+		register, calls := testRegister(t, regions, &rollout, &fanOut)
+
+		result, err := register.Run(context.Background())
+		must.ErrorContains(t, err, `failed to roll back region "euw1"`)
+		must.Eq(t, err.Error(), result.Error)
+
+		must.Eq(t, []string{"register euw1", "deployment euw1", "register euw2"}, calls.get())
+		must.Nil(t, regions[0].revertReq)
+
+		rollback := result.Regions["euw1"].Rollback
+		must.Eq(t, domain.JobRegisterRollbackStatusFailed, rollback.Status)
+		must.StrContains(t, rollback.Error, "job modified at index 15")
+
+		// Regions which failed to register have nothing to roll back.
+		must.Nil(t, result.Regions["euw2"].Rollback)
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
+)
+
+// StatusAggregator reads the status of a single job from every region with a
+// Nomad client and merges it into one status.
+type StatusAggregator struct {
+	logger *zap.Logger
+
+	clients   *client.Clients
+	fanOut    *fanout.Config
+	jobID     string
+	namespace string
+	state     store.State
+}
+
+type StatusAggregatorReq struct {
+	Clients   *client.Clients
+	FanOut    *fanout.Config
+	JobID     string
+	Namespace string
+	State     store.State
+}
+
+func NewStatusAggregator(logger *zap.Logger, req *StatusAggregatorReq) *StatusAggregator {
+	return &StatusAggregator{
+		clients:   req.Clients,
+		fanOut:    req.FanOut,
+		jobID:     req.JobID,
+		namespace: req.Namespace,
+		logger: logger.With(
+			zap.String("job_id", req.JobID),
+			zap.String("job_namespace", req.Namespace),
+		).Named("job_status"),
+		state: req.State,
+	}
+}
+
+// Run reads the job from every region in parallel and compares each region
+// with the last registration run of the job. A region which cannot be read is
+// reported with its error, rather than failing the whole status, so the
+// status of the other regions can still be viewed.
+func (s *StatusAggregator) Run(ctx context.Context) (*domain.JobStatus, error) {
+
+	lastRun, err := s.lastRun()
+	if err != nil {
+		return nil, err
+	}
+
+	regionNames := s.clients.Names()
+	results := make([]*regionStatusResult, len(regionNames))
+
+	// Region errors are recorded within the results, so no call fails and
+	// the fan-out mode has no effect.
+	_ = fanout.Run(ctx, s.fanOut, len(regionNames), func(ctx context.Context, i int) error {
+		results[i] = s.regionStatus(ctx, regionNames[i])
+		return nil
+	})
+
+	status := domain.JobStatus{
+		JobID:        s.jobID,
+		JobNamespace: s.namespace,
+		Regions:      make(map[string]*domain.JobStatusRegion),
+	}
+
+	var registeredIndexes map[string]uint64
+	if lastRun != nil {
+		status.RunID = &lastRun.ID
+		registeredIndexes = lastRun.RegisteredJobModifyIndexes()
+	}
+
+	for i, regionName := range regionNames {
+		result := results[i]
+
+		// A region which was not called, because the context was cancelled,
+		// has no result and its status is unknown.
+		if result == nil {
+			result = &regionStatusResult{err: ctx.Err()}
+		}
+
+		registeredIndex, registered := registeredIndexes[regionName]
+
+		if result.err == nil && result.job == nil && !registered {
+			continue
+		}
+
+		regionStatus := domain.JobStatusRegion{Region: regionName}
+
+		switch {
+		case result.err != nil:
+			regionStatus.Error = result.err.Error()
+
+		case result.job == nil:
+			regionStatus.Missing = true
+
+		default:
+			if result.job.Status != nil {
+				regionStatus.JobStatus = *result.job.Status
+			}
+			regionStatus.JobVersion = result.job.Version
+			regionStatus.Allocations = result.allocations
+
+			if result.deployment != nil {
+				regionStatus.DeploymentID = result.deployment.ID
+				regionStatus.DeploymentStatus = result.deployment.Status
+			}
+
+			regionStatus.Mismatch = lastRun != nil &&
+				(!registered || result.job.JobModifyIndex == nil || *result.job.JobModifyIndex != registeredIndex)
+		}
+
+		status.Regions[regionName] = &regionStatus
+	}
+
+	return &status, nil
+}
+
+// lastRun returns the most recent registration run of the job, or nil if the
+// job has not been run.
+func (s *StatusAggregator) lastRun() (*domain.JobRegisterPlanRun, error) {
+	listResp, err := s.state.JobRegister().Run().List(&store.JobRegisterRunListReq{
+		JobID:        s.jobID,
+		JobNamespace: s.namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastRun *domain.JobRegisterPlanRun
+
+	// Run IDs are ULIDs, so they sort by the time the run was created.
+	for _, run := range listResp.Runs {
+		if lastRun == nil || run.ID.Compare(lastRun.ID) > 0 {
+			lastRun = run
+		}
+	}
+
+	return lastRun, nil
+}
+
+// regionStatusResult is the status of the job read from a single region. The
+// job is nil if the region does not hold it.
+type regionStatusResult struct {
+	job         *api.Job
+	allocations map[string]int
+	deployment  *api.Deployment
+	err         error
+}
+
+// regionStatus reads the job, its allocations, and its latest deployment from
+// the region.
+func (s *StatusAggregator) regionStatus(ctx context.Context, regionName string) *regionStatusResult {
+	nomadClient, err := s.clients.Get(regionName)
+	if err != nil {
+		return &regionStatusResult{err: err}
+	}
+
+	ctx, span := tracing.Start(ctx, "nomad.job.status", trace.WithAttributes(
+		attribute.String("attila.region.name", regionName),
+		attribute.String("nomad.job.id", s.jobID),
+		attribute.String("nomad.job.namespace", s.namespace),
+	))
+
+	result, err := readRegionStatus(ctx, nomadClient, s.jobID, s.namespace)
+	tracing.End(span, err)
+
+	if err != nil {
+		s.logger.Error("failed to read regional job status", zap.String("region_name", regionName), zap.Error(err))
+		return &regionStatusResult{err: err}
+	}
+
+	return result
+}
+
+func readRegionStatus(ctx context.Context, nomadClient *api.Client, jobID, namespace string) (*regionStatusResult, error) {
+
+	queryOpts := (&api.QueryOptions{Namespace: namespace}).WithContext(ctx)
+
+	job, _, err := nomadClient.Jobs().Info(jobID, queryOpts)
+	if err != nil {
+		if isNotFound(err) {
+			return &regionStatusResult{}, nil
+		}
+		return nil, fmt.Errorf("failed to read Nomad job: %w", err)
+	}
+
+	allocs, _, err := nomadClient.Jobs().Allocations(jobID, false, queryOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Nomad job allocations: %w", err)
+	}
+
+	allocations := make(map[string]int)
+	for _, alloc := range allocs {
+		allocations[alloc.ClientStatus]++
+	}
+
+	deployment, _, err := nomadClient.Jobs().LatestDeployment(jobID, queryOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Nomad job deployment: %w", err)
+	}
+
+	return &regionStatusResult{job: job, allocations: allocations, deployment: deployment}, nil
+}
@@ -0,0 +1,204 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
+)
+
+// Stopper manages stopping a single job within every region which holds it.
+type Stopper struct {
+	logger *zap.Logger
+
+	clients   *client.Clients
+	fanOut    *fanout.Config
+	jobID     string
+	namespace string
+	plan      bool
+	purge     bool
+	state     store.State
+
+	stopResult *domain.JobStop
+}
+
+type StopperReq struct {
+	Clients   *client.Clients
+	FanOut    *fanout.Config
+	JobID     string
+	Namespace string
+	Plan      bool
+	Purge     bool
+	State     store.State
+}
+
+func NewStopper(logger *zap.Logger, req *StopperReq) *Stopper {
+	return &Stopper{
+		clients:   req.Clients,
+		fanOut:    req.FanOut,
+		jobID:     req.JobID,
+		namespace: req.Namespace,
+		logger: logger.With(
+			zap.String("job_id", req.JobID),
+			zap.String("job_namespace", req.Namespace),
+		).Named("job_stop"),
+		plan:       req.Plan,
+		purge:      req.Purge,
+		state:      req.State,
+		stopResult: domain.NewJobStop(req.JobID, req.Namespace, req.Purge, req.Plan),
+	}
+}
+
+// Run finds every region which holds the job and stops it. A job which has
+// already been stopped is only included when it is being purged, as there is
+// nothing else to do. When planning, the regions holding the job are returned
+// without the job being stopped.
+//
+// Failing to read the job from any region fails the whole stop, so no region
+// is stopped based on an incomplete view. In fail-fast mode, a failure to stop
+// the job in any region stops further regions from being called. When the
+// fan-out mode collects all failures, every region is called. Once any region
+// has been called, the result is always returned alongside the error, with its
+// error set, so the regions which were already stopped are reported.
+func (s *Stopper) Run(ctx context.Context) (*domain.JobStop, error) {
+
+	regionListResp, err := s.state.Region().List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.findRegions(ctx, regionListResp.Regions); err != nil {
+		return nil, err
+	}
+
+	if s.plan || len(s.stopResult.Regions) == 0 {
+		return s.stopResult, nil
+	}
+
+	regionNames := slices.Sorted(maps.Keys(s.stopResult.Regions))
+	evalIDs := make([]string, len(regionNames))
+	errs := make([]error, len(regionNames))
+
+	runErr := fanout.Run(ctx, s.fanOut, len(regionNames), func(ctx context.Context, i int) error {
+		evalIDs[i], errs[i] = s.stopRegion(ctx, regionNames[i])
+		if errs[i] != nil {
+			return fmt.Errorf("failed to stop job in region %q, %w", regionNames[i], errs[i])
+		}
+		return nil
+	})
+
+	for i, regionName := range regionNames {
+		if evalIDs[i] != "" || errs[i] != nil {
+			s.stopResult.SetStop(regionName, evalIDs[i], errs[i])
+		}
+	}
+
+	if runErr != nil {
+		s.stopResult.Error = runErr.Error()
+		return s.stopResult, runErr
+	}
+
+	return s.stopResult, nil
+}
+
+// findRegions reads the job from each region in parallel and adds the regions
+// which hold it to the stop result in region order.
+func (s *Stopper) findRegions(ctx context.Context, regions []*domain.Region) error {
+
+	jobs := make([]*api.Job, len(regions))
+
+	err := fanout.Run(ctx, s.fanOut, len(regions), func(ctx context.Context, i int) error {
+		nomadClient, err := s.clients.Get(regions[i].Name)
+		if err != nil {
+			return fmt.Errorf("failed to get Nomad client, %w", err)
+		}
+
+		queryOpts := (&api.QueryOptions{Namespace: s.namespace}).WithContext(ctx)
+
+		job, _, err := nomadClient.Jobs().Info(s.jobID, queryOpts)
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to read Nomad job for region %q, %w", regions[i].Name, err)
+		}
+
+		jobs[i] = job
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, region := range regions {
+		if jobs[i] == nil || (!s.purge && jobs[i].Stop != nil && *jobs[i].Stop) {
+			continue
+		}
+		s.stopResult.AddRegion(region.Name, jobs[i])
+	}
+
+	return nil
+}
+
+// stopRegion deregisters the job from the region and returns the ID of the
+// evaluation Nomad created.
+func (s *Stopper) stopRegion(ctx context.Context, regionName string) (string, error) {
+	nomadClient, err := s.clients.Get(regionName)
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.Info("regional job stop started",
+		zap.String("region_name", regionName), zap.Bool("purge", s.purge))
+
+	startTime := time.Now()
+
+	ctx, span := tracing.Start(ctx, "nomad.job.deregister", trace.WithAttributes(
+		attribute.String("attila.region.name", regionName),
+		attribute.String("nomad.job.id", s.jobID),
+		attribute.String("nomad.job.namespace", s.namespace),
+		attribute.Bool("nomad.job.purge", s.purge),
+	))
+
+	evalID, _, err := nomadClient.Jobs().DeregisterOpts(
+		s.jobID,
+		&api.DeregisterOptions{Purge: s.purge},
+		(&api.WriteOptions{Namespace: s.namespace}).WithContext(ctx),
+	)
+	observeOutcome(stopDuration, regionName, startTime, err)
+	tracing.End(span, err)
+
+	if err != nil {
+		s.logger.Error("regional job stop failed", zap.String("region_name", regionName), zap.Error(err))
+		return "", err
+	}
+
+	s.logger.Info("regional job stop successful",
+		zap.String("region_name", regionName), zap.String("eval_id", evalID))
+
+	return evalID, nil
+}
+
+// isNotFound returns whether the error is a Nomad API response indicating the
+// object does not exist.
+func isNotFound(err error) bool {
+	var respErr api.UnexpectedResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode() == http.StatusNotFound
+}
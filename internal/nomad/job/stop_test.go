@@ -0,0 +1,105 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package job
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/helper/fanout"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/nomad/client"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+// testStopper stores the regions and returns the stopper which stops the job
+// within them, using a fake Nomad API for each region. The calls made to the
+// regions are recorded in the returned calls.
+func testStopper(t *testing.T, regions []*fakeRegion, fanOut *fanout.Config) (*Stopper, *fakeCalls) {
+	t.Helper()
+
+	state, err := mem.NewStore()
+	must.NoError(t, err)
+
+	calls := &fakeCalls{}
+	clients := client.New(zap.NewNop())
+
+	for _, region := range regions {
+		nomadServer := httptest.NewServer(region.handler(t, calls))
+		t.Cleanup(nomadServer.Close)
+
+		nomadClient, err := api.NewClient(&api.Config{Address: nomadServer.URL})
+		must.NoError(t, err)
+		clients.Set(region.name, nomadClient)
+
+		mockRegion := mock.Region()
+		mockRegion.Name = region.name
+
+		_, stateErr := state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+		must.Nil(t, stateErr)
+	}
+
+	return NewStopper(zap.NewNop(), &StopperReq{
+		Clients:   clients,
+		FanOut:    fanOut,
+		JobID:     "example",
+		Namespace: "default",
+		State:     state,
+	}), calls
+}
+
+func TestStopper_Run(t *testing.T) {
+
+	testCases := []struct {
+		name            string
+		inputFanOut     *fanout.Config
+		expectedCalls   []string
+		expectedEvalIDs map[string]string
+	}{
+		{
+			name:            "fail fast",
+			inputFanOut:     &fanout.Config{Workers: new(1), Mode: fanout.ModeFailFast},
+			expectedCalls:   []string{"deregister euw1", "deregister euw2"},
+			expectedEvalIDs: map[string]string{"euw1": "eval-2", "euw2": "", "euw3": ""},
+		},
+		{
+			name:            "collect all",
+			inputFanOut:     &fanout.Config{Workers: new(1), Mode: fanout.ModeCollectAll},
+			expectedCalls:   []string{"deregister euw1", "deregister euw2", "deregister euw3"},
+			expectedEvalIDs: map[string]string{"euw1": "eval-2", "euw2": "", "euw3": "eval-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			// The second region fails to stop the job, after the first region
+			// has already stopped it.
+			regions := []*fakeRegion{
+				{name: "euw1"}, {name: "euw2", failDeregister: true}, {name: "euw3"},
+			}
+
+			stopper, calls := testStopper(t, regions, tc.inputFanOut)
+
+			result, err := stopper.Run(context.Background())
+			must.ErrorContains(t, err, `failed to stop job in region "euw2"`)
+			must.NotNil(t, result)
+			must.Eq(t, err.Error(), result.Error)
+
+			must.Eq(t, tc.expectedCalls, calls.get())
+			must.MapLen(t, len(tc.expectedEvalIDs), result.Regions)
+
+			for regionName, expectedEvalID := range tc.expectedEvalIDs {
+				must.Eq(t, expectedEvalID, result.Regions[regionName].EvalID)
+				must.Eq(t, regionName == "euw2", result.Regions[regionName].Error != "")
+			}
+		})
+	}
+}
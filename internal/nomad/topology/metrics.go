@@ -0,0 +1,164 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"time"
+
+	"github.com/rasorp/attila/internal/metrics"
+	"github.com/rasorp/attila/internal/server/nomad"
+)
+
+var (
+	collectionsTotal = metrics.Default.NewCounter(
+		"attila_topology_collections_total",
+		"The number of region topology collections, by region and outcome.",
+		"region", "outcome",
+	)
+	collectionDuration = metrics.Default.NewHistogram(
+		"attila_topology_collection_duration_seconds",
+		"The time taken to perform region topology collections, by region.",
+		metrics.DefaultBuckets,
+		"region",
+	)
+)
+
+// registerAgeMetric registers the gauge which reports the time since the last
+// successful collection of each tracked region. Regions which have never been
+// successfully collected are not reported.
+func (c *Topology) registerAgeMetric() {
+	metrics.Default.NewGaugeFunc(
+		"attila_topology_collection_age_seconds",
+		"The time since the last successful topology collection, by region.",
+		[]string{"region"},
+		func(set func(v float64, labelValues ...string)) {
+			c.regionsLock.RLock()
+			defer c.regionsLock.RUnlock()
+
+			for name, r := range c.regions {
+				if lastSuccess := r.lastSuccess.Load(); lastSuccess > 0 {
+					set(time.Since(time.Unix(0, lastSuccess)).Seconds(), name)
+				}
+			}
+		},
+	)
+}
+
+// capacityLabels are the labels of each region capacity gauge.
+var capacityLabels = []string{"region", "node_pool", "node_status"}
+
+// capacityKey identifies a group of nodes within a region, which capacity is
+// reported for.
+type capacityKey struct {
+	nodePool   string
+	nodeStatus string
+}
+
+// capacity is the summed resources of a group of nodes. CPU is in MHz and
+// memory in MiB, matching the topology.
+type capacity struct {
+	cpuAllocatable    int64
+	cpuAllocated      int64
+	memoryAllocatable int64
+	memoryAllocated   int64
+}
+
+// topologyCapacity groups the nodes of the topology by node pool and status,
+// summing their resources. The sum of all groups equals the topology
+// overview.
+func topologyCapacity(topology *nomad.Topology) map[capacityKey]*capacity {
+	groups := make(map[capacityKey]*capacity)
+
+	for _, node := range topology.Detail.Nodes {
+		key := capacityKey{nodePool: node.NodePool, nodeStatus: node.Status}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &capacity{}
+			groups[key] = group
+		}
+
+		group.cpuAllocatable += node.CPUAllocatable
+		group.cpuAllocated += node.CPUAllocated
+		group.memoryAllocatable += node.MemoryAllocatable
+		group.memoryAllocated += node.MemoryAllocated
+	}
+
+	return groups
+}
+
+// registerCapacityMetrics registers the gauges which report the allocatable
+// and allocated resources of each region, by node pool and node status. The
+// values are computed from the latest topology of each region when metrics
+// are gathered, so regions which are removed, or node pools which no longer
+// have nodes, stop being reported without needing to be deleted.
+func (c *Topology) registerCapacityMetrics() {
+
+	const mebibyte = 1024 * 1024
+
+	c.registerCapacityGauge(
+		"attila_region_cpu_allocatable_mhz",
+		"The CPU available to allocations, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return float64(g.cpuAllocatable), true },
+	)
+	c.registerCapacityGauge(
+		"attila_region_cpu_allocated_mhz",
+		"The CPU reserved by running allocations, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return float64(g.cpuAllocated), true },
+	)
+	c.registerCapacityGauge(
+		"attila_region_cpu_utilization_ratio",
+		"The ratio of allocated to allocatable CPU, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return ratio(g.cpuAllocated, g.cpuAllocatable) },
+	)
+	c.registerCapacityGauge(
+		"attila_region_memory_allocatable_bytes",
+		"The memory available to allocations, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return float64(g.memoryAllocatable * mebibyte), true },
+	)
+	c.registerCapacityGauge(
+		"attila_region_memory_allocated_bytes",
+		"The memory reserved by running allocations, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return float64(g.memoryAllocated * mebibyte), true },
+	)
+	c.registerCapacityGauge(
+		"attila_region_memory_utilization_ratio",
+		"The ratio of allocated to allocatable memory, by region, node pool, and node status.",
+		func(g *capacity) (float64, bool) { return ratio(g.memoryAllocated, g.memoryAllocatable) },
+	)
+}
+
+// registerCapacityGauge registers a single capacity gauge. The valueFn returns
+// the value to report for a group of nodes and whether it should be reported
+// at all.
+func (c *Topology) registerCapacityGauge(name, help string, valueFn func(*capacity) (float64, bool)) {
+	metrics.Default.NewGaugeFunc(name, help, capacityLabels,
+		func(set func(v float64, labelValues ...string)) {
+			c.regionsLock.RLock()
+			defer c.regionsLock.RUnlock()
+
+			for regionName, r := range c.regions {
+				result := r.getResult()
+				if result == nil {
+					continue
+				}
+
+				for key, group := range topologyCapacity(result) {
+					if v, ok := valueFn(group); ok {
+						set(v, regionName, key.nodePool, key.nodeStatus)
+					}
+				}
+			}
+		},
+	)
+}
+
+// ratio returns the ratio of used to total. It is not reported when the total
+// is zero, such as a group containing only down nodes, as it is undefined.
+func ratio(used, total int64) (float64, bool) {
+	if total <= 0 {
+		return 0, false
+	}
+	return float64(used) / float64(total), true
+}
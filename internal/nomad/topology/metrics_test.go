@@ -0,0 +1,134 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/metrics"
+	"github.com/rasorp/attila/internal/server/nomad"
+)
+
+func Test_topologyCapacity(t *testing.T) {
+
+	testCases := []struct {
+		name           string
+		inputNodes     []*nomad.Node
+		expectedOutput map[capacityKey]*capacity
+	}{
+		{
+			name:           "no nodes",
+			inputNodes:     nil,
+			expectedOutput: map[capacityKey]*capacity{},
+		},
+		{
+			name: "single node",
+			inputNodes: []*nomad.Node{
+				{NodePool: "default", Status: "ready", CPUAllocatable: 1000, CPUAllocated: 250,
+					MemoryAllocatable: 2048, MemoryAllocated: 512},
+			},
+			expectedOutput: map[capacityKey]*capacity{
+				{nodePool: "default", nodeStatus: "ready"}: {
+					cpuAllocatable: 1000, cpuAllocated: 250, memoryAllocatable: 2048, memoryAllocated: 512},
+			},
+		},
+		{
+			name: "grouped by node pool and status",
+			inputNodes: []*nomad.Node{
+				{NodePool: "default", Status: "ready", CPUAllocatable: 1000, CPUAllocated: 250,
+					MemoryAllocatable: 2048, MemoryAllocated: 512},
+				{NodePool: "default", Status: "ready", CPUAllocatable: 3000, CPUAllocated: 750,
+					MemoryAllocatable: 4096, MemoryAllocated: 1024},
+				{NodePool: "default", Status: "down", CPUAllocatable: 500, MemoryAllocatable: 1024},
+				{NodePool: "gpu", Status: "ready", CPUAllocatable: 8000, CPUAllocated: 8000,
+					MemoryAllocatable: 16384, MemoryAllocated: 8192},
+			},
+			expectedOutput: map[capacityKey]*capacity{
+				{nodePool: "default", nodeStatus: "ready"}: {
+					cpuAllocatable: 4000, cpuAllocated: 1000, memoryAllocatable: 6144, memoryAllocated: 1536},
+				{nodePool: "default", nodeStatus: "down"}: {
+					cpuAllocatable: 500, memoryAllocatable: 1024},
+				{nodePool: "gpu", nodeStatus: "ready"}: {
+					cpuAllocatable: 8000, cpuAllocated: 8000, memoryAllocatable: 16384, memoryAllocated: 8192},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topology := nomad.Topology{Detail: &nomad.Detail{Nodes: tc.inputNodes}}
+			must.Eq(t, tc.expectedOutput, topologyCapacity(&topology))
+		})
+	}
+}
+
+func Test_ratio(t *testing.T) {
+
+	testCases := []struct {
+		name           string
+		inputUsed      int64
+		inputTotal     int64
+		expectedOutput float64
+		expectedOK     bool
+	}{
+		{name: "unused", inputUsed: 0, inputTotal: 1000, expectedOutput: 0, expectedOK: true},
+		{name: "partially used", inputUsed: 250, inputTotal: 1000, expectedOutput: 0.25, expectedOK: true},
+		{name: "fully used", inputUsed: 1000, inputTotal: 1000, expectedOutput: 1, expectedOK: true},
+		{name: "zero total", inputUsed: 0, inputTotal: 0, expectedOK: false},
+		{name: "negative total", inputUsed: 10, inputTotal: -1, expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualOutput, actualOK := ratio(tc.inputUsed, tc.inputTotal)
+			must.Eq(t, tc.expectedOutput, actualOutput)
+			must.Eq(t, tc.expectedOK, actualOK)
+		})
+	}
+}
+
+func TestTopology_registerCapacityMetrics(t *testing.T) {
+
+	euw1 := region{result: &nomad.Topology{Detail: &nomad.Detail{Nodes: []*nomad.Node{
+		{NodePool: "default", Status: "ready", CPUAllocatable: 1000, CPUAllocated: 250},
+		{NodePool: "default", Status: "down"},
+	}}}}
+
+	euw2 := region{result: &nomad.Topology{Detail: &nomad.Detail{Nodes: []*nomad.Node{
+		{NodePool: "default", Status: "ready", CPUAllocatable: 2000, CPUAllocated: 1000},
+	}}}}
+
+	// Regions which have not been collected are not reported. Registering the
+	// gauges again replaces the functions of any previous registration.
+	topology := Topology{regions: map[string]*region{"euw1": &euw1, "euw2": &euw2, "euw3": {}}}
+	topology.registerCapacityMetrics()
+
+	var buf bytes.Buffer
+	must.NoError(t, metrics.Default.WriteText(&buf))
+
+	var actualLines []string
+
+	for line := range strings.Lines(buf.String()) {
+		if strings.HasPrefix(line, "attila_region_cpu_") {
+			actualLines = append(actualLines, strings.TrimSpace(line))
+		}
+	}
+
+	// The groups of each region are reported separately, and the ratio is not
+	// reported for the group of down nodes, which has no allocatable CPU.
+	must.Eq(t, []string{
+		`attila_region_cpu_allocatable_mhz{region="euw1",node_pool="default",node_status="down"} 0`,
+		`attila_region_cpu_allocatable_mhz{region="euw1",node_pool="default",node_status="ready"} 1000`,
+		`attila_region_cpu_allocatable_mhz{region="euw2",node_pool="default",node_status="ready"} 2000`,
+		`attila_region_cpu_allocated_mhz{region="euw1",node_pool="default",node_status="down"} 0`,
+		`attila_region_cpu_allocated_mhz{region="euw1",node_pool="default",node_status="ready"} 250`,
+		`attila_region_cpu_allocated_mhz{region="euw2",node_pool="default",node_status="ready"} 1000`,
+		`attila_region_cpu_utilization_ratio{region="euw1",node_pool="default",node_status="ready"} 0.25`,
+		`attila_region_cpu_utilization_ratio{region="euw2",node_pool="default",node_status="ready"} 0.5`,
+	}, actualLines)
+}
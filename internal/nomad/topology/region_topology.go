@@ -4,7 +4,9 @@
 package topology
 
 import (
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
@@ -30,15 +32,24 @@ type region struct {
 	result     *nomad.Topology
 	resultLock sync.RWMutex
 
+	// lastSuccess is the time, in Unix nanoseconds, that the last successful
+	// collection finished. It is zero until the first collection succeeds.
+	lastSuccess atomic.Int64
+
+	// updateFn is called each time a new result is stored, so the controller
+	// can notify watchers of the change.
+	updateFn func()
+
 	// shutdownCh is used to instruct the long-lived routine to shut down.
 	shutdownCh chan struct{}
 }
 
-func newRegion(name string, clients *client.Clients, logger *zap.Logger) *region {
+func newRegion(name string, clients *client.Clients, logger *zap.Logger, updateFn func()) *region {
 	return &region{
 		name:       name,
 		clients:    clients,
 		logger:     logger.With(zap.String("region", name)),
+		updateFn:   updateFn,
 		shutdownCh: make(chan struct{}),
 	}
 }
@@ -93,6 +104,14 @@ func (r *region) runExecute() {
 	startTime := time.Now()
 	r.logger.Info("performing execution of data collection")
 
+	// Record the outcome on every return path. It is only set to success
+	// once the result has been stored.
+	outcome := "failure"
+	defer func() {
+		collectionsTotal.Inc(r.name, outcome)
+		collectionDuration.Observe(time.Since(startTime).Seconds(), r.name)
+	}()
+
 	apiClient, err := r.clients.Get(r.name)
 	if err != nil {
 		r.logger.Error("failed to get API client", zap.Error(err))
@@ -111,16 +130,37 @@ func (r *region) runExecute() {
 		return
 	}
 
+	// The result is always stored, so its create time reflects the latest
+	// collection, but watchers are only notified when the topology changed.
 	r.resultLock.Lock()
+	changed := topologyChanged(r.result, result)
 	r.result = result
 	r.resultLock.Unlock()
 
+	if changed {
+		r.updateFn()
+	}
+
+	outcome = "success"
+	r.lastSuccess.Store(time.Now().UnixNano())
+
 	r.logger.Info(
 		"finished execution of data collection",
 		zap.Int64("dur", int64(time.Since(startTime))),
 	)
 }
 
+// topologyChanged reports whether the current topology differs from the
+// previous collection, which is nil before the first collection succeeds. The
+// create time is not compared, as it differs on every collection.
+func topologyChanged(previous, current *nomad.Topology) bool {
+	if previous == nil {
+		return true
+	}
+	return !reflect.DeepEqual(previous.Overview, current.Overview) ||
+		!reflect.DeepEqual(previous.Detail, current.Detail)
+}
+
 func (r *region) executeAgentMembers(client *api.Client, result *nomad.Topology) error {
 
 	members, err := client.Agent().Members()
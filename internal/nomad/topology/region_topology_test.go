@@ -0,0 +1,91 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/nomad/client"
+)
+
+func Test_region_runExecute(t *testing.T) {
+
+	// Run a fake Nomad API with a single server and client. The status of the
+	// client can be changed, so the topology changes between collections.
+	var nodeStatus atomic.Value
+	nodeStatus.Store(api.NodeStatusReady)
+
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+
+		switch r.URL.Path {
+		case "/v1/agent/members":
+			resp = api.ServerMembers{Members: []*api.AgentMember{
+				{Name: "server-1", Status: "alive", Tags: map[string]string{"id": "server-1"}},
+			}}
+		case "/v1/nodes":
+			resp = []*api.NodeListStub{{
+				ID:                "node-1",
+				NodePool:          "default",
+				Status:            nodeStatus.Load().(string),
+				NodeResources:     &api.NodeResources{Cpu: api.NodeCpuResources{CpuShares: 1000}},
+				ReservedResources: &api.NodeReservedResources{},
+			}}
+		case "/v1/node/node-1/allocations":
+			resp = []*api.Allocation{}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	nomadClient, err := api.NewClient(&api.Config{Address: nomadServer.URL})
+	must.NoError(t, err)
+
+	clients := client.New(zap.NewNop())
+	clients.Set("euw1", nomadClient)
+
+	var numUpdates int
+	r := newRegion("euw1", clients, zap.NewNop(), func() { numUpdates++ })
+
+	// The first collection always notifies watchers.
+	r.runExecute()
+	must.Eq(t, 1, numUpdates)
+
+	firstResult := r.getResult()
+	must.NotNil(t, firstResult)
+
+	// Collecting an unchanged topology stores the result, but does not notify
+	// watchers.
+	r.runExecute()
+	must.Eq(t, 1, numUpdates)
+	must.NotEq(t, firstResult, r.getResult())
+
+	// A change to the topology notifies watchers.
+	nodeStatus.Store(api.NodeStatusDown)
+
+	r.runExecute()
+	must.Eq(t, 2, numUpdates)
+	must.Eq(t, api.NodeStatusDown, r.getResult().Detail.Nodes[0].Status)
+
+	// A failed collection keeps the previous result and does not notify
+	// watchers.
+	clients.Delete("euw1")
+
+	r.runExecute()
+	must.Eq(t, 2, numUpdates)
+	must.Eq(t, api.NodeStatusDown, r.getResult().Detail.Nodes[0].Status)
+}
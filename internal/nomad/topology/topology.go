@@ -21,14 +21,25 @@ type Topology struct {
 	// use the lock for concurrent safety.
 	regions     map[string]*region
 	regionsLock sync.RWMutex
+
+	// index is incremented each time the topology of a region changes, at
+	// which point indexCh is closed and replaced. Access should use the index
+	// lock for concurrent safety.
+	index     uint64
+	indexCh   chan struct{}
+	indexLock sync.Mutex
 }
 
 func New(logger *zap.Logger, clients *client.Clients) nomad.TopologyController {
-	return &Topology{
+	t := Topology{
 		clients: clients,
 		logger:  logger.Named("region_topology"),
 		regions: make(map[string]*region),
+		indexCh: make(chan struct{}),
 	}
+	t.registerAgeMetric()
+	t.registerCapacityMetrics()
+	return &t
 }
 
 func (c *Topology) RegionSet(name string, _ *api.Client) {
@@ -43,7 +54,7 @@ func (c *Topology) RegionSet(name string, _ *api.Client) {
 		return
 	}
 
-	c.regions[name] = newRegion(name, c.clients, c.logger)
+	c.regions[name] = newRegion(name, c.clients, c.logger, c.bumpIndex)
 	go c.regions[name].run()
 }
 
@@ -54,7 +65,23 @@ func (c *Topology) RegionDelete(name string) {
 	if capacityRunner, ok := c.regions[name]; ok {
 		capacityRunner.stop()
 		delete(c.regions, name)
+		c.bumpIndex()
+	}
+}
+
+func (c *Topology) StopCollectors() {
+	c.regionsLock.Lock()
+	defer c.regionsLock.Unlock()
+
+	if len(c.regions) == 0 {
+		return
+	}
+
+	for name, capacityRunner := range c.regions {
+		capacityRunner.stop()
+		delete(c.regions, name)
 	}
+	c.bumpIndex()
 }
 
 func (c *Topology) RegionNum() int {
@@ -95,3 +122,22 @@ func (c *Topology) GetTopology(name string) *nomad.Topology {
 
 	return nil
 }
+
+func (c *Topology) GetTopologyIndex() (uint64, <-chan struct{}) {
+	c.indexLock.Lock()
+	defer c.indexLock.Unlock()
+
+	return c.index, c.indexCh
+}
+
+// bumpIndex increments the topology index and notifies any blocking queries
+// that the topology has changed. It is called by the region collectors each
+// time they store a result which differs from the previous collection.
+func (c *Topology) bumpIndex() {
+	c.indexLock.Lock()
+	defer c.indexLock.Unlock()
+
+	c.index++
+	close(c.indexCh)
+	c.indexCh = make(chan struct{})
+}
@@ -4,16 +4,27 @@
 package selector
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/hashicorp/nomad/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/rasorp/attila/internal/metrics"
 	"github.com/rasorp/attila/internal/register/method/selector/builtin"
+	"github.com/rasorp/attila/internal/tracing"
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
 
+var evaluationsTotal = metrics.Default.NewCounter(
+	"attila_method_selector_evaluations_total",
+	"The number of job register method selector evaluations, by provider and result.",
+	"provider", "result",
+)
+
 // RunRequest is the selector object passed when triggering a run. It is
 // specifically distinct from the jobsdk object to decouple the packages and
 // allow for independent changes.
@@ -102,8 +113,9 @@ func New(log *zap.Logger, cfgs []*jobsdk.MethodSelectorConfig) (*Selector, error
 }
 
 // Run runs all configured selectors against the given job and returns whether
-// the job passed all the matching runs.
-func (s *Selector) Run(req *RunRequest) (*RunResult, error) {
+// the job passed all the matching runs. A span is created for each selector
+// run, as a child of any span within the context.
+func (s *Selector) Run(ctx context.Context, req *RunRequest) (*RunResult, error) {
 
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -123,11 +135,27 @@ func (s *Selector) Run(req *RunRequest) (*RunResult, error) {
 			zap.String("selector_provider", selector.Provider()),
 		)
 
+		_, span := tracing.Start(ctx, "method_selector.run", trace.WithAttributes(
+			attribute.String("attila.selector.name", selector.Name()),
+			attribute.String("attila.selector.provider", selector.Provider()),
+		))
+
 		result, err := selector.Run(&jobsdk.MethodSelectorRunRequest{Job: req.Job})
 		if err != nil {
+			evaluationsTotal.Inc(selector.Provider(), "error")
+			tracing.End(span, err)
 			return nil, fmt.Errorf("failed to run selector: %w", err)
 		}
 
+		span.SetAttributes(attribute.Bool("attila.selector.match", result.Match))
+		tracing.End(span, nil)
+
+		if result.Match {
+			evaluationsTotal.Inc(selector.Provider(), "match")
+		} else {
+			evaluationsTotal.Inc(selector.Provider(), "no_match")
+		}
+
 		s.logger.Info("successfully ran job register method selector",
 			zap.String("selector_name", selector.Name()),
 			zap.String("selector_provider", selector.Provider()),
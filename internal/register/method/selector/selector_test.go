@@ -4,12 +4,18 @@
 package selector
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/rasorp/attila/internal/tracing"
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
 
@@ -71,7 +77,7 @@ func TestSelector_Run(t *testing.T) {
 
 	t.Run("nil receiver returns error", func(t *testing.T) {
 		var s *Selector
-		result, err := s.Run(&RunRequest{Job: matchAllJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: matchAllJob})
 		must.Error(t, err)
 		must.Nil(t, result)
 	})
@@ -80,7 +86,7 @@ func TestSelector_Run(t *testing.T) {
 		s, err := New(zaptest.NewLogger(t), nil)
 		must.NoError(t, err)
 		must.NotNil(t, s)
-		result, err := s.Run(nil)
+		result, err := s.Run(context.Background(), nil)
 		must.Error(t, err)
 		must.Nil(t, result)
 	})
@@ -89,7 +95,7 @@ func TestSelector_Run(t *testing.T) {
 		s, err := New(zaptest.NewLogger(t), nil)
 		must.NoError(t, err)
 		must.NotNil(t, s)
-		result, err := s.Run(&RunRequest{Job: nil})
+		result, err := s.Run(context.Background(), &RunRequest{Job: nil})
 		must.Error(t, err)
 		must.Nil(t, result)
 	})
@@ -98,7 +104,7 @@ func TestSelector_Run(t *testing.T) {
 		s, err := New(zaptest.NewLogger(t), nil)
 		must.NoError(t, err)
 		must.NotNil(t, s)
-		result, err := s.Run(&RunRequest{Job: matchAllJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: matchAllJob})
 		must.NoError(t, err)
 		must.True(t, result.Match)
 	})
@@ -115,7 +121,7 @@ func TestSelector_Run(t *testing.T) {
 		}
 		s, err := New(zaptest.NewLogger(t), cfgs)
 		must.NoError(t, err)
-		result, err := s.Run(&RunRequest{Job: matchAllJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: matchAllJob})
 		must.NoError(t, err)
 		must.True(t, result.Match)
 	})
@@ -132,7 +138,7 @@ func TestSelector_Run(t *testing.T) {
 		}
 		s, err := New(zaptest.NewLogger(t), cfgs)
 		must.NoError(t, err)
-		result, err := s.Run(&RunRequest{Job: nomatchJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: nomatchJob})
 		must.NoError(t, err)
 		must.False(t, result.Match)
 	})
@@ -156,7 +162,7 @@ func TestSelector_Run(t *testing.T) {
 		}
 		s, err := New(zaptest.NewLogger(t), cfgs)
 		must.NoError(t, err)
-		result, err := s.Run(&RunRequest{Job: matchAllJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: matchAllJob})
 		must.NoError(t, err)
 		must.True(t, result.Match)
 	})
@@ -180,8 +186,51 @@ func TestSelector_Run(t *testing.T) {
 		}
 		s, err := New(zaptest.NewLogger(t), cfgs)
 		must.NoError(t, err)
-		result, err := s.Run(&RunRequest{Job: matchAllJob})
+		result, err := s.Run(context.Background(), &RunRequest{Job: matchAllJob})
 		must.NoError(t, err)
 		must.False(t, result.Match)
 	})
 }
+
+func TestSelector_Run_tracing(t *testing.T) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	cfgs := []*jobsdk.MethodSelectorConfig{
+		{
+			MethodSelectorBaseConfig: &jobsdk.MethodSelectorBaseConfig{
+				Provider: "expr",
+				Name:     "ns-filter",
+			},
+			ProviderConfig: map[string]any{"expression": `job.Namespace == "platform"`},
+		},
+	}
+	s, err := New(zaptest.NewLogger(t), cfgs)
+	must.NoError(t, err)
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+
+	result, err := s.Run(ctx, &RunRequest{Job: &api.Job{ID: new("example"), Namespace: new("platform")}})
+	must.NoError(t, err)
+	must.True(t, result.Match)
+
+	parent.End()
+
+	// The selector span is ended first, so is the first exported.
+	spans := exporter.GetSpans()
+	must.Len(t, 2, spans)
+	must.Eq(t, "method_selector.run", spans[0].Name)
+	must.Eq(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+
+	attrs := attribute.NewSet(spans[0].Attributes...)
+
+	name, _ := attrs.Value("attila.selector.name")
+	must.Eq(t, "ns-filter", name.AsString())
+
+	match, _ := attrs.Value("attila.selector.match")
+	must.True(t, match.AsBool())
+}
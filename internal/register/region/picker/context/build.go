@@ -4,31 +4,50 @@
 package context
 
 import (
+	stdcontext "context"
+
 	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/fanout"
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
 
-type BuildRegionContextFunc func(*domain.Region) (map[string]any, error)
+type BuildRegionContextFunc func(stdcontext.Context, *domain.Region) (map[string]any, error)
 
-func BuildCandidates(regions []*domain.Region, buildContext BuildRegionContextFunc) ([]jobsdk.RegisterRuleRegionCandidate, error) {
+// BuildCandidates returns a candidate for each region, in the same order as
+// the passed regions. The region contexts are built in parallel according to
+// the fan-out config.
+func BuildCandidates(
+	ctx stdcontext.Context,
+	fanOut *fanout.Config,
+	regions []*domain.Region,
+	buildContext BuildRegionContextFunc,
+) ([]jobsdk.RegisterRuleRegionCandidate, error) {
 	if len(regions) == 0 {
 		return nil, nil
 	}
 
-	candidates := make([]jobsdk.RegisterRuleRegionCandidate, 0, len(regions))
-	for _, region := range regions {
-		candidate := jobsdk.RegisterRuleRegionCandidate{
+	candidates := make([]jobsdk.RegisterRuleRegionCandidate, len(regions))
+	for i, region := range regions {
+		candidates[i] = jobsdk.RegisterRuleRegionCandidate{
 			Name:  region.Name,
 			Group: region.Group,
 		}
-		if buildContext != nil {
-			ctx, err := buildContext(region)
-			if err != nil {
-				return nil, err
-			}
-			candidate.Context = ctx
+	}
+
+	if buildContext == nil {
+		return candidates, nil
+	}
+
+	err := fanout.Run(ctx, fanOut, len(regions), func(ctx stdcontext.Context, i int) error {
+		regionCtx, err := buildContext(ctx, regions[i])
+		if err != nil {
+			return err
 		}
-		candidates = append(candidates, candidate)
+		candidates[i].Context = regionCtx
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return candidates, nil
@@ -4,13 +4,25 @@
 package picker
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rasorp/attila/internal/metrics"
 	"github.com/rasorp/attila/internal/register/region/picker/builtin"
+	"github.com/rasorp/attila/internal/tracing"
 	jobsdk "github.com/rasorp/attila/pkg/job"
 )
 
+var evaluationsTotal = metrics.Default.NewCounter(
+	"attila_region_picker_evaluations_total",
+	"The number of region picker evaluations, by provider and outcome.",
+	"provider", "outcome",
+)
+
 type Picker struct {
 
 	// providers is keyed by the operator provided name and not protected by a
@@ -72,7 +84,11 @@ func New(cfgs []*jobsdk.RegionPickerConfig) (*Picker, error) {
 	return &Picker{providers: pickers}, nil
 }
 
-func (p *Picker) Process(req *jobsdk.RegionPickerRunRequest) ([]jobsdk.RegisterRuleRegionCandidate, error) {
+// Process runs each region picker of the rule in order, with each picker
+// receiving the candidates returned by the previous one. A span is created for
+// each picker run, as a child of any span within the context.
+func (p *Picker) Process(
+	ctx context.Context, req *jobsdk.RegionPickerRunRequest) ([]jobsdk.RegisterRuleRegionCandidate, error) {
 
 	if req == nil {
 		return nil, errors.New("empy picker request")
@@ -93,13 +109,25 @@ func (p *Picker) Process(req *jobsdk.RegionPickerRunRequest) ([]jobsdk.RegisterR
 		nextReq := *req
 		nextReq.RegionCandidates = current
 
+		_, span := tracing.Start(ctx, "region_picker.run", trace.WithAttributes(
+			attribute.String("attila.picker.name", picker.Name),
+			attribute.String("attila.picker.provider", picker.Provider),
+			attribute.Int("attila.picker.candidates_in", len(current)),
+		))
+
 		result, err := provider.Run(&nextReq)
+		if err == nil && result == nil {
+			err = fmt.Errorf("region picker %q returned nil result", picker.Name)
+		}
 		if err != nil {
+			evaluationsTotal.Inc(picker.Provider, "error")
+			tracing.End(span, err)
 			return nil, err
 		}
-		if result == nil {
-			return nil, fmt.Errorf("region picker %q returned nil result", picker.Name)
-		}
+		evaluationsTotal.Inc(picker.Provider, "success")
+
+		span.SetAttributes(attribute.Int("attila.picker.candidates_out", len(result.RegionCandidates)))
+		tracing.End(span, nil)
 		current = builtin.CopyCandidates(result.RegionCandidates)
 	}
 
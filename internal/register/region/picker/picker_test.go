@@ -4,6 +4,7 @@
 package picker
 
 import (
+	"context"
 	"testing"
 
 	"github.com/shoenig/test/must"
@@ -357,7 +358,7 @@ func TestPicker_Process(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.useNilReceiver {
 				var nilPicker *Picker
-				actualResponse, err := nilPicker.Process(tc.inputPickerRunRequest)
+				actualResponse, err := nilPicker.Process(context.Background(), tc.inputPickerRunRequest)
 				must.Nil(t, actualResponse)
 				must.ErrorContains(t, err, tc.expectedErrorContains)
 				return
@@ -366,7 +367,7 @@ func TestPicker_Process(t *testing.T) {
 			pickerImpl, err := New(tc.configuredPickers)
 			must.NoError(t, err)
 
-			actualResponse, err := pickerImpl.Process(tc.inputPickerRunRequest)
+			actualResponse, err := pickerImpl.Process(context.Background(), tc.inputPickerRunRequest)
 			if tc.expectedErrorContains != "" {
 				must.Nil(t, actualResponse)
 				must.ErrorContains(t, err, tc.expectedErrorContains)
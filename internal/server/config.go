@@ -7,19 +7,37 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"strings"
-
-	"github.com/hashicorp/go-set/v3"
-	"go.uber.org/zap"
+	"time"
 
+	"github.com/rasorp/attila/internal/helper/fanout"
 	"github.com/rasorp/attila/internal/logger"
+	"github.com/rasorp/attila/internal/nomad/job"
+	serverHTTP "github.com/rasorp/attila/internal/server/http"
 	storebackend "github.com/rasorp/attila/internal/store/backend"
+	"github.com/rasorp/attila/internal/tracing"
 )
 
 type Config struct {
 	Log   *logger.Config       `hcl:"log,optional"`
 	State *storebackend.Config `hcl:"state,optional"`
 	HTTP  *HTTPConfig          `hcl:"http,optional"`
+	ACL   *ACLConfig           `hcl:"acl,optional"`
+
+	// Tracing configures the export of OpenTelemetry spans for API requests
+	// and job registration planning and runs.
+	Tracing *tracing.Config `hcl:"tracing,optional"`
+
+	// FanOut configures how job registration plans and runs call Nomad
+	// regions in parallel.
+	FanOut *fanout.Config `hcl:"fan_out,optional"`
+
+	// Plan configures the expiry of job registration plans, and when runs of
+	// stale plans are rejected.
+	Plan *job.PlanConfig `hcl:"plan,optional"`
+
+	// Drift configures the periodic check of the regions of successful job
+	// registration runs for changes made outside of Attila.
+	Drift *job.DriftConfig `hcl:"drift,optional"`
 }
 
 func (c *Config) Merge(z *Config) *Config {
@@ -32,6 +50,11 @@ func (c *Config) Merge(z *Config) *Config {
 	result.Log = c.Log.Merge(z.Log)
 	result.State = c.State.Merge(z.State)
 	result.HTTP = c.HTTP.Merge(z.HTTP)
+	result.ACL = c.ACL.Merge(z.ACL)
+	result.Tracing = c.Tracing.Merge(z.Tracing)
+	result.FanOut = c.FanOut.Merge(z.FanOut)
+	result.Plan = c.Plan.Merge(z.Plan)
+	result.Drift = c.Drift.Merge(z.Drift)
 
 	return &result
 }
@@ -52,16 +75,152 @@ func (c *Config) Validate() error {
 		errs = append(errs, err)
 	}
 
+	if err := c.Tracing.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.FanOut.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.Plan.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.Drift.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errors.Join(errs...)
 }
 
 type HTTPConfig struct {
 	Binds          []*BindConfig `hcl:"bind,optional"`
 	AccessLogLevel string        `hcl:"access_log_level,optional"`
+
+	// TLS is the TLS configuration used by HTTPS binds which do not include
+	// their own.
+	TLS *TLSConfig `hcl:"tls,optional"`
+
+	// RequestTimeout is the deadline applied to requests which call Nomad
+	// regions, such as job registration plans and runs. Once it elapses, any
+	// outstanding Nomad calls are cancelled. It is parsed as a Go duration and
+	// requests have no deadline if it is not set.
+	RequestTimeout string `hcl:"request_timeout,optional"`
 }
 
 type BindConfig struct {
 	Addr string `hcl:"addr,optional"`
+
+	// TLS overrides the HTTP TLS configuration for this bind. It is only used
+	// when the bind address has the https scheme.
+	TLS *TLSConfig `hcl:"tls,optional"`
+}
+
+// TLSConfig is the TLS configuration for HTTPS binds. The files are re-read
+// when the server receives a SIGHUP, so certificates can be rotated without
+// restarting the server.
+type TLSConfig struct {
+
+	// CertFile and KeyFile are the paths to the PEM encoded certificate and
+	// private key the server presents to clients.
+	CertFile string `hcl:"cert_file"`
+	KeyFile  string `hcl:"key_file"`
+
+	// ClientCAFile is the path to the PEM encoded CA certificates used to
+	// verify client certificates. When set, client certificates are verified
+	// if presented.
+	ClientCAFile string `hcl:"client_ca_file,optional"`
+
+	// VerifyClient requires all clients to present a certificate signed by
+	// the client CA, which enables mTLS.
+	VerifyClient *bool `hcl:"verify_client,optional"`
+}
+
+// VerifyClientEnabled is a helper function that informs the caller if clients
+// must present a valid certificate.
+func (t *TLSConfig) VerifyClientEnabled() bool {
+	return t != nil && t.VerifyClient != nil && *t.VerifyClient
+}
+
+// Validate performs validation of the TLS configuration block. It does not
+// read the configured files, as this happens when the listener is set up.
+func (t *TLSConfig) Validate() error {
+
+	var errs []error
+
+	if t.CertFile == "" {
+		errs = append(errs, errors.New("must set cert_file parameter"))
+	}
+	if t.KeyFile == "" {
+		errs = append(errs, errors.New("must set key_file parameter"))
+	}
+	if t.VerifyClientEnabled() && t.ClientCAFile == "" {
+		errs = append(errs, errors.New("must set client_ca_file parameter when verify_client is enabled"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Merge combines the passed TLS configuration with the current object.
+func (t *TLSConfig) Merge(z *TLSConfig) *TLSConfig {
+
+	if t == nil {
+		return z
+	}
+	if z == nil {
+		return t
+	}
+
+	result := *t
+
+	if z.CertFile != "" {
+		result.CertFile = z.CertFile
+	}
+	if z.KeyFile != "" {
+		result.KeyFile = z.KeyFile
+	}
+	if z.ClientCAFile != "" {
+		result.ClientCAFile = z.ClientCAFile
+	}
+	if z.VerifyClient != nil {
+		result.VerifyClient = z.VerifyClient
+	}
+
+	return &result
+}
+
+// RequestTimeoutDuration returns the parsed request timeout, or zero if it is
+// not set. The config must have been validated.
+func (h *HTTPConfig) RequestTimeoutDuration() time.Duration {
+	timeout, _ := time.ParseDuration(h.RequestTimeout)
+	return timeout
+}
+
+// BindTLS returns the TLS configuration for the passed bind, which is the
+// bind configuration if set, otherwise the HTTP configuration.
+func (h *HTTPConfig) BindTLS(bind *BindConfig) *TLSConfig {
+	if bind.TLS != nil {
+		return bind.TLS
+	}
+	return h.TLS
+}
+
+// ForwardTLS returns the TLS configuration used when forwarding requests to
+// the leader of a replicated state backend. This is the HTTP TLS configuration,
+// or that of the first HTTPS bind if it is not set. A nil return indicates
+// HTTPS is not configured.
+func (h *HTTPConfig) ForwardTLS() *TLSConfig {
+	if h.TLS != nil {
+		return h.TLS
+	}
+	for _, bind := range h.Binds {
+		parsedURL, err := url.Parse(bind.Addr)
+		if err == nil && parsedURL.Scheme == "https" && bind.TLS != nil {
+			return bind.TLS
+		}
+	}
+	return nil
 }
 
 func (h *HTTPConfig) Validate() error {
@@ -75,9 +234,22 @@ func (h *HTTPConfig) Validate() error {
 	if len(h.Binds) < 1 {
 		errs = append(errs, errors.New("http bind address required"))
 	}
-	if _, err := zap.ParseAtomicLevel(strings.ToLower(h.AccessLogLevel)); err != nil {
+	if _, err := serverHTTP.ParseAccessLogLevel(h.AccessLogLevel); err != nil {
 		errs = append(errs, fmt.Errorf("failed to parse access log level: %w", err))
 	}
+	if h.RequestTimeout != "" {
+		if timeout, err := time.ParseDuration(h.RequestTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse request timeout: %w", err))
+		} else if timeout < 0 {
+			errs = append(errs, errors.New("request timeout must not be negative"))
+		}
+	}
+
+	if h.TLS != nil {
+		if err := h.TLS.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to validate tls: %w", err))
+		}
+	}
 
 	for _, bind := range h.Binds {
 		parsedURL, err := url.Parse(bind.Addr)
@@ -85,11 +257,21 @@ func (h *HTTPConfig) Validate() error {
 			errs = append(errs, fmt.Errorf("failed to parse bind address: %w", err))
 		} else {
 			switch parsedURL.Scheme {
-			case "unix", "http", "https":
+			case "unix", "http":
+			case "https":
+				if h.BindTLS(bind) == nil {
+					errs = append(errs, fmt.Errorf("https bind %q requires tls config", bind.Addr))
+				}
 			default:
 				errs = append(errs, fmt.Errorf("unsupported bind protocol %q", parsedURL.Scheme))
 			}
 		}
+
+		if bind.TLS != nil {
+			if err := bind.TLS.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to validate bind %q tls: %w", bind.Addr, err))
+			}
+		}
 	}
 
 	return errors.Join(errs...)
@@ -100,19 +282,56 @@ func (h *HTTPConfig) Merge(z *HTTPConfig) *HTTPConfig {
 	if h == nil {
 		return z
 	}
+	if z == nil {
+		return h
+	}
 
 	result := *h
 
 	if z.AccessLogLevel != "" {
 		result.AccessLogLevel = z.AccessLogLevel
 	}
+	if z.RequestTimeout != "" {
+		result.RequestTimeout = z.RequestTimeout
+	}
+
+	result.TLS = h.TLS.Merge(z.TLS)
+
+	// Bind blocks are not merged individually, so a non-empty set of binds
+	// replaces the existing set. Otherwise, the default bind would remain and
+	// could conflict with a configured bind using the same address.
+	if len(z.Binds) > 0 {
+		result.Binds = z.Binds
+	}
+
+	return &result
+}
+
+type ACLConfig struct {
+	Enable *bool `hcl:"enabled"`
+}
+
+// Enabled is a helper function that informs the caller if ACLs are enabled.
+// When enabled, every API request must include a token which grants access to
+// the requested resource.
+func (a *ACLConfig) Enabled() bool {
+	return a != nil && a.Enable != nil && *a.Enable
+}
+
+func (a *ACLConfig) Merge(z *ACLConfig) *ACLConfig {
+
+	if a == nil {
+		return z
+	}
+	if z == nil {
+		return a
+	}
 
-	// Use a set to deduplicate the bind addresses, so it does the heavy
-	// lifitng and ensures accuracy.
-	bindSet := set.New[*BindConfig](0)
-	bindSet.InsertSlice(result.Binds)
-	bindSet.InsertSlice(h.Binds)
-	result.Binds = bindSet.Slice()
+	result := *a
+
+	if z.Enable != nil {
+		result.Enable = z.Enable
+	}
 
 	return &result
 }
@@ -131,5 +350,10 @@ func DefaultConfig() *Config {
 				},
 			},
 		},
+		ACL:     &ACLConfig{},
+		Tracing: tracing.DefaultConfig(),
+		FanOut:  fanout.DefaultConfig(),
+		Plan:    job.DefaultPlanConfig(),
+		Drift:   job.DefaultDriftConfig(),
 	}
 }
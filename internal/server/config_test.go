@@ -0,0 +1,130 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTPConfig_Validate_TLS(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		inputConfig   *HTTPConfig
+		expectedError string
+	}{
+		{
+			name: "https without tls",
+			inputConfig: &HTTPConfig{
+				AccessLogLevel: "info",
+				Binds:          []*BindConfig{{Addr: "https://127.0.0.1:8443"}},
+			},
+			expectedError: `https bind "https://127.0.0.1:8443" requires tls config`,
+		},
+		{
+			name: "https with http tls",
+			inputConfig: &HTTPConfig{
+				AccessLogLevel: "info",
+				Binds:          []*BindConfig{{Addr: "https://127.0.0.1:8443"}},
+				TLS:            &TLSConfig{CertFile: "/cert.pem", KeyFile: "/key.pem"},
+			},
+		},
+		{
+			name: "https with bind tls",
+			inputConfig: &HTTPConfig{
+				AccessLogLevel: "info",
+				Binds: []*BindConfig{{
+					Addr: "https://127.0.0.1:8443",
+					TLS:  &TLSConfig{CertFile: "/cert.pem", KeyFile: "/key.pem"},
+				}},
+			},
+		},
+		{
+			name: "verify client without ca",
+			inputConfig: &HTTPConfig{
+				AccessLogLevel: "info",
+				Binds:          []*BindConfig{{Addr: "https://127.0.0.1:8443"}},
+				TLS:            &TLSConfig{CertFile: "/cert.pem", KeyFile: "/key.pem", VerifyClient: new(true)},
+			},
+			expectedError: "must set client_ca_file parameter when verify_client is enabled",
+		},
+		{
+			name: "missing key",
+			inputConfig: &HTTPConfig{
+				AccessLogLevel: "info",
+				Binds:          []*BindConfig{{Addr: "http://127.0.0.1:8080"}},
+				TLS:            &TLSConfig{CertFile: "/cert.pem"},
+			},
+			expectedError: "must set key_file parameter",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualError := tc.inputConfig.Validate()
+
+			if tc.expectedError != "" {
+				must.ErrorContains(t, actualError, tc.expectedError)
+			} else {
+				must.NoError(t, actualError)
+			}
+		})
+	}
+}
+
+func TestHTTPConfig_BindTLS(t *testing.T) {
+
+	httpTLS := &TLSConfig{CertFile: "/http.pem", KeyFile: "/http.key"}
+	bindTLS := &TLSConfig{CertFile: "/bind.pem", KeyFile: "/bind.key"}
+
+	cfg := HTTPConfig{TLS: httpTLS}
+
+	must.Eq(t, httpTLS, cfg.BindTLS(&BindConfig{Addr: "https://127.0.0.1:8443"}))
+	must.Eq(t, bindTLS, cfg.BindTLS(&BindConfig{Addr: "https://127.0.0.1:8443", TLS: bindTLS}))
+}
+
+func TestHTTPConfig_ForwardTLS(t *testing.T) {
+	httpTLS := &TLSConfig{CertFile: "http.pem", KeyFile: "http-key.pem"}
+	bindTLS := &TLSConfig{CertFile: "bind.pem", KeyFile: "bind-key.pem"}
+
+	testCases := []struct {
+		name           string
+		inputConfig    *HTTPConfig
+		expectedOutput *TLSConfig
+	}{
+		{
+			name: "no tls",
+			inputConfig: &HTTPConfig{
+				Binds: []*BindConfig{{Addr: "http://127.0.0.1:8080"}},
+			},
+			expectedOutput: nil,
+		},
+		{
+			name: "http tls",
+			inputConfig: &HTTPConfig{
+				TLS:   httpTLS,
+				Binds: []*BindConfig{{Addr: "https://127.0.0.1:8443", TLS: bindTLS}},
+			},
+			expectedOutput: httpTLS,
+		},
+		{
+			name: "https bind tls",
+			inputConfig: &HTTPConfig{
+				Binds: []*BindConfig{
+					{Addr: "unix:///var/run/attila.sock"},
+					{Addr: "https://127.0.0.1:8443", TLS: bindTLS},
+				},
+			},
+			expectedOutput: bindTLS,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expectedOutput, tc.inputConfig.ForwardTLS())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/server/nomad"
+	"github.com/rasorp/attila/internal/store"
+)
+
+// driftReconciler periodically checks the regions of successful job
+// registration runs for drift. The Nomad clients and drift results are only
+// held by the server which runs the Nomad controllers, so it is started and
+// stopped alongside them.
+type driftReconciler struct {
+	logger     *zap.Logger
+	controller nomad.Controller
+	state      store.State
+	interval   time.Duration
+
+	// stopCh is closed to stop the running reconciler and is nil when the
+	// reconciler is not running. All access should use the lock, as the
+	// reconciler is started and stopped as leadership changes.
+	stopCh chan struct{}
+	lock   sync.Mutex
+}
+
+func newDriftReconciler(
+	logger *zap.Logger, controller nomad.Controller, state store.State, interval time.Duration) *driftReconciler {
+	return &driftReconciler{
+		logger:     logger.Named("drift_reconciler"),
+		controller: controller,
+		state:      state,
+		interval:   interval,
+	}
+}
+
+// start runs the reconciler in a routine until stop is called. Calling start
+// on a running reconciler has no effect.
+func (d *driftReconciler) start() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stopCh != nil {
+		return
+	}
+
+	d.stopCh = make(chan struct{})
+	go d.run(d.stopCh)
+}
+
+// stop stops the running reconciler. Calling stop on a stopped reconciler has
+// no effect.
+func (d *driftReconciler) stop() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stopCh == nil {
+		return
+	}
+
+	close(d.stopCh)
+	d.stopCh = nil
+}
+
+func (d *driftReconciler) run(stopCh <-chan struct{}) {
+	d.logger.Info("starting drift reconciler", zap.Duration("interval", d.interval))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			d.logger.Info("shutting down drift reconciler")
+			return
+		case <-ticker.C:
+			d.reconcile(stopCh)
+		}
+	}
+}
+
+// reconcile performs a single drift check. It is limited to the interval, so a
+// slow region cannot delay later checks, and is cancelled if the reconciler is
+// stopped.
+func (d *driftReconciler) reconcile(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.interval)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := d.controller.JobDriftReconcile(ctx, d.state); err != nil {
+		d.logger.Error("failed to reconcile job drift", zap.Error(err))
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type ACLBootstrapResp struct {
+	Token                *domain.ACLToken `json:"token"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLPolicyCreateResp struct {
+	Policy               *domain.ACLPolicy `json:"policy"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLPolicyDeleteResp struct {
+	internalResponseMeta `json:"-"`
+}
+
+type ACLPolicyGetResp struct {
+	Policy               *domain.ACLPolicy `json:"policy"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLPolicyListResp struct {
+	Policies             []*domain.ACLPolicyStub `json:"policies"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLPolicyUpdateResp struct {
+	Policy               *domain.ACLPolicy `json:"policy"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLTokenCreateReq struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Policies []string `json:"policies"`
+}
+
+type ACLTokenCreateResp struct {
+	Token                *domain.ACLToken `json:"token"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLTokenDeleteResp struct {
+	internalResponseMeta `json:"-"`
+}
+
+type ACLTokenGetResp struct {
+	Token                *domain.ACLToken `json:"token"`
+	internalResponseMeta `json:"-"`
+}
+
+type ACLTokenListResp struct {
+	Tokens               []*domain.ACLTokenStub `json:"tokens"`
+	internalResponseMeta `json:"-"`
+}
+
+type aclEndpoint struct {
+	enabled bool
+	state   store.State
+}
+
+func (a aclEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(a.enabledCheck)
+
+	r.Post("/bootstrap", a.bootstrap)
+
+	r.Route("/policies", func(r chi.Router) {
+		r.Get("/", a.policyList)
+		r.Post("/", a.policyCreate)
+
+		r.Route("/{policyName}", func(r chi.Router) {
+			r.Use(a.policyContext)
+			r.Delete("/", a.policyDelete)
+			r.Get("/", a.policyGet)
+			r.Put("/", a.policyUpdate)
+		})
+	})
+
+	r.Route("/tokens", func(r chi.Router) {
+		r.Get("/", a.tokenList)
+		r.Post("/", a.tokenCreate)
+		r.Get("/self", a.tokenSelf)
+
+		r.Route("/{accessorID}", func(r chi.Router) {
+			r.Use(a.tokenContext)
+			r.Delete("/", a.tokenDelete)
+			r.Get("/", a.tokenGet)
+		})
+	})
+
+	return r
+}
+
+// enabledCheck rejects all ACL requests when the server does not have ACLs
+// enabled, as any tokens or policies created would have no effect.
+func (a aclEndpoint) enabledCheck(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			httpWriteResponseError(w, NewResponseError(errors.New("acl support disabled"), http.StatusBadRequest))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a aclEndpoint) bootstrap(w http.ResponseWriter, r *http.Request) {
+	stateReq := store.ACLTokenBootstrapReq{
+		Token: domain.NewACLToken("Bootstrap Token", domain.ACLTokenTypeManagement, nil),
+	}
+
+	bootstrapResp, err := a.state.ACL().Token().Bootstrap(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLBootstrapResp{
+			Token:                bootstrapResp.Token,
+			internalResponseMeta: newInternalResponseMeta(http.StatusCreated),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyCreate(w http.ResponseWriter, r *http.Request) {
+	var policyObj domain.ACLPolicy
+
+	if err := json.NewDecoder(r.Body).Decode(&policyObj); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	if err := policyObj.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	policyObj.Metadata = domain.NewMetadata()
+
+	stateReq := store.ACLPolicyCreateReq{Policy: &policyObj}
+
+	policyCreateResp, err := a.state.ACL().Policy().Create(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLPolicyCreateResp{
+			Policy:               policyCreateResp.Policy,
+			internalResponseMeta: newInternalResponseMeta(http.StatusCreated),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyDelete(w http.ResponseWriter, r *http.Request) {
+	policyName := r.Context().Value("policy-name").(string)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.ACLPolicyDeleteReq{Name: policyName, CheckIndex: checkIndex}
+
+	_, err := a.state.ACL().Policy().Delete(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLPolicyDeleteResp{
+			internalResponseMeta: newInternalResponseMeta(http.StatusNoContent),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyGet(w http.ResponseWriter, r *http.Request) {
+	policyName := r.Context().Value("policy-name").(string)
+
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.ACLPolicyGetReq{Name: policyName, QueryOptions: queryOpts}
+
+	policyGetResp, err := a.state.ACL().Policy().Get(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		setIndexHeader(w, policyGetResp.Index)
+
+		resp := ACLPolicyGetResp{
+			Policy:               policyGetResp.Policy,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyList(w http.ResponseWriter, r *http.Request) {
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	policyListResp, err := a.state.ACL().Policy().List(&store.ACLPolicyListReq{QueryOptions: queryOpts})
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		setIndexHeader(w, policyListResp.Index)
+
+		resp := ACLPolicyListResp{
+			Policies:             make([]*domain.ACLPolicyStub, len(policyListResp.Policies)),
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+
+		for i, policy := range policyListResp.Policies {
+			resp.Policies[i] = policy.Stub()
+		}
+
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyUpdate(w http.ResponseWriter, r *http.Request) {
+	policyName := r.Context().Value("policy-name").(string)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	var policyObj domain.ACLPolicy
+
+	if err := json.NewDecoder(r.Body).Decode(&policyObj); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	// The policy name is the identifier used within the URI, so the object
+	// can omit it. If it is supplied, it must match, as renaming a policy is
+	// not supported.
+	if policyObj.Name == "" {
+		policyObj.Name = policyName
+	} else if policyObj.Name != policyName {
+		httpWriteResponseError(w, NewResponseError(
+			fmt.Errorf("acl policy name %q does not match URI name %q", policyObj.Name, policyName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	if err := policyObj.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	stateReq := store.ACLPolicyUpdateReq{Policy: &policyObj, CheckIndex: checkIndex}
+
+	policyUpdateResp, err := a.state.ACL().Policy().Update(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLPolicyUpdateResp{
+			Policy:               policyUpdateResp.Policy,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) policyContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var policyName string
+
+		if policyName = chi.URLParam(r, "policyName"); policyName == "" {
+			httpWriteResponseError(w, errors.New("acl policy not found"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "policy-name", policyName) //nolint:staticcheck
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a aclEndpoint) tokenCreate(w http.ResponseWriter, r *http.Request) {
+	var req ACLTokenCreateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	if req.Type == "" {
+		req.Type = domain.ACLTokenTypeClient
+	}
+
+	tokenObj := domain.NewACLToken(req.Name, req.Type, req.Policies)
+
+	if err := tokenObj.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	stateReq := store.ACLTokenCreateReq{Token: tokenObj}
+
+	tokenCreateResp, err := a.state.ACL().Token().Create(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLTokenCreateResp{
+			Token:                tokenCreateResp.Token,
+			internalResponseMeta: newInternalResponseMeta(http.StatusCreated),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) tokenDelete(w http.ResponseWriter, r *http.Request) {
+	accessorID := r.Context().Value("accessor-id").(ulid.ULID)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.ACLTokenDeleteReq{AccessorID: accessorID, CheckIndex: checkIndex}
+
+	_, err := a.state.ACL().Token().Delete(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := ACLTokenDeleteResp{
+			internalResponseMeta: newInternalResponseMeta(http.StatusNoContent),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) tokenGet(w http.ResponseWriter, r *http.Request) {
+	accessorID := r.Context().Value("accessor-id").(ulid.ULID)
+
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.ACLTokenGetReq{AccessorID: accessorID, QueryOptions: queryOpts}
+
+	tokenGetResp, err := a.state.ACL().Token().Get(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		setIndexHeader(w, tokenGetResp.Index)
+
+		resp := ACLTokenGetResp{
+			Token:                tokenGetResp.Token,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
+func (a aclEndpoint) tokenList(w http.ResponseWriter, r *http.Request) {
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	tokenListResp, err := a.state.ACL().Token().List(&store.ACLTokenListReq{QueryOptions: queryOpts})
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		setIndexHeader(w, tokenListResp.Index)
+
+		resp := ACLTokenListResp{
+			Tokens:               make([]*domain.ACLTokenStub, len(tokenListResp.Tokens)),
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+
+		for i, token := range tokenListResp.Tokens {
+			resp.Tokens[i] = token.Stub()
+		}
+
+		httpWriteResponse(w, &resp)
+	}
+}
+
+// tokenSelf returns the token used to authenticate the request. The ACL
+// middleware resolves the token and adds it to the request context.
+func (a aclEndpoint) tokenSelf(w http.ResponseWriter, r *http.Request) {
+	token, ok := requestACLToken(r)
+	if !ok {
+		httpWriteResponseError(w, NewResponseError(errors.New("acl token not found"), http.StatusNotFound))
+		return
+	}
+
+	resp := ACLTokenGetResp{
+		Token:                token,
+		internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+	}
+	httpWriteResponse(w, &resp)
+}
+
+func (a aclEndpoint) tokenContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var accessorIDString string
+
+		if accessorIDString = chi.URLParam(r, "accessorID"); accessorIDString == "" {
+			httpWriteResponseError(w, errors.New("accessor id not found"))
+			return
+		}
+
+		if accessorID, err := ulid.Parse(accessorIDString); err != nil {
+			httpWriteResponseError(w, NewResponseError(
+				fmt.Errorf("failed to parse accessor ID: %w", err), http.StatusBadRequest))
+		} else {
+			ctx := context.WithValue(r.Context(), "accessor-id", accessorID) //nolint:staticcheck
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rasorp/attila/internal/event"
+)
+
+const (
+	// queryParamTopic is the URL query parameter used to filter the event
+	// stream. It can be supplied multiple times and takes the form "Topic" or
+	// "Topic:Key".
+	queryParamTopic = "topic"
+
+	// eventStreamHeartbeatInterval is how often an empty object is written to
+	// an idle event stream, which keeps the connection alive and allows the
+	// server to detect disconnected clients.
+	eventStreamHeartbeatInterval = 10 * time.Second
+)
+
+type eventEndpoint struct {
+	broker *event.Broker
+}
+
+func (e eventEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/", func(r chi.Router) {
+		r.Get("/stream", e.stream)
+	})
+
+	return r
+}
+
+// stream writes matching events to the client as newline delimited JSON until
+// the client disconnects or the server shuts down.
+func (e eventEndpoint) stream(w http.ResponseWriter, r *http.Request) {
+	topics, err := parseEventTopics(r)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err, http.StatusBadRequest))
+		return
+	}
+
+	sub := e.broker.Subscribe(&event.SubscribeReq{Topics: topics})
+	defer sub.Close()
+
+	// The stream is long-lived, so remove the write deadline the server sets
+	// on every request.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	// The headers are only sent once subscribed, so clients know events
+	// published after the response is received are not missed.
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	enc := json.NewEncoder(w)
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var obj any
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			obj = struct{}{}
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			obj = ev
+		}
+
+		if err := enc.Encode(obj); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// parseEventTopics converts the topic query parameters into the subscription
+// filter. No parameters results in an empty filter, which matches all events.
+func parseEventTopics(r *http.Request) (map[event.Topic][]string, error) {
+	topics := make(map[event.Topic][]string)
+
+	for _, raw := range r.URL.Query()[queryParamTopic] {
+		topicName, key, _ := strings.Cut(raw, ":")
+
+		topic, err := event.ParseTopic(topicName)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "" {
+			key = event.KeyAll
+		}
+		topics[topic] = append(topics[topic], key)
+	}
+
+	return topics, nil
+}
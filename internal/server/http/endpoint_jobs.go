@@ -0,0 +1,91 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/server/nomad"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type JobsStatusResp struct {
+	Status               *domain.JobStatus `json:"status"`
+	internalResponseMeta `json:"-"`
+}
+
+type JobsStopResp struct {
+	Stop                 *domain.JobStop `json:"stop"`
+	internalResponseMeta `json:"-"`
+}
+
+// jobsEndpoint serves the endpoints which act on a single Nomad job across
+// every region Attila manages, rather than on a registration object.
+type jobsEndpoint struct {
+	nomadController nomad.Controller
+	state           store.State
+
+	// requestTimeout is the deadline applied to the requests which call Nomad
+	// regions.
+	requestTimeout time.Duration
+}
+
+func (j jobsEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(requestTimeoutMiddleware(j.requestTimeout)).Delete("/", j.stop)
+	r.With(requestTimeoutMiddleware(j.requestTimeout)).Get("/status", j.status)
+
+	return r
+}
+
+func (j jobsEndpoint) stop(w http.ResponseWriter, r *http.Request) {
+
+	purge, err := parseQueryBool(r, queryParamPurge)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err, http.StatusBadRequest))
+		return
+	}
+
+	plan, err := parseQueryBool(r, queryParamPlan)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err, http.StatusBadRequest))
+		return
+	}
+
+	result, err := j.nomadController.JobStop(
+		r.Context(), chi.URLParam(r, "namespace"), chi.URLParam(r, "id"), purge, plan, j.state)
+	if err != nil && result == nil {
+		httpWriteResponseError(w, NewResponseError(err, controllerErrorCode(err)))
+		return
+	}
+
+	responseCode := http.StatusOK
+	if err != nil {
+		responseCode = controllerErrorCode(err)
+	}
+
+	httpWriteResponse(w, &JobsStopResp{
+		Stop:                 result,
+		internalResponseMeta: newInternalResponseMeta(responseCode),
+	})
+}
+
+func (j jobsEndpoint) status(w http.ResponseWriter, r *http.Request) {
+	result, err := j.nomadController.JobStatus(
+		r.Context(), chi.URLParam(r, "namespace"), chi.URLParam(r, "id"), j.state)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err, controllerErrorCode(err)))
+		return
+	}
+
+	httpWriteResponse(w, &JobsStatusResp{
+		Status:               result,
+		internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+	})
+}
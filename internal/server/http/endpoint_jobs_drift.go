@@ -0,0 +1,51 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/server/nomad"
+)
+
+type JobsDriftListResp struct {
+	Drifts               []*domain.JobDrift `json:"drifts"`
+	internalResponseMeta `json:"-"`
+}
+
+// jobsDriftEndpoint serves the result of the latest drift check of each region
+// a successful job registration run registered a job in. The results are held
+// by the Nomad controller, so this endpoint is read only.
+type jobsDriftEndpoint struct {
+	nomadController nomad.Controller
+}
+
+func (j jobsDriftEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", j.list)
+
+	return r
+}
+
+func (j jobsDriftEndpoint) list(w http.ResponseWriter, r *http.Request) {
+	jobID := r.URL.Query().Get(queryParamJobID)
+	namespace := r.URL.Query().Get(queryParamNamespace)
+
+	drifts := make([]*domain.JobDrift, 0)
+
+	for _, drift := range j.nomadController.GetJobDrifts() {
+		if (jobID == "" || drift.JobID == jobID) && (namespace == "" || drift.JobNamespace == namespace) {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	httpWriteResponse(w, &JobsDriftListResp{
+		Drifts:               drifts,
+		internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+	})
+}
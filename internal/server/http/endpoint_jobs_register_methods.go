@@ -35,6 +35,11 @@ type JobRegisterMethodListResp struct {
 	internalResponseMeta `json:"-"`
 }
 
+type JobRegisterMethodUpdateResp struct {
+	Method               *domain.JobRegisterMethod `json:"method"`
+	internalResponseMeta `json:"-"`
+}
+
 type jobsRegisterMethodsEndpoint struct {
 	state store.State
 }
@@ -53,6 +58,7 @@ func (j jobsRegisterMethodsEndpoint) routes() chi.Router {
 		r.Use(j.context)
 		r.Delete("/", j.delete)
 		r.Get("/", j.get)
+		r.Put("/", j.update)
 	})
 
 	return r
@@ -92,7 +98,13 @@ func (j jobsRegisterMethodsEndpoint) create(w http.ResponseWriter, r *http.Reque
 func (j jobsRegisterMethodsEndpoint) delete(w http.ResponseWriter, r *http.Request) {
 	methodName := r.Context().Value("method-name").(string)
 
-	stateReq := store.JobRegisterMethodDeleteReq{Name: methodName}
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterMethodDeleteReq{Name: methodName, CheckIndex: checkIndex}
 
 	_, err := j.state.JobRegister().Method().Delete(&stateReq)
 	if err != nil {
@@ -109,13 +121,21 @@ func (j jobsRegisterMethodsEndpoint) delete(w http.ResponseWriter, r *http.Reque
 func (a jobsRegisterMethodsEndpoint) get(w http.ResponseWriter, r *http.Request) {
 	methodName := r.Context().Value("method-name").(string)
 
-	stateReq := store.JobRegisterMethodGetReq{Name: methodName}
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterMethodGetReq{Name: methodName, QueryOptions: queryOpts}
 
 	methodGetResp, err := a.state.JobRegister().Method().Get(&stateReq)
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, methodGetResp.Index)
+
 		resp := JobRegisterMethodGetResp{
 			Method:               methodGetResp.Method,
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -125,11 +145,19 @@ func (a jobsRegisterMethodsEndpoint) get(w http.ResponseWriter, r *http.Request)
 }
 
 func (j jobsRegisterMethodsEndpoint) list(w http.ResponseWriter, r *http.Request) {
-	stateResp, err := j.state.JobRegister().Method().List(&store.JobRegisterMethodListReq{})
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateResp, err := j.state.JobRegister().Method().List(&store.JobRegisterMethodListReq{QueryOptions: queryOpts})
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, stateResp.Index)
+
 		resp := JobRegisterMethodListResp{
 			Methods:              make([]*domain.JobRegisterMethodStub, len(stateResp.Methods)),
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -143,6 +171,56 @@ func (j jobsRegisterMethodsEndpoint) list(w http.ResponseWriter, r *http.Request
 	}
 }
 
+func (j jobsRegisterMethodsEndpoint) update(w http.ResponseWriter, r *http.Request) {
+	methodName := r.Context().Value("method-name").(string)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	var methodObj domain.JobRegisterMethod
+
+	if err := json.NewDecoder(r.Body).Decode(&methodObj); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	// The method name is the identifier used within the URI, so the object
+	// can omit it. If it is supplied, it must match, as renaming a method is
+	// not supported.
+	if methodObj.Name == "" {
+		methodObj.Name = methodName
+	} else if methodObj.Name != methodName {
+		httpWriteResponseError(w, NewResponseError(
+			fmt.Errorf("job register method name %q does not match URI name %q", methodObj.Name, methodName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	if err := methodObj.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	stateReq := store.JobRegisterMethodUpdateReq{Method: &methodObj, CheckIndex: checkIndex}
+
+	methodUpdateResp, err := j.state.JobRegister().Method().Update(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := JobRegisterMethodUpdateResp{
+			Method:               methodUpdateResp.Method,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
 func (j jobsRegisterMethodsEndpoint) context(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
@@ -0,0 +1,110 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+func TestJobsRegisterMethodsEndpoint_update(t *testing.T) {
+
+	state, err := mem.New()
+	must.NoError(t, err)
+
+	mockRule := mock.JobRegistrationRule()
+
+	_, errResp := state.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: mockRule})
+	must.Nil(t, errResp)
+
+	mockMethod := mock.JobRegistrationMethod()
+	mockMethod.Name = "example"
+	mockMethod.Rules = nil
+
+	_, errResp = state.JobRegister().Method().Create(&store.JobRegisterMethodCreateReq{Method: mockMethod})
+	must.Nil(t, errResp)
+
+	router, _ := testRouter(state)
+
+	updatedMethod := mock.JobRegistrationMethod()
+	updatedMethod.Name = ""
+	updatedMethod.Rules = []*domain.JobRegisterMethodRuleLink{{Name: mockRule.Name}}
+
+	unknownRuleMethod := mock.JobRegistrationMethod()
+	unknownRuleMethod.Name = ""
+
+	testCases := []struct {
+		name         string
+		inputPath    string
+		inputBody    any
+		expectedCode int
+	}{
+		{
+			name:         "missing method",
+			inputPath:    "/v1alpha1/jobs/register/methods/missing",
+			inputBody:    updatedMethod,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid body",
+			inputPath:    "/v1alpha1/jobs/register/methods/example",
+			inputBody:    "method",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "mismatched name",
+			inputPath:    "/v1alpha1/jobs/register/methods/example",
+			inputBody:    domain.JobRegisterMethod{Name: "other", Selectors: updatedMethod.Selectors},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid method",
+			inputPath:    "/v1alpha1/jobs/register/methods/example",
+			inputBody:    domain.JobRegisterMethod{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown rule",
+			inputPath:    "/v1alpha1/jobs/register/methods/example",
+			inputBody:    unknownRuleMethod,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "stale check index",
+			inputPath:    "/v1alpha1/jobs/register/methods/example?check_index=100",
+			inputBody:    updatedMethod,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testRequest(t, router, http.MethodPut, tc.inputPath, tc.inputBody)
+			must.Eq(t, tc.expectedCode, rec.Code, must.Sprint(rec.Body.String()))
+		})
+	}
+
+	// None of the failed requests updated the method. The name is taken from
+	// the URI when the object omits it.
+	rec := testRequest(t, router, http.MethodPut, "/v1alpha1/jobs/register/methods/example?check_index=2", updatedMethod)
+	must.Eq(t, http.StatusOK, rec.Code, must.Sprint(rec.Body.String()))
+
+	var resp JobRegisterMethodUpdateResp
+	must.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	must.Eq(t, "example", resp.Method.Name)
+	must.Eq(t, updatedMethod.Rules, resp.Method.Rules)
+	must.Eq(t, 3, resp.Method.Metadata.ModifyIndex)
+
+	getResp, errResp := state.JobRegister().Method().Get(&store.JobRegisterMethodGetReq{Name: "example"})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedMethod.Rules, getResp.Method.Rules)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hashicorp/nomad/api"
@@ -22,6 +23,10 @@ import (
 
 type JobsRegisterPlansCreateReq struct {
 	Job *api.Job `json:"job"`
+
+	// Submission is the optional original source and variables of the job,
+	// which is stored with the plan.
+	Submission *api.JobSubmission `json:"submission"`
 }
 
 type JobsRegisterPlansCreateResp struct {
@@ -44,7 +49,14 @@ type JobsRegisterPlansListResp struct {
 }
 
 type JobsRegisterPlansRunReq struct {
+
+	// Job is the job to register, which must match the planned job. If it is
+	// not set, the job stored with the plan is registered.
 	Job *api.Job `json:"job"`
+
+	// Rollout overrides the rollout of the plan, which is taken from the rules
+	// that picked its regions.
+	Rollout *domain.JobRegisterRollout `json:"rollout"`
 }
 
 type JobsRegisterPlansRunResp struct {
@@ -57,6 +69,10 @@ type jobsRegisterPlansEndpoint struct {
 	logger          *zap.Logger
 	nomadController nomad.Controller
 	state           store.State
+
+	// requestTimeout is the deadline applied to the requests which call Nomad
+	// regions.
+	requestTimeout time.Duration
 }
 
 func (j jobsRegisterPlansEndpoint) routes() chi.Router {
@@ -65,7 +81,7 @@ func (j jobsRegisterPlansEndpoint) routes() chi.Router {
 	// Add the root endpoints which do not include a plan ID within the URI.
 	r.Route("/", func(r chi.Router) {
 		r.Get("/", j.list)
-		r.Post("/", j.create)
+		r.With(requestTimeoutMiddleware(j.requestTimeout)).Post("/", j.create)
 	})
 
 	// Add the endpoints which are specific to a job register plan using the ID.
@@ -73,7 +89,7 @@ func (j jobsRegisterPlansEndpoint) routes() chi.Router {
 		r.Use(j.context)
 		r.Delete("/", j.delete)
 		r.Get("/", j.get)
-		r.Post("/run", j.run)
+		r.With(requestTimeoutMiddleware(j.requestTimeout)).Post("/run", j.run)
 	})
 
 	return r
@@ -88,12 +104,14 @@ func (j jobsRegisterPlansEndpoint) create(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	controllerResp, err := j.nomadController.JobRegistrationPlanCreate(req.Job, j.state)
+	controllerResp, err := j.nomadController.JobRegistrationPlanCreate(r.Context(), req.Job, j.state)
 	if err != nil {
-		httpWriteResponseError(w, NewResponseError(err, http.StatusInternalServerError))
+		httpWriteResponseError(w, NewResponseError(err, controllerErrorCode(err)))
 		return
 	}
 
+	controllerResp.Submission = req.Submission
+
 	stateResp, stateErr := j.state.JobRegister().Plan().Create(&store.JobRegisterPlanCreateReq{Plan: controllerResp})
 	if stateErr != nil {
 		httpWriteResponseError(w, NewResponseError(stateErr.Err(), stateErr.StatusCode()))
@@ -109,7 +127,13 @@ func (j jobsRegisterPlansEndpoint) create(w http.ResponseWriter, r *http.Request
 func (j jobsRegisterPlansEndpoint) delete(w http.ResponseWriter, r *http.Request) {
 	planID := r.Context().Value("id").(ulid.ULID)
 
-	stateReq := store.JobRegisterPlanDeleteReq{ID: planID}
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterPlanDeleteReq{ID: planID, CheckIndex: checkIndex}
 
 	_, err := j.state.JobRegister().Plan().Delete(&stateReq)
 	if err != nil {
@@ -124,12 +148,20 @@ func (j jobsRegisterPlansEndpoint) delete(w http.ResponseWriter, r *http.Request
 func (j jobsRegisterPlansEndpoint) get(w http.ResponseWriter, r *http.Request) {
 	planID := r.Context().Value("id").(ulid.ULID)
 
-	stateReq := store.JobRegisterPlanGetReq{ID: planID}
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterPlanGetReq{ID: planID, QueryOptions: queryOpts}
 
 	stateResp, err := j.state.JobRegister().Plan().Get(&stateReq)
 	if err != nil {
 		httpWriteResponseError(w, NewResponseError(err.Err(), err.StatusCode()))
 	} else {
+		setIndexHeader(w, stateResp.Index)
+
 		httpWriteResponse(w, &JobsRegisterPlansGetResp{
 			Plan:                 stateResp.Plan,
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -138,11 +170,19 @@ func (j jobsRegisterPlansEndpoint) get(w http.ResponseWriter, r *http.Request) {
 }
 
 func (j jobsRegisterPlansEndpoint) list(w http.ResponseWriter, r *http.Request) {
-	stateResp, err := j.state.JobRegister().Plan().List(&store.JobRegisterPlanListReq{})
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateResp, err := j.state.JobRegister().Plan().List(&store.JobRegisterPlanListReq{QueryOptions: queryOpts})
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, stateResp.Index)
+
 		resp := JobsRegisterPlansListResp{
 			Plans:                stateResp.Plans,
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -160,17 +200,53 @@ func (j jobsRegisterPlansEndpoint) run(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := httpReq.Rollout.Validate(); err != nil {
+		httpWriteResponseError(w,
+			NewResponseError(fmt.Errorf("failed to validate rollout: %w", err), http.StatusBadRequest))
+		return
+	}
+
 	planID := r.Context().Value("id").(ulid.ULID)
 
-	result, err := j.nomadController.JobRegistrationRun(planID, httpReq.Job, j.state)
+	// Register the job stored with the plan, if the request does not include
+	// one. The job submitted with the request is checked against the plan when
+	// it is run.
+	if httpReq.Job == nil {
+		planResp, stateErr := j.state.JobRegister().Plan().Get(&store.JobRegisterPlanGetReq{ID: planID})
+		if stateErr != nil {
+			httpWriteResponseError(w, NewResponseError(stateErr.Err(), stateErr.StatusCode()))
+			return
+		}
+		if planResp.Plan.Job == nil {
+			httpWriteResponseError(w, NewResponseError(
+				errors.New("plan does not include a job, so the run must include one"), http.StatusBadRequest))
+			return
+		}
+		httpReq.Job = planResp.Plan.Job
+	}
+
+	result, err := j.nomadController.JobRegistrationRun(r.Context(), planID, httpReq.Job, httpReq.Rollout, nil, j.state)
 	if err != nil && result == nil {
-		httpWriteResponseError(w, NewResponseError(err, http.StatusInternalServerError))
+		httpWriteResponseError(w, NewResponseError(err, controllerErrorCode(err)))
 		return
 	}
 
 	responseCode := http.StatusCreated
 	if err != nil {
-		responseCode = http.StatusInternalServerError
+		responseCode = controllerErrorCode(err)
+	}
+
+	// Record the run, including any partial failure, so there is a history
+	// of what was registered where once the plan has been deleted. The state
+	// sets the modify index of the stored object, so a copy is stored, as the
+	// result has already been published to event subscribers.
+	storedRun := *result
+
+	if runResp, stateErr := j.state.JobRegister().Run().Create(
+		&store.JobRegisterRunCreateReq{Run: &storedRun}); stateErr != nil {
+		j.logger.Error("failed to store job register run", zap.Error(stateErr))
+	} else {
+		result = runResp.Run
 	}
 
 	stateReq := store.JobRegisterPlanDeleteReq{ID: planID}
@@ -186,6 +262,28 @@ func (j jobsRegisterPlansEndpoint) run(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// controllerErrorCode returns the response code for an error returned by the
+// Nomad controller. Errors caused by the request or a region call exceeding its
+// deadline are reported as a gateway timeout, as a Nomad region did not respond
+// in time. Runs with a job which does not match the plan are a bad request,
+// and runs of stale plans conflict with the current state of the regions.
+// State errors, such as a plan which does not exist, keep their own code.
+func controllerErrorCode(err error) int {
+	var stateErr *store.ErrorResp
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, domain.ErrJobRegisterPlanJobMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrJobRegisterPlanStale):
+		return http.StatusConflict
+	case errors.As(err, &stateErr):
+		return stateErr.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
 func (j jobsRegisterPlansEndpoint) context(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
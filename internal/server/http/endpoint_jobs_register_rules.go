@@ -37,6 +37,11 @@ type JobRegisterRuleListResp struct {
 	internalResponseMeta `json:"-"`
 }
 
+type JobRegisterRuleUpdateResp struct {
+	Rule                 *domain.JobRegisterRule `json:"rule"`
+	internalResponseMeta `json:"-"`
+}
+
 type jobsRegisterRulesEndpoint struct {
 	state store.State
 }
@@ -55,6 +60,7 @@ func (j jobsRegisterRulesEndpoint) routes() chi.Router {
 		r.Use(j.context)
 		r.Delete("/", j.delete)
 		r.Get("/", j.get)
+		r.Put("/", j.update)
 	})
 
 	return r
@@ -104,7 +110,13 @@ func (j jobsRegisterRulesEndpoint) create(w http.ResponseWriter, r *http.Request
 func (j jobsRegisterRulesEndpoint) delete(w http.ResponseWriter, r *http.Request) {
 	ruleName := r.Context().Value("rule-name").(string)
 
-	stateReq := store.JobRegisterRuleDeleteReq{Name: ruleName}
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterRuleDeleteReq{Name: ruleName, CheckIndex: checkIndex}
 
 	_, err := j.state.JobRegister().Rule().Delete(&stateReq)
 	if err != nil {
@@ -121,13 +133,21 @@ func (j jobsRegisterRulesEndpoint) delete(w http.ResponseWriter, r *http.Request
 func (j jobsRegisterRulesEndpoint) get(w http.ResponseWriter, r *http.Request) {
 	ruleName := r.Context().Value("rule-name").(string)
 
-	stateReq := store.JobRegisterRuleGetReq{Name: ruleName}
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterRuleGetReq{Name: ruleName, QueryOptions: queryOpts}
 
 	ruleGetResp, err := j.state.JobRegister().Rule().Get(&stateReq)
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, ruleGetResp.Index)
+
 		resp := JobRegisterRuleGetResp{
 			Rule:                 ruleGetResp.Rule,
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -137,11 +157,19 @@ func (j jobsRegisterRulesEndpoint) get(w http.ResponseWriter, r *http.Request) {
 }
 
 func (j jobsRegisterRulesEndpoint) list(w http.ResponseWriter, r *http.Request) {
-	ruleListResp, err := j.state.JobRegister().Rule().List(&store.JobRegisterRuleListReq{})
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	ruleListResp, err := j.state.JobRegister().Rule().List(&store.JobRegisterRuleListReq{QueryOptions: queryOpts})
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, ruleListResp.Index)
+
 		resp := JobRegisterRuleListResp{
 			Rules:                make([]*domain.JobRegisterRuleStub, len(ruleListResp.Rules)),
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -155,6 +183,62 @@ func (j jobsRegisterRulesEndpoint) list(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (j jobsRegisterRulesEndpoint) update(w http.ResponseWriter, r *http.Request) {
+	ruleName := r.Context().Value("rule-name").(string)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	var ruleObj domain.JobRegisterRule
+
+	if err := json.NewDecoder(r.Body).Decode(&ruleObj); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	// The rule name is the identifier used within the URI, so the object can
+	// omit it. If it is supplied, it must match, as renaming a rule is not
+	// supported.
+	if ruleObj.Name == "" {
+		ruleObj.Name = ruleName
+	} else if ruleObj.Name != ruleName {
+		httpWriteResponseError(w, NewResponseError(
+			fmt.Errorf("job register rule name %q does not match URI name %q", ruleObj.Name, ruleName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	if err := ruleObj.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	if _, err := picker.New(ruleObj.RegionPickers); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	stateReq := store.JobRegisterRuleUpdateReq{Rule: &ruleObj, CheckIndex: checkIndex}
+
+	ruleUpdateResp, err := j.state.JobRegister().Rule().Update(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		resp := JobRegisterRuleUpdateResp{
+			Rule:                 ruleUpdateResp.Rule,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
 func (j jobsRegisterRulesEndpoint) context(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
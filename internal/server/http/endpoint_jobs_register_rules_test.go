@@ -0,0 +1,105 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+	jobsdk "github.com/rasorp/attila/pkg/job"
+)
+
+func TestJobsRegisterRulesEndpoint_update(t *testing.T) {
+
+	state, err := mem.New()
+	must.NoError(t, err)
+
+	mockRule := mock.JobRegistrationRule()
+	mockRule.Name = "example"
+
+	_, errResp := state.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: mockRule})
+	must.Nil(t, errResp)
+
+	router, _ := testRouter(state)
+
+	updatedRule := mock.JobRegistrationRule()
+	updatedRule.Name = ""
+	updatedRule.RegionContexts = []domain.JobRegisterRuleRegionContext{{Kind: domain.JobRegisterRuleContextKindNodepool}}
+	updatedRule.RegionPickers = []*jobsdk.RegionPickerConfig{
+		{
+			RegionPickerBaseConfig: &jobsdk.RegionPickerBaseConfig{
+				Name:     "all",
+				Provider: jobsdk.RegionPickerProviderFilter,
+			},
+			ProviderConfig: map[string]any{"expression": "true"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		inputPath    string
+		inputBody    any
+		expectedCode int
+	}{
+		{
+			name:         "missing rule",
+			inputPath:    "/v1alpha1/jobs/register/rules/missing",
+			inputBody:    updatedRule,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid body",
+			inputPath:    "/v1alpha1/jobs/register/rules/example",
+			inputBody:    "rule",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "mismatched name",
+			inputPath:    "/v1alpha1/jobs/register/rules/example",
+			inputBody:    domain.JobRegisterRule{Name: "other", RegionPickers: updatedRule.RegionPickers},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid rule",
+			inputPath:    "/v1alpha1/jobs/register/rules/example",
+			inputBody:    domain.JobRegisterRule{RegionPickers: mock.JobRegistrationRule().RegionPickers},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "stale check index",
+			inputPath:    "/v1alpha1/jobs/register/rules/example?check_index=100",
+			inputBody:    updatedRule,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testRequest(t, router, http.MethodPut, tc.inputPath, tc.inputBody)
+			must.Eq(t, tc.expectedCode, rec.Code, must.Sprint(rec.Body.String()))
+		})
+	}
+
+	// None of the failed requests updated the rule. The name is taken from the
+	// URI when the object omits it.
+	rec := testRequest(t, router, http.MethodPut, "/v1alpha1/jobs/register/rules/example?check_index=1", updatedRule)
+	must.Eq(t, http.StatusOK, rec.Code, must.Sprint(rec.Body.String()))
+
+	var resp JobRegisterRuleUpdateResp
+	must.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	must.Eq(t, "example", resp.Rule.Name)
+	must.Eq(t, updatedRule.RegionContexts, resp.Rule.RegionContexts)
+	must.Eq(t, 2, resp.Rule.Metadata.ModifyIndex)
+
+	getResp, errResp := state.JobRegister().Rule().Get(&store.JobRegisterRuleGetReq{Name: "example"})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedRule.RegionContexts, getResp.Rule.RegionContexts)
+}
@@ -0,0 +1,115 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type JobsRegisterRunsGetResp struct {
+	Run                  *domain.JobRegisterPlanRun `json:"run"`
+	internalResponseMeta `json:"-"`
+}
+
+type JobsRegisterRunsListResp struct {
+	Runs                 []*domain.JobRegisterPlanRun `json:"runs"`
+	internalResponseMeta `json:"-"`
+}
+
+// jobsRegisterRunsEndpoint serves the history of job registration plan runs.
+// Runs are written by the plan run endpoint, so this endpoint is read only.
+type jobsRegisterRunsEndpoint struct {
+	state store.State
+}
+
+func (j jobsRegisterRunsEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", j.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Use(j.context)
+		r.Get("/", j.get)
+	})
+
+	return r
+}
+
+func (j jobsRegisterRunsEndpoint) get(w http.ResponseWriter, r *http.Request) {
+	runID := r.Context().Value("id").(ulid.ULID)
+
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterRunGetReq{ID: runID, QueryOptions: queryOpts}
+
+	stateResp, err := j.state.JobRegister().Run().Get(&stateReq)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err.Err(), err.StatusCode()))
+	} else {
+		setIndexHeader(w, stateResp.Index)
+
+		httpWriteResponse(w, &JobsRegisterRunsGetResp{
+			Run:                  stateResp.Run,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		})
+	}
+}
+
+func (j jobsRegisterRunsEndpoint) list(w http.ResponseWriter, r *http.Request) {
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.JobRegisterRunListReq{
+		JobID:        r.URL.Query().Get(queryParamJobID),
+		JobNamespace: r.URL.Query().Get(queryParamNamespace),
+		QueryOptions: queryOpts,
+	}
+
+	stateResp, err := j.state.JobRegister().Run().List(&stateReq)
+	if err != nil {
+		httpWriteResponseError(w, NewResponseError(err.Err(), err.StatusCode()))
+	} else {
+		setIndexHeader(w, stateResp.Index)
+
+		httpWriteResponse(w, &JobsRegisterRunsListResp{
+			Runs:                 stateResp.Runs,
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		})
+	}
+}
+
+func (j jobsRegisterRunsEndpoint) context(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var runIDString string
+
+		if runIDString = chi.URLParam(r, "id"); runIDString == "" {
+			httpWriteResponseError(w, errors.New("id not found"))
+			return
+		}
+
+		if runULID, err := ulid.Parse(runIDString); err != nil {
+			httpWriteResponseError(w, fmt.Errorf("failed to parse ID: %w", err))
+		} else {
+			ctx := context.WithValue(r.Context(), "id", runULID) //nolint:staticcheck
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	})
+}
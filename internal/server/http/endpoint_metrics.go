@@ -0,0 +1,72 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/rasorp/attila/internal/metrics"
+)
+
+var (
+	httpRequestsTotal = metrics.Default.NewCounter(
+		"attila_http_requests_total",
+		"The number of HTTP requests handled, by route and response status.",
+		"method", "route", "status",
+	)
+	httpRequestDuration = metrics.Default.NewHistogram(
+		"attila_http_request_duration_seconds",
+		"The time taken to handle HTTP requests, by route.",
+		metrics.DefaultBuckets,
+		"method", "route",
+	)
+)
+
+type metricsEndpoint struct {
+	registry *metrics.Registry
+}
+
+func (m metricsEndpoint) routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", m.get)
+	return r
+}
+
+func (m metricsEndpoint) get(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.WriteHeader(http.StatusOK)
+	_ = m.registry.WriteText(w)
+}
+
+// metricsMiddleware records the count and latency of each request. Requests
+// are labelled with their route pattern rather than path, so object names do
+// not create a series each. The pattern is only known once the router has
+// matched the request, so is read after the handler returns.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		startTime := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		route := routePattern(r)
+		if route == "" {
+			route = "not_found"
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		httpRequestsTotal.Inc(r.Method, route, strconv.Itoa(status))
+		httpRequestDuration.Observe(time.Since(startTime).Seconds(), r.Method, route)
+	})
+}
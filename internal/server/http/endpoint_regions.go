@@ -40,6 +40,15 @@ type RegionListResp struct {
 	internalResponseMeta `json:"-"`
 }
 
+type RegionUpdateReq struct {
+	Region *domain.Region `json:"region"`
+}
+
+type RegionUpdateResp struct {
+	Region               *domain.Region `json:"region"`
+	internalResponseMeta `json:"-"`
+}
+
 type regionsEndpoint struct {
 	state           store.State
 	nomadController nomad.Controller
@@ -59,6 +68,7 @@ func (a regionsEndpoint) routes() chi.Router {
 		r.Use(a.context)
 		r.Delete("/", a.delete)
 		r.Get("/", a.get)
+		r.Put("/", a.update)
 	})
 
 	return r
@@ -98,7 +108,7 @@ func (a regionsEndpoint) create(w http.ResponseWriter, r *http.Request) {
 	} else {
 		a.nomadController.RegionSet(stateResp.Region.Name, nomadClient)
 		resp := RegionCreateResp{
-			Region:               stateResp.Region,
+			Region:               stateResp.Region.Redacted(),
 			internalResponseMeta: newInternalResponseMeta(http.StatusCreated),
 		}
 		httpWriteResponse(w, &resp)
@@ -108,7 +118,13 @@ func (a regionsEndpoint) create(w http.ResponseWriter, r *http.Request) {
 func (a regionsEndpoint) delete(w http.ResponseWriter, r *http.Request) {
 	regionName := r.Context().Value("region-name").(string)
 
-	stateReq := store.RegionDeleteReq{RegionName: regionName}
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	stateReq := store.RegionDeleteReq{RegionName: regionName, CheckIndex: checkIndex}
 
 	_, err := a.state.Region().Delete(&stateReq)
 	if err != nil {
@@ -126,27 +142,61 @@ func (a regionsEndpoint) delete(w http.ResponseWriter, r *http.Request) {
 func (a regionsEndpoint) get(w http.ResponseWriter, r *http.Request) {
 	regionName := r.Context().Value("region-name").(string)
 
-	stateReq := store.RegionGetReq{RegionName: regionName}
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	// Secret fields are write-only unless the caller explicitly asks for them
+	// and holds a privileged token.
+	includeSecrets, secretsErr := parseIncludeSecrets(r)
+	if secretsErr != nil {
+		httpWriteResponseError(w, NewResponseError(secretsErr, http.StatusBadRequest))
+		return
+	}
+	if includeSecrets && !requestIsPrivileged(r) {
+		httpWriteResponseError(w, NewResponseError(
+			errors.New("acl management token required to read region secrets"), http.StatusForbidden))
+		return
+	}
+
+	stateReq := store.RegionGetReq{RegionName: regionName, QueryOptions: queryOpts}
 
 	regionGetResp, err := a.state.Region().Get(&stateReq)
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, regionGetResp.Index)
+
 		resp := RegionGetResp{
-			Region:               regionGetResp.Region,
+			Region:               regionGetResp.Region.Redacted(),
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
 		}
+
+		if includeSecrets {
+			resp.Region = regionGetResp.Region
+		}
+
 		httpWriteResponse(w, &resp)
 	}
 }
 
 func (a regionsEndpoint) list(w http.ResponseWriter, r *http.Request) {
-	regionListResp, err := a.state.Region().List(&store.RegionListReq{})
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	regionListResp, err := a.state.Region().List(&store.RegionListReq{QueryOptions: queryOpts})
 	if err != nil {
 		respErr := NewResponseError(err.Err(), err.StatusCode())
 		httpWriteResponseError(w, respErr)
 	} else {
+		setIndexHeader(w, regionListResp.Index)
+
 		resp := RegionListResp{
 			Regions:              make([]*domain.RegionStub, len(regionListResp.Regions)),
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -160,6 +210,83 @@ func (a regionsEndpoint) list(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (a regionsEndpoint) update(w http.ResponseWriter, r *http.Request) {
+	regionName := r.Context().Value("region-name").(string)
+
+	checkIndex, indexErr := parseCheckIndex(r)
+	if indexErr != nil {
+		httpWriteResponseError(w, NewResponseError(indexErr, http.StatusBadRequest))
+		return
+	}
+
+	var req RegionUpdateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpWriteResponseError(w, NewResponseError(fmt.Errorf("failed to decode object: %w", err), http.StatusBadRequest))
+		return
+	}
+
+	if req.Region == nil {
+		httpWriteResponseError(w, NewResponseError(errors.New("region object required"), http.StatusBadRequest))
+		return
+	}
+
+	// The region name is the identifier used within the URI, so the object
+	// can omit it. If it is supplied, it must match, as renaming a region is
+	// not supported.
+	if req.Region.Name == "" {
+		req.Region.Name = regionName
+	} else if req.Region.Name != regionName {
+		httpWriteResponseError(w, NewResponseError(
+			fmt.Errorf("region name %q does not match URI name %q", req.Region.Name, regionName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	// A region read from the API has its secrets redacted. Restore them from
+	// the stored region, so the caller does not have to supply them again.
+	existingResp, existingErr := a.state.Region().Get(&store.RegionGetReq{RegionName: regionName})
+	if existingErr != nil {
+		httpWriteResponseError(w, NewResponseError(existingErr.Err(), existingErr.StatusCode()))
+		return
+	}
+	req.Region.RestoreRedacted(existingResp.Region)
+
+	req.Region.SetDefaults()
+
+	if err := req.Region.Validate(); err != nil {
+		respErr := NewResponseError(err, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	nomadClient, clientErr := req.Region.GenerateNomadClient()
+	if clientErr != nil {
+		respErr := NewResponseError(clientErr, http.StatusBadRequest)
+		httpWriteResponseError(w, respErr)
+		return
+	}
+
+	stateReq := store.RegionUpdateReq{Region: req.Region, CheckIndex: checkIndex}
+
+	stateResp, err := a.state.Region().Update(&stateReq)
+	if err != nil {
+		respErr := NewResponseError(err.Err(), err.StatusCode())
+		httpWriteResponseError(w, respErr)
+	} else {
+		// Swap the client within the controller. The topology collector for
+		// the region is already running and pulls the client on each
+		// collection, so it does not need to be restarted.
+		a.nomadController.RegionSet(stateResp.Region.Name, nomadClient)
+		resp := RegionUpdateResp{
+			Region:               stateResp.Region.Redacted(),
+			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
+		}
+		httpWriteResponse(w, &resp)
+	}
+}
+
 func (a regionsEndpoint) context(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
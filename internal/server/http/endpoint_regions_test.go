@@ -0,0 +1,105 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+func TestRegionsEndpoint_update(t *testing.T) {
+
+	state, err := mem.New()
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+	mockRegion.Name = "euw1"
+
+	_, errResp := state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	router, controller := testRouter(state)
+
+	// The region is read from the API, so holds redacted secrets.
+	updatedRegion := mockRegion.Redacted()
+	updatedRegion.API = []*domain.RegionAPI{{Address: "http://10.0.0.20:4646", Default: true}}
+
+	testCases := []struct {
+		name         string
+		inputPath    string
+		inputBody    any
+		expectedCode int
+	}{
+		{
+			name:         "missing region",
+			inputPath:    "/v1alpha1/regions/euw2",
+			inputBody:    RegionUpdateReq{Region: &domain.Region{Name: "euw2", API: updatedRegion.API}},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "missing object",
+			inputPath:    "/v1alpha1/regions/euw1",
+			inputBody:    RegionUpdateReq{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "mismatched name",
+			inputPath:    "/v1alpha1/regions/euw1",
+			inputBody:    RegionUpdateReq{Region: &domain.Region{Name: "euw2", API: updatedRegion.API}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid region",
+			inputPath:    "/v1alpha1/regions/euw1",
+			inputBody:    RegionUpdateReq{Region: &domain.Region{Name: "euw1"}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid check index",
+			inputPath:    "/v1alpha1/regions/euw1?check_index=invalid",
+			inputBody:    RegionUpdateReq{Region: updatedRegion},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "stale check index",
+			inputPath:    "/v1alpha1/regions/euw1?check_index=100",
+			inputBody:    RegionUpdateReq{Region: updatedRegion},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testRequest(t, router, http.MethodPut, tc.inputPath, tc.inputBody)
+			must.Eq(t, tc.expectedCode, rec.Code, must.Sprint(rec.Body.String()))
+		})
+	}
+
+	// None of the failed requests updated the region. The response holds the
+	// updated region with its secrets redacted, while the stored region keeps
+	// the secrets it was created with.
+	rec := testRequest(t, router, http.MethodPut, "/v1alpha1/regions/euw1", RegionUpdateReq{Region: updatedRegion})
+	must.Eq(t, http.StatusOK, rec.Code)
+
+	var resp RegionUpdateResp
+	must.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	must.Eq(t, updatedRegion.API, resp.Region.API)
+	must.Eq(t, domain.RegionRedactedValue, resp.Region.Auth.Token)
+	must.Eq(t, 2, resp.Region.Metadata.ModifyIndex)
+
+	getResp, errResp := state.Region().Get(&store.RegionGetReq{RegionName: "euw1"})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedRegion.API, getResp.Region.API)
+	must.Eq(t, mockRegion.Auth.Token, getResp.Region.Auth.Token)
+
+	must.MapContainsKey(t, controller.clients, "euw1")
+}
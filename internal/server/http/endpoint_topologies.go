@@ -11,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/rasorp/attila/internal/server/nomad"
+	"github.com/rasorp/attila/internal/store"
 )
 
 type TopologyListReq struct{}
@@ -51,10 +52,36 @@ func (t topologiesEndpoint) routes() chi.Router {
 func (t topologiesEndpoint) get(w http.ResponseWriter, r *http.Request) {
 	regionName := r.Context().Value("region-name").(string)
 
-	if topology := t.nomadController.GetTopology(regionName); topology == nil {
-		respErr := NewResponseError(errors.New("region not found"), http.StatusNotFound)
-		httpWriteResponseError(w, respErr)
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	var (
+		topology *nomad.Topology
+		index    uint64
+	)
+
+	errResp := store.BlockingQuery(&queryOpts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		var changeCh <-chan struct{}
+
+		// Read the index before the topology, so a change which happens in
+		// between results in the query running again, rather than being
+		// missed.
+		index, changeCh = t.nomadController.GetTopologyIndex()
+
+		if topology = t.nomadController.GetTopology(regionName); topology == nil {
+			return 0, nil, store.NewErrorResp(errors.New("region not found"), http.StatusNotFound)
+		}
+		return index, topologyWaitFunc(changeCh), nil
+	})
+
+	if errResp != nil {
+		httpWriteResponseError(w, NewResponseError(errResp.Err(), errResp.StatusCode()))
 	} else {
+		setIndexHeader(w, index)
+
 		resp := TopologyGetResp{
 			Topology:             topology,
 			internalResponseMeta: newInternalResponseMeta(http.StatusOK),
@@ -64,13 +91,46 @@ func (t topologiesEndpoint) get(w http.ResponseWriter, r *http.Request) {
 }
 
 func (t topologiesEndpoint) list(w http.ResponseWriter, r *http.Request) {
+	queryOpts, queryErr := parseQueryOptions(r)
+	if queryErr != nil {
+		httpWriteResponseError(w, NewResponseError(queryErr, http.StatusBadRequest))
+		return
+	}
+
+	var (
+		topologies []*nomad.Overview
+		index      uint64
+	)
+
+	_ = store.BlockingQuery(&queryOpts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		var changeCh <-chan struct{}
+
+		index, changeCh = t.nomadController.GetTopologyIndex()
+		topologies = t.nomadController.GetTopologies()
+
+		return index, topologyWaitFunc(changeCh), nil
+	})
+
+	setIndexHeader(w, index)
+
 	resp := TopologyListResp{
-		Topologies:           t.nomadController.GetTopologies(),
+		Topologies:           topologies,
 		internalResponseMeta: newInternalResponseMeta(http.StatusOK),
 	}
 	httpWriteResponse(w, &resp)
 }
 
+// topologyWaitFunc returns a function which blocks until the topology change
+// channel is closed, or the context is done.
+func topologyWaitFunc(changeCh <-chan struct{}) func(context.Context) {
+	return func(ctx context.Context) {
+		select {
+		case <-changeCh:
+		case <-ctx.Done():
+		}
+	}
+}
+
 func (t topologiesEndpoint) context(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
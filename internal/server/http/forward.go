@@ -0,0 +1,116 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/store"
+)
+
+// headerForwarded marks a request which has been forwarded to the leader. A
+// forwarded request received with a verified client certificate is always
+// handled locally, which avoids forwarding loops while servers disagree about
+// the leader during an election.
+const headerForwarded = "X-Attila-Forwarded"
+
+// forwardMiddleware proxies requests which must be handled by the leader of a
+// replicated state backend. This includes all writes and topology reads, as
+// only the leader runs the topology collectors. Job status reads are forwarded
+// as only the leader keeps the Nomad region clients up to date, and job drift
+// reads as only the leader runs the drift reconciler. The event stream is also
+// forwarded, as events are published by the server which performs the write.
+// The transport is used to connect to the leader and should hold the TLS
+// configuration required by it.
+func forwardMiddleware(
+	logger *zap.Logger, replicated store.Replicated, transport http.RoundTripper) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if !requiresLeader(r) || isForwarded(r) || replicated.IsLeader() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			leaderAddr := replicated.LeaderHTTPAddress()
+			if leaderAddr == "" {
+				httpWriteResponseError(w, NewResponseError(errors.New("no cluster leader"), http.StatusServiceUnavailable))
+				return
+			}
+
+			target, err := url.Parse(leaderAddr)
+			if err != nil {
+				httpWriteResponseError(w, NewResponseError(
+					fmt.Errorf("failed to parse leader address: %w", err), http.StatusInternalServerError))
+				return
+			}
+
+			// The event stream is long-lived, so remove the write deadline the
+			// server sets on every request. The proxy flushes each event as it
+			// is received, as the response does not have a content length.
+			if isEventStream(r) {
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+			}
+
+			proxy := httputil.ReverseProxy{
+				Transport: transport,
+				Rewrite: func(pr *httputil.ProxyRequest) {
+					pr.SetURL(target)
+					pr.SetXForwarded()
+					pr.Out.Header.Set(headerForwarded, "true")
+				},
+				ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+					logger.Error("failed to forward request to leader",
+						zap.String("leader_address", leaderAddr), zap.Error(err))
+					httpWriteResponseError(w, NewResponseError(
+						fmt.Errorf("failed to forward request to leader: %w", err), http.StatusBadGateway))
+				},
+			}
+
+			proxy.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requiresLeader returns whether the request must be handled by the leader.
+func requiresLeader(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return strings.HasPrefix(r.URL.Path, "/v1alpha1/topologies") ||
+			r.URL.Path == "/v1alpha1/jobs/drift" || isJobStatus(r) || isEventStream(r)
+	default:
+		return true
+	}
+}
+
+// isForwarded returns whether the request was forwarded by another server. The
+// header is only trusted on connections which presented a verified client
+// certificate, as the leader forwarding transport does. Otherwise, any client
+// could set it to have a follower handle a request meant for the leader.
+func isForwarded(r *http.Request) bool {
+	return r.Header.Get(headerForwarded) != "" && r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+func isEventStream(r *http.Request) bool {
+	return r.URL.Path == "/v1alpha1/event/stream"
+}
+
+// isJobStatus returns whether the request reads the status of a job, which is
+// served from the path "/v1alpha1/jobs/{namespace}/{id}/status".
+func isJobStatus(r *http.Request) bool {
+	jobPath, ok := strings.CutPrefix(r.URL.Path, "/v1alpha1/jobs/")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(jobPath, "/")
+	return len(parts) == 3 && parts[2] == "status"
+}
@@ -0,0 +1,96 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+)
+
+// testFollower is a store.Replicated implementation for a follower, whose
+// leader serves HTTP at leaderAddr.
+type testFollower struct {
+	leaderAddr string
+}
+
+func (f *testFollower) IsLeader() bool            { return false }
+func (f *testFollower) LeaderHTTPAddress() string { return f.leaderAddr }
+func (f *testFollower) LeaderCh() <-chan bool     { return nil }
+func (f *testFollower) Shutdown() error           { return nil }
+
+func TestForwardMiddleware(t *testing.T) {
+
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "leader")
+	}))
+	t.Cleanup(leader.Close)
+
+	handler := forwardMiddleware(zap.NewNop(), &testFollower{leaderAddr: leader.URL}, http.DefaultTransport)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "local")
+		}))
+
+	verifiedTLS := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}
+
+	testCases := []struct {
+		name           string
+		inputMethod    string
+		inputForwarded bool
+		inputTLS       *tls.ConnectionState
+		expectedBody   string
+	}{
+		{
+			name:         "read handled locally",
+			inputMethod:  http.MethodGet,
+			expectedBody: "local",
+		},
+		{
+			name:         "write forwarded",
+			inputMethod:  http.MethodPost,
+			expectedBody: "leader",
+		},
+		{
+			name:           "forwarded header without tls",
+			inputMethod:    http.MethodPost,
+			inputForwarded: true,
+			expectedBody:   "leader",
+		},
+		{
+			name:           "forwarded header without client certificate",
+			inputMethod:    http.MethodPost,
+			inputForwarded: true,
+			inputTLS:       &tls.ConnectionState{},
+			expectedBody:   "leader",
+		},
+		{
+			name:           "forwarded header with verified client certificate",
+			inputMethod:    http.MethodPost,
+			inputForwarded: true,
+			inputTLS:       verifiedTLS,
+			expectedBody:   "local",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.inputMethod, "/v1alpha1/regions", nil)
+			req.TLS = tc.inputTLS
+			if tc.inputForwarded {
+				req.Header.Set(headerForwarded, "true")
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			must.Eq(t, http.StatusOK, rec.Code)
+			must.Eq(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -4,31 +4,47 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-)
 
-func loggerMiddleware(logger *zap.Logger, accessLevel string) func(next http.Handler) http.Handler {
+	"github.com/rasorp/attila/internal/acl"
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/event"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/tracing"
+)
 
-	var level zapcore.Level
-	switch accessLevel {
-	case "trace":
-		level = zapcore.DebugLevel
-	case "debug":
-		level = zapcore.DebugLevel
+// ParseAccessLogLevel converts the access log level config parameter to the
+// level access log entries are written at.
+func ParseAccessLogLevel(accessLevel string) (zapcore.Level, error) {
+	switch strings.ToLower(accessLevel) {
+	case "trace", "debug":
+		return zapcore.DebugLevel, nil
 	case "info":
-		level = zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	default:
-		panic(fmt.Sprintf("unsupported access log level: %q", accessLevel))
+		return zapcore.InvalidLevel, fmt.Errorf("unsupported access log level: %q", accessLevel)
 	}
+}
 
+// loggerMiddleware writes an access log entry for each request at the level
+// held by accessLevel, which can be changed while the server is running.
+func loggerMiddleware(logger *zap.Logger, accessLevel zap.AtomicLevel) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
 
@@ -51,7 +67,7 @@ func loggerMiddleware(logger *zap.Logger, accessLevel string) func(next http.Han
 				}
 
 				// Check the log message would be written before writing out all the data.
-				if entry := logger.Check(level, "successfully handled HTTP request"); entry != nil {
+				if entry := logger.Check(accessLevel.Level(), "successfully handled HTTP request"); entry != nil {
 					entry.Write(
 						zap.String("remote_address", r.RemoteAddr),
 						zap.String("path", r.URL.Path),
@@ -72,6 +88,273 @@ func loggerMiddleware(logger *zap.Logger, accessLevel string) func(next http.Han
 	}
 }
 
+// tracingMiddleware creates a server span for each request, continuing any
+// trace context sent by the caller. The span is named using the route pattern
+// once the router has matched the request, so spans for the same endpoint can
+// be grouped regardless of the object names within the path.
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := tracing.Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := tracing.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			))
+		defer span.End()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		if pattern := routePattern(r); pattern != "" {
+			span.SetName(r.Method + " " + pattern)
+			span.SetAttributes(attribute.String("http.route", pattern))
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
+
+// routePattern returns the pattern of the route which handled the request. It
+// is only populated once the router has matched the request, so should be
+// called after the handler returns.
+func routePattern(r *http.Request) string {
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+		return routeCtx.RoutePattern()
+	}
+	return ""
+}
+
+// blockingQueryDeadlineBuffer is added to the wait time of a blocking query
+// when extending the write deadline, allowing time for the response to be
+// written once the query returns.
+const blockingQueryDeadlineBuffer = 15 * time.Second
+
+// blockingQueryMiddleware extends the write deadline of blocking queries. The
+// server write timeout is shorter than the time a query can block for, which
+// would otherwise cause the connection to be closed before the response is
+// written. Invalid query options are ignored, as the handler will reject them.
+func blockingQueryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts, err := parseQueryOptions(r); err == nil && opts.MinIndex > 0 {
+			deadline := time.Now().Add(opts.Wait() + blockingQueryDeadlineBuffer)
+			_ = http.NewResponseController(w).SetWriteDeadline(deadline)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestTimeoutMiddleware applies the timeout as a deadline to the request
+// context, so Nomad calls made while handling the request are cancelled once
+// it elapses. The write deadline is extended past the timeout, as it can be
+// longer than the server write timeout. A zero timeout applies no deadline and
+// removes the write deadline, as a job registration run can wait on Nomad
+// deployments for longer than the server write timeout.
+func requestTimeoutMiddleware(timeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if timeout <= 0 {
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + blockingQueryDeadlineBuffer))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// headerToken is the request header used to supply the secret ID of the ACL
+// token which authenticates the request.
+const headerToken = "X-Attila-Token"
+
+// aclMiddleware authenticates each request using the ACL token sent in the
+// token header and authorizes it against the requested resource. A missing
+// token results in a 401, while an unknown token or one without the required
+// access results in a 403. The resolved token is added to the request context,
+// so handlers can identify the caller.
+func aclMiddleware(stateStore store.State) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			// Bootstrapping creates the first token, so cannot require one.
+			// The state ensures it can only ever succeed once.
+			if r.URL.Path == "/v1alpha1/acl/bootstrap" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			secretID := r.Header.Get(headerToken)
+			if secretID == "" {
+				httpWriteResponseError(w, NewResponseError(errors.New("acl token required"), http.StatusUnauthorized))
+				return
+			}
+
+			aclObj, token, errResp := acl.Resolve(stateStore, secretID)
+			if errResp != nil {
+				if errResp.StatusCode() == http.StatusNotFound {
+					httpWriteResponseError(w, NewResponseError(errors.New("acl token not found"), http.StatusForbidden))
+				} else {
+					httpWriteResponseError(w, NewResponseError(errResp.Err(), errResp.StatusCode()))
+				}
+				return
+			}
+
+			if err := aclAuthorize(r, aclObj); err != nil {
+				httpWriteResponseError(w, NewResponseError(err, http.StatusForbidden))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxKey{}, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ctxKey is the key of the resolved ACL token within the request context. It
+// is unexported, so the value cannot collide with that of another package.
+type ctxKey struct{}
+
+// requestACLToken returns the ACL token the middleware resolved for the
+// request, and whether one was resolved.
+func requestACLToken(r *http.Request) (*domain.ACLToken, bool) {
+	token, ok := r.Context().Value(ctxKey{}).(*domain.ACLToken)
+	return token, ok
+}
+
+// requestIsPrivileged returns whether the caller may read secret fields, which
+// requires a management token. When ACLs are disabled, the middleware does not
+// add a token to the context and every caller has full access.
+func requestIsPrivileged(r *http.Request) bool {
+	token, ok := requestACLToken(r)
+	return !ok || token.IsManagement()
+}
+
+// aclPathResources maps the API path prefixes to the resource they serve.
+// When prefixes overlap, the longest matching prefix is used.
+var aclPathResources = map[string]acl.Resource{
+	"/v1alpha1/regions":               acl.ResourceRegion,
+	"/v1alpha1/jobs":                  acl.ResourceJob,
+	"/v1alpha1/jobs/register/methods": acl.ResourceMethod,
+	"/v1alpha1/jobs/register/rules":   acl.ResourceRule,
+	"/v1alpha1/jobs/register/plans":   acl.ResourcePlan,
+	"/v1alpha1/jobs/register/runs":    acl.ResourcePlan,
+	"/v1alpha1/topologies":            acl.ResourceTopology,
+}
+
+// aclEventResources maps event topics to the resource a subscriber must be
+// able to read in order to receive them.
+var aclEventResources = map[event.Topic]acl.Resource{
+	event.TopicRegion:            acl.ResourceRegion,
+	event.TopicJobRegisterMethod: acl.ResourceMethod,
+	event.TopicJobRegisterRule:   acl.ResourceRule,
+	event.TopicJobRegisterPlan:   acl.ResourcePlan,
+	event.TopicJobRegisterRun:    acl.ResourcePlan,
+}
+
+// aclAuthorize returns an error if the ACL does not allow the request. Paths
+// which do not map to a known resource require a management token. Metrics do
+// not expose object details, so can be read with any valid token.
+func aclAuthorize(r *http.Request, aclObj *acl.ACL) error {
+	if aclObj.IsManagement() {
+		return nil
+	}
+
+	switch {
+	case r.URL.Path == "/v1alpha1/acl/tokens/self", r.URL.Path == "/v1/metrics":
+		return nil
+	case isEventStream(r):
+		return aclAuthorizeEventStream(r, aclObj)
+	}
+
+	resource, ok := aclPathResource(r.URL.Path)
+	if !ok {
+		return errors.New("acl management token required")
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if !aclObj.AllowRead(resource) {
+			return fmt.Errorf("acl token does not allow %s read", resource)
+		}
+	default:
+		if !aclObj.AllowWrite(resource) {
+			return fmt.Errorf("acl token does not allow %s write", resource)
+		}
+	}
+	return nil
+}
+
+// aclPathResource returns the resource served by the path, using the longest
+// matching prefix, as map iteration does not have an order.
+func aclPathResource(path string) (acl.Resource, bool) {
+	var (
+		matchedPrefix   string
+		matchedResource acl.Resource
+	)
+
+	for prefix, resource := range aclPathResources {
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if len(prefix) > len(matchedPrefix) {
+			matchedPrefix, matchedResource = prefix, resource
+		}
+	}
+
+	return matchedResource, matchedPrefix != ""
+}
+
+// aclAuthorizeEventStream ensures the ACL can read the resources of every
+// requested topic. Subscribing to all topics requires read access to all of
+// them.
+func aclAuthorizeEventStream(r *http.Request, aclObj *acl.ACL) error {
+	// Invalid topics are not authorized against, as the handler will reject
+	// the request.
+	topics, err := parseEventTopics(r)
+	if err != nil {
+		return nil
+	}
+
+	if len(topics) == 0 {
+		topics = map[event.Topic][]string{event.TopicAll: nil}
+	}
+
+	for topic := range topics {
+		var resources []acl.Resource
+
+		if topic == event.TopicAll {
+			for _, resource := range aclEventResources {
+				resources = append(resources, resource)
+			}
+		} else {
+			resources = append(resources, aclEventResources[topic])
+		}
+
+		for _, resource := range resources {
+			if !aclObj.AllowRead(resource) {
+				return fmt.Errorf("acl token does not allow %s read", resource)
+			}
+		}
+	}
+
+	return nil
+}
+
 func contentInBytes(header http.Header) int {
 	if i, err := strconv.Atoi(header.Get("Content-Length")); err != nil {
 		return 0
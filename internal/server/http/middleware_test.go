@@ -0,0 +1,417 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/acl"
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+// testACLPolicies are the policies stored by testACLState. Each is linked to
+// its own client token.
+var testACLPolicies = []*domain.ACLPolicy{
+	{Name: "job-write", Job: domain.ACLAccessWrite},
+	{Name: "plan-write", Plan: domain.ACLAccessWrite},
+	{Name: "region-read", Region: domain.ACLAccessRead},
+	{Name: "region-write", Region: domain.ACLAccessWrite},
+}
+
+// testACLState returns a state holding a management token and a client token
+// for each of the test policies. The secret ID of each token is returned,
+// keyed by the policy name, or "management" for the management token.
+func testACLState(t *testing.T) (store.State, map[string]string) {
+	t.Helper()
+
+	state, err := mem.New()
+	must.NoError(t, err)
+
+	management := domain.NewACLToken("management", domain.ACLTokenTypeManagement, nil)
+
+	_, errResp := state.ACL().Token().Bootstrap(&store.ACLTokenBootstrapReq{Token: management})
+	must.Nil(t, errResp)
+
+	secretIDs := map[string]string{"management": management.SecretID}
+
+	for _, policy := range testACLPolicies {
+		_, errResp := state.ACL().Policy().Create(&store.ACLPolicyCreateReq{Policy: policy})
+		must.Nil(t, errResp)
+
+		token := domain.NewACLToken(policy.Name, domain.ACLTokenTypeClient, []string{policy.Name})
+
+		_, errResp = state.ACL().Token().Create(&store.ACLTokenCreateReq{Token: token})
+		must.Nil(t, errResp)
+
+		secretIDs[policy.Name] = token.SecretID
+	}
+
+	return state, secretIDs
+}
+
+func TestACLMiddleware(t *testing.T) {
+
+	state, secretIDs := testACLState(t)
+
+	handler := aclMiddleware(state)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		name         string
+		inputToken   string
+		inputMethod  string
+		inputPath    string
+		expectedCode int
+	}{
+		{
+			name:         "no token",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/regions",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "unknown token",
+			inputToken:   "f3a9c2b8-5d1e-4c7a-9b6f-0e2d4a8c1b7e",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/regions",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "bootstrap without token",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/acl/bootstrap",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read can list regions",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/regions",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read can get region",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/regions/euw1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read cannot create region",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/regions",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region read cannot delete region",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodDelete,
+			inputPath:    "/v1alpha1/regions/euw1",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region write can create region",
+			inputToken:   "region-write",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/regions",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region write cannot read methods",
+			inputToken:   "region-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/jobs/register/methods",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region write cannot match prefix without separator",
+			inputToken:   "region-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/regionsfoo",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region write cannot read policies",
+			inputToken:   "region-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/acl/policies",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "client can read own token",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/acl/tokens/self",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "client can read metrics",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1/metrics",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "job write cannot write methods",
+			inputToken:   "job-write",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/jobs/register/methods",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "plan write can read runs",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/jobs/register/runs",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read can stream region topic",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/event/stream?topic=Region:euw1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read cannot stream plan topic",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/event/stream?topic=Region&topic=JobRegisterPlan",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region read cannot stream all topics",
+			inputToken:   "region-read",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/event/stream",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "plan write can stream run topic",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/event/stream?topic=JobRegisterRun",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "management can stream all topics",
+			inputToken:   "management",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/event/stream?topic=*",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "management can read policies",
+			inputToken:   "management",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/acl/policies",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "plan write cannot stop job",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodDelete,
+			inputPath:    "/v1alpha1/jobs/default/example",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "plan write cannot purge job",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodDelete,
+			inputPath:    "/v1alpha1/jobs/default/example?purge=true",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "plan write cannot read job status",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodGet,
+			inputPath:    "/v1alpha1/jobs/default/example/status",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "plan write can run plan",
+			inputToken:   "plan-write",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/jobs/register/plans/01JZ0000000000000000000000/run",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "job write can stop job",
+			inputToken:   "job-write",
+			inputMethod:  http.MethodDelete,
+			inputPath:    "/v1alpha1/jobs/default/example?purge=true",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "job write cannot run plan",
+			inputToken:   "job-write",
+			inputMethod:  http.MethodPost,
+			inputPath:    "/v1alpha1/jobs/register/plans/01JZ0000000000000000000000/run",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "management can stop job",
+			inputToken:   "management",
+			inputMethod:  http.MethodDelete,
+			inputPath:    "/v1alpha1/jobs/default/example?purge=true",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.inputMethod, tc.inputPath, nil)
+			if tc.inputToken != "" {
+				secretID, ok := secretIDs[tc.inputToken]
+				if !ok {
+					secretID = tc.inputToken
+				}
+				req.Header.Set(headerToken, secretID)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			must.Eq(t, tc.expectedCode, rec.Code, must.Sprint(rec.Body.String()))
+		})
+	}
+}
+
+func Test_aclPathResource(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		inputPath        string
+		expectedResource acl.Resource
+		expectedOK       bool
+	}{
+		{name: "exact prefix", inputPath: "/v1alpha1/regions", expectedResource: acl.ResourceRegion, expectedOK: true},
+		{name: "nested path", inputPath: "/v1alpha1/regions/euw1", expectedResource: acl.ResourceRegion, expectedOK: true},
+		{name: "job", inputPath: "/v1alpha1/jobs/default/example", expectedResource: acl.ResourceJob, expectedOK: true},
+		{
+			name:             "longest prefix method",
+			inputPath:        "/v1alpha1/jobs/register/methods/example",
+			expectedResource: acl.ResourceMethod,
+			expectedOK:       true,
+		},
+		{
+			name:             "longest prefix run",
+			inputPath:        "/v1alpha1/jobs/register/runs",
+			expectedResource: acl.ResourcePlan,
+			expectedOK:       true,
+		},
+		{name: "prefix without separator", inputPath: "/v1alpha1/regionsfoo", expectedOK: false},
+		{name: "unknown", inputPath: "/v1alpha1/acl/policies", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualResource, actualOK := aclPathResource(tc.inputPath)
+			must.Eq(t, tc.expectedResource, actualResource)
+			must.Eq(t, tc.expectedOK, actualOK)
+		})
+	}
+}
+
+func Test_requestIsPrivileged(t *testing.T) {
+
+	testCases := []struct {
+		name           string
+		inputToken     *domain.ACLToken
+		expectedOutput bool
+	}{
+		{
+			name:           "acl disabled",
+			inputToken:     nil,
+			expectedOutput: true,
+		},
+		{
+			name:           "management",
+			inputToken:     domain.NewACLToken("management", domain.ACLTokenTypeManagement, nil),
+			expectedOutput: true,
+		},
+		{
+			name:           "client",
+			inputToken:     domain.NewACLToken("client", domain.ACLTokenTypeClient, []string{"region-read"}),
+			expectedOutput: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1alpha1/regions/euw1", nil)
+			if tc.inputToken != nil {
+				req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, tc.inputToken))
+			}
+			must.Eq(t, tc.expectedOutput, requestIsPrivileged(req))
+		})
+	}
+}
+
+func TestRouter_regionSecrets(t *testing.T) {
+
+	state, secretIDs := testACLState(t)
+
+	mockRegion := mock.Region()
+	mockRegion.Name = "euw1"
+
+	_, errResp := state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	router := NewRouter(zap.NewNop(), zap.NewAtomicLevel(), state, nil, nil, true, time.Minute, nil)
+
+	testCases := []struct {
+		name         string
+		inputToken   string
+		inputPath    string
+		expectedCode int
+	}{
+		{
+			name:         "region read without secrets",
+			inputToken:   "region-read",
+			inputPath:    "/v1alpha1/regions/euw1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "region read with secrets",
+			inputToken:   "region-read",
+			inputPath:    "/v1alpha1/regions/euw1?secrets=true",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "region write with secrets",
+			inputToken:   "region-write",
+			inputPath:    "/v1alpha1/regions/euw1?secrets=true",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "management with secrets",
+			inputToken:   "management",
+			inputPath:    "/v1alpha1/regions/euw1?secrets=true",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.inputPath, nil)
+			req.Header.Set(headerToken, secretIDs[tc.inputToken])
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			must.Eq(t, tc.expectedCode, rec.Code, must.Sprint(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rasorp/attila/internal/store"
+)
+
+const (
+	// queryParamCheckIndex is the URL query parameter used to supply a
+	// check-and-set index on write requests.
+	queryParamCheckIndex = "check_index"
+
+	// headerIfMatch is the standard HTTP header which can be used as an
+	// alternative to the check index query parameter. The value can
+	// optionally be quoted, as is the norm for entity tags.
+	headerIfMatch = "If-Match"
+
+	// queryParamIndex and queryParamWait are the URL query parameters used to
+	// perform a blocking query on read requests. The wait value is a duration
+	// string, such as "30s".
+	queryParamIndex = "index"
+	queryParamWait  = "wait"
+
+	// queryParamSecrets is the URL query parameter used to request that secret
+	// fields are included within a response, rather than being redacted.
+	queryParamSecrets = "secrets"
+
+	// queryParamJobID and queryParamNamespace are the URL query parameters
+	// used to filter list responses to a single Nomad job.
+	queryParamJobID     = "job_id"
+	queryParamNamespace = "namespace"
+
+	// queryParamPurge and queryParamPlan are the URL query parameters used to
+	// purge a stopped job, and to plan a stop without performing it.
+	queryParamPurge = "purge"
+	queryParamPlan  = "plan"
+
+	// headerIndex is the response header which details the index of the data
+	// returned by a read request.
+	headerIndex = "X-Attila-Index"
+)
+
+// parseCheckIndex returns the optional check-and-set index from the request.
+// The query parameter takes precedence over the header. A nil index is
+// returned when neither is set, indicating the caller does not want the check
+// to be performed.
+func parseCheckIndex(r *http.Request) (*uint64, error) {
+	raw := r.URL.Query().Get(queryParamCheckIndex)
+	if raw == "" {
+		raw = strings.Trim(r.Header.Get(headerIfMatch), `"`)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	index, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse check index %q: %w", raw, err)
+	}
+
+	return &index, nil
+}
+
+// parseQueryOptions returns the blocking query options from the request. The
+// request context is included, so the query is abandoned if the client
+// disconnects.
+func parseQueryOptions(r *http.Request) (store.QueryOptions, error) {
+	opts := store.QueryOptions{Ctx: r.Context()}
+
+	if raw := r.URL.Query().Get(queryParamIndex); raw != "" {
+		index, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return opts, fmt.Errorf("failed to parse index %q: %w", raw, err)
+		}
+		opts.MinIndex = index
+	}
+
+	if raw := r.URL.Query().Get(queryParamWait); raw != "" {
+		wait, err := time.ParseDuration(raw)
+		if err != nil {
+			return opts, fmt.Errorf("failed to parse wait %q: %w", raw, err)
+		}
+		opts.MaxWait = wait
+	}
+
+	return opts, nil
+}
+
+// setIndexHeader writes the index response header. The value is never lower
+// than one, so clients can always use it as the index of a subsequent blocking
+// query without it returning immediately.
+func setIndexHeader(w http.ResponseWriter, index uint64) {
+	w.Header().Set(headerIndex, strconv.FormatUint(max(index, 1), 10))
+}
+
+// parseIncludeSecrets returns whether the request asks for secret fields to be
+// included within the response. An error is returned if the parameter cannot
+// be parsed.
+func parseIncludeSecrets(r *http.Request) (bool, error) {
+	return parseQueryBool(r, queryParamSecrets)
+}
+
+// parseQueryBool returns the value of the optional boolean query parameter,
+// which is false when it is not set. An error is returned if the parameter
+// cannot be parsed.
+func parseQueryBool(r *http.Request, param string) (bool, error) {
+	raw := r.URL.Query().Get(param)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s %q: %w", param, raw, err)
+	}
+
+	return value, nil
+}
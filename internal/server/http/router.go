@@ -5,21 +5,61 @@ package http
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/rasorp/attila/internal/event"
+	"github.com/rasorp/attila/internal/metrics"
 	"github.com/rasorp/attila/internal/server/nomad"
 	"github.com/rasorp/attila/internal/store"
 )
 
-func NewRouter(logger *zap.Logger, accessLevel string, stateStore store.State, nomadController nomad.Controller) *chi.Mux {
+func NewRouter(
+	logger *zap.Logger,
+	accessLevel zap.AtomicLevel,
+	stateStore store.State,
+	nomadController nomad.Controller,
+	eventBroker *event.Broker,
+	aclEnabled bool,
+	requestTimeout time.Duration,
+	forwardTransport http.RoundTripper,
+) *chi.Mux {
 
 	r := chi.NewRouter()
+	r.Use(metricsMiddleware)
+	r.Use(tracingMiddleware)
 	r.Use(loggerMiddleware(logger, accessLevel))
+	r.Use(blockingQueryMiddleware)
+
+	// When ACLs are enabled, every request must be authenticated and
+	// authorized before it is handled or forwarded to the leader.
+	if aclEnabled {
+		r.Use(aclMiddleware(stateStore))
+	}
+
+	// Replicated state backends only accept writes on the leader, so requests
+	// which need the leader are forwarded to it using the passed transport.
+	if replicated, ok := stateStore.(store.Replicated); ok {
+		r.Use(forwardMiddleware(logger, replicated, forwardTransport))
+	}
+
+	r.Mount("/v1/metrics", metricsEndpoint{
+		registry: metrics.Default,
+	}.routes())
 
 	r.Route("/v1alpha1", func(r chi.Router) {
 
+		r.Mount("/acl", aclEndpoint{
+			enabled: aclEnabled,
+			state:   stateStore,
+		}.routes())
+
+		r.Mount("/event", eventEndpoint{
+			broker: eventBroker,
+		}.routes())
+
 		r.Mount(
 			"/topologies",
 			topologiesEndpoint{
@@ -27,7 +67,7 @@ func NewRouter(logger *zap.Logger, accessLevel string, stateStore store.State, n
 			}.routes(),
 		)
 
-		r.Mount("/jobs", jobRouter(logger, stateStore, nomadController))
+		r.Mount("/jobs", jobRouter(logger, stateStore, nomadController, requestTimeout))
 
 		r.Mount("/regions", regionsEndpoint{
 			nomadController: nomadController,
@@ -38,7 +78,8 @@ func NewRouter(logger *zap.Logger, accessLevel string, stateStore store.State, n
 	return r
 }
 
-func jobRouter(logger *zap.Logger, stateStore store.State, nomadController nomad.Controller) http.Handler {
+func jobRouter(
+	logger *zap.Logger, stateStore store.State, nomadController nomad.Controller, requestTimeout time.Duration) http.Handler {
 	r := chi.NewRouter()
 
 	r.Mount("/register/methods", jobsRegisterMethodsEndpoint{
@@ -48,6 +89,7 @@ func jobRouter(logger *zap.Logger, stateStore store.State, nomadController nomad
 	r.Mount("/register/plans", jobsRegisterPlansEndpoint{
 		logger:          logger,
 		nomadController: nomadController,
+		requestTimeout:  requestTimeout,
 		state:           stateStore,
 	}.routes())
 
@@ -55,5 +97,21 @@ func jobRouter(logger *zap.Logger, stateStore store.State, nomadController nomad
 		state: stateStore,
 	}.routes())
 
+	r.Mount("/register/runs", jobsRegisterRunsEndpoint{
+		state: stateStore,
+	}.routes())
+
+	r.Mount("/drift", jobsDriftEndpoint{
+		nomadController: nomadController,
+	}.routes())
+
+	// The register and drift routes are static, so chi matches them before
+	// the job namespace and ID parameters.
+	r.Mount("/{namespace}/{id}", jobsEndpoint{
+		nomadController: nomadController,
+		requestTimeout:  requestTimeout,
+		state:           stateStore,
+	}.routes())
+
 	return r
 }
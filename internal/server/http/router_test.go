@@ -0,0 +1,57 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/server/nomad"
+	"github.com/rasorp/attila/internal/store"
+)
+
+// testController is a nomad.Controller which records the region clients set
+// by the handlers. Calling any other method panics.
+type testController struct {
+	nomad.Controller
+
+	lock    sync.Mutex
+	clients map[string]*api.Client
+}
+
+func (c *testController) RegionSet(name string, client *api.Client) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.clients[name] = client
+}
+
+// testRouter returns the router, with ACLs disabled, serving the passed state.
+func testRouter(state store.State) (http.Handler, *testController) {
+	controller := testController{clients: make(map[string]*api.Client)}
+	return NewRouter(zap.NewNop(), zap.NewAtomicLevel(), state, &controller, nil, false, time.Minute, nil), &controller
+}
+
+// testRequest performs the request against the handler, encoding the body as
+// JSON when it is not nil.
+func testRequest(t *testing.T, handler http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		must.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+	return rec
+}
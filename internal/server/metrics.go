@@ -0,0 +1,65 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/metrics"
+)
+
+var plansReapedTotal = metrics.Default.NewCounter(
+	"attila_job_register_plans_reaped_total",
+	"The number of expired job registration plans deleted by the plan reaper.",
+)
+
+// registerStateMetrics registers the gauge which reports the number of objects
+// held within the state store, by type. The counts are read from the state each
+// time metrics are gathered, so are always current. Objects which fail to be
+// listed are not reported, rather than reported as zero.
+func (s *Server) registerStateMetrics() {
+	metrics.Default.NewGaugeFunc(
+		"attila_state_objects",
+		"The number of objects held within the state store, by type.",
+		[]string{"type"},
+		func(set func(v float64, labelValues ...string)) {
+
+			if resp, err := s.state.Region().List(nil); err == nil {
+				set(float64(len(resp.Regions)), "region")
+			} else {
+				s.serverLogger.Error("failed to list regions for metrics", zap.Error(err.Err()))
+			}
+
+			if resp, err := s.state.JobRegister().Method().List(nil); err == nil {
+				set(float64(len(resp.Methods)), "job_register_method")
+			} else {
+				s.serverLogger.Error("failed to list job register methods for metrics", zap.Error(err.Err()))
+			}
+
+			if resp, err := s.state.JobRegister().Rule().List(nil); err == nil {
+				set(float64(len(resp.Rules)), "job_register_rule")
+			} else {
+				s.serverLogger.Error("failed to list job register rules for metrics", zap.Error(err.Err()))
+			}
+
+			if resp, err := s.state.JobRegister().Plan().List(nil); err == nil {
+				set(float64(len(resp.Plans)), "job_register_plan")
+			} else {
+				s.serverLogger.Error("failed to list job register plans for metrics", zap.Error(err.Err()))
+			}
+
+			if resp, err := s.state.ACL().Token().List(nil); err == nil {
+				set(float64(len(resp.Tokens)), "acl_token")
+			} else {
+				s.serverLogger.Error("failed to list ACL tokens for metrics", zap.Error(err.Err()))
+			}
+
+			if resp, err := s.state.ACL().Policy().List(nil); err == nil {
+				set(float64(len(resp.Policies)), "acl_policy")
+			} else {
+				s.serverLogger.Error("failed to list ACL policies for metrics", zap.Error(err.Err()))
+			}
+		},
+	)
+}
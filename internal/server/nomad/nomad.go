@@ -4,6 +4,8 @@
 package nomad
 
 import (
+	"context"
+
 	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
 
@@ -16,10 +18,38 @@ type Controller interface {
 	ClientController
 
 	// JobRegistrationPlanCreate
-	JobRegistrationPlanCreate(job *api.Job, store store.State) (*domain.JobRegisterPlan, error)
+	JobRegistrationPlanCreate(ctx context.Context, job *api.Job, store store.State) (*domain.JobRegisterPlan, error)
+
+	// JobRegistrationRun registers the job in the regions of the plan. The
+	// rollout overrides the rollout of the plan and can be nil. The progress
+	// function is called as each region moves through the run and can also be
+	// nil.
+	JobRegistrationRun(
+		ctx context.Context,
+		planID ulid.ULID,
+		job *api.Job,
+		rollout *domain.JobRegisterRollout,
+		progress domain.JobRegisterProgressFunc,
+		store store.State,
+	) (*domain.JobRegisterPlanRun, error)
+
+	// JobStop stops the job within every region which holds it, purging it if
+	// requested. When planning, the regions holding the job are returned
+	// without the job being stopped.
+	JobStop(ctx context.Context, namespace, jobID string, purge, plan bool, store store.State) (*domain.JobStop, error)
+
+	// JobStatus returns the status of the job merged across every region,
+	// flagging the regions which do not match the last run of the job.
+	JobStatus(ctx context.Context, namespace, jobID string, store store.State) (*domain.JobStatus, error)
 
-	// JobRegistrationRun
-	JobRegistrationRun(planID ulid.ULID, job *api.Job, store store.State) (*domain.JobRegisterPlanRun, error)
+	// JobDriftReconcile compares the jobs registered by successful runs with
+	// the jobs running within each region. It is called periodically by the
+	// server which runs the controllers.
+	JobDriftReconcile(ctx context.Context, store store.State) error
+
+	// GetJobDrifts returns the result of the latest drift check of each
+	// region a successful run registered a job in.
+	GetJobDrifts() []*domain.JobDrift
 
 	TopologyController
 }
@@ -51,6 +81,18 @@ type TopologyController interface {
 	// so the caller can check this and return a 404.
 	GetTopology(name string) *Topology
 
+	// GetTopologyIndex returns an index which is incremented each time the
+	// topology of any region changes, along with a channel which is closed on
+	// the next change. It allows the HTTP endpoints to perform blocking
+	// queries.
+	GetTopologyIndex() (uint64, <-chan struct{})
+
+	// StopCollectors stops the topology collection of every region, without
+	// removing the region clients. Setting a region starts its collection
+	// again. It is called when the server loses leadership, as only the
+	// leader collects region topology.
+	StopCollectors()
+
 	// ClientController ensures modifications to the tracked regions within
 	// Attila state can be propagated to the topology controller.
 	ClientController
@@ -0,0 +1,118 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/store"
+)
+
+// planReaper periodically deletes job registration plans which have expired.
+// Only the server which runs the Nomad controllers runs the reaper, so it is
+// started and stopped alongside them.
+type planReaper struct {
+	logger   *zap.Logger
+	state    store.State
+	interval time.Duration
+
+	// stopCh is closed to stop the running reaper and is nil when the reaper
+	// is not running. doneCh is closed once the running reaper has exited.
+	// All access should use the lock, as the reaper is started and stopped as
+	// leadership changes.
+	stopCh chan struct{}
+	doneCh chan struct{}
+	lock   sync.Mutex
+}
+
+func newPlanReaper(logger *zap.Logger, state store.State, interval time.Duration) *planReaper {
+	return &planReaper{
+		logger:   logger.Named("plan_reaper"),
+		state:    state,
+		interval: interval,
+	}
+}
+
+// start runs the reaper in a routine until stop is called. Calling start on a
+// running reaper has no effect.
+func (p *planReaper) start() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopCh != nil {
+		return
+	}
+
+	p.stopCh = make(chan struct{})
+	p.doneCh = make(chan struct{})
+	go p.run(p.stopCh, p.doneCh)
+}
+
+// stop stops the running reaper and waits for it to exit, so no plans are
+// deleted once it returns. Calling stop on a stopped reaper has no effect.
+func (p *planReaper) stop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopCh == nil {
+		return
+	}
+
+	close(p.stopCh)
+	<-p.doneCh
+	p.stopCh, p.doneCh = nil, nil
+}
+
+func (p *planReaper) run(stopCh <-chan struct{}, doneCh chan<- struct{}) {
+	defer close(doneCh)
+
+	p.logger.Info("starting plan reaper", zap.Duration("interval", p.interval))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			p.logger.Info("shutting down plan reaper")
+			return
+		case <-ticker.C:
+			p.reap(time.Now())
+		}
+	}
+}
+
+// reap deletes every plan which had expired by the passed time. A failure to
+// delete one plan does not stop the others being deleted, and the plan will be
+// retried on the next pass.
+func (p *planReaper) reap(now time.Time) {
+	listResp, err := p.state.JobRegister().Plan().List(nil)
+	if err != nil {
+		p.logger.Error("failed to list job register plans", zap.Error(err.Err()))
+		return
+	}
+
+	for _, plan := range listResp.Plans {
+		if !plan.Expired(now) {
+			continue
+		}
+
+		_, err := p.state.JobRegister().Plan().Delete(&store.JobRegisterPlanDeleteReq{ID: plan.ID})
+		if err != nil {
+			p.logger.Error("failed to delete expired job register plan",
+				zap.String("plan_id", plan.ID.String()), zap.Error(err.Err()))
+			continue
+		}
+
+		plansReapedTotal.Inc()
+
+		p.logger.Info("deleted expired job register plan",
+			zap.String("plan_id", plan.ID.String()),
+			zap.Time("expire_time", plan.ExpireTime),
+		)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+func TestPlanReaper(t *testing.T) {
+
+	state, err := mem.New()
+	must.NoError(t, err)
+
+	createPlan := func(expireTime time.Time) ulid.ULID {
+		plan := mock.JobRegistrationPlan()
+		plan.ExpireTime = expireTime
+
+		_, stateErr := state.JobRegister().Plan().Create(&store.JobRegisterPlanCreateReq{Plan: plan})
+		must.Nil(t, stateErr)
+		return plan.ID
+	}
+
+	planIDs := func() []ulid.ULID {
+		listResp, stateErr := state.JobRegister().Plan().List(nil)
+		must.Nil(t, stateErr)
+
+		ids := make([]ulid.ULID, len(listResp.Plans))
+		for i, plan := range listResp.Plans {
+			ids[i] = plan.ID
+		}
+		return ids
+	}
+
+	// Plans without an expiry time, or one in the future, must survive each
+	// pass of the reaper.
+	noExpiryID := createPlan(time.Time{})
+	futureID := createPlan(time.Now().Add(time.Hour))
+	expiredID := createPlan(time.Now().Add(-time.Minute))
+
+	reaper := newPlanReaper(zap.NewNop(), state, 10*time.Millisecond)
+
+	// Starting a running reaper has no effect, and stopping it must be safe
+	// to call more than once.
+	reaper.start()
+	reaper.start()
+	t.Cleanup(reaper.stop)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(planIDs()) == 2 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.SliceContainsAll(t, []ulid.ULID{noExpiryID, futureID}, planIDs())
+	must.SliceNotContains(t, planIDs(), expiredID)
+
+	// Once stopped, the reaper must not delete plans which expire.
+	reaper.stop()
+	reaper.stop()
+
+	stoppedID := createPlan(time.Now().Add(-time.Minute))
+
+	time.Sleep(50 * time.Millisecond)
+	must.SliceContains(t, planIDs(), stoppedID)
+
+	// Restarting the reaper resumes deleting expired plans.
+	reaper.start()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(planIDs()) == 2 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.SliceNotContains(t, planIDs(), stoppedID)
+}
@@ -0,0 +1,141 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"go.uber.org/zap"
+
+	serverHTTP "github.com/rasorp/attila/internal/server/http"
+)
+
+// Reload applies the passed configuration to the running server. Log levels
+// and formats, the access log level, HTTP binds, and TLS certificates are
+// changed in place. Settings which can only be applied by restarting the
+// server are logged, so operators know the change has not taken effect.
+func (s *Server) Reload(cfg *Config) {
+
+	s.serverLogger.Info("reloading server configuration")
+
+	if err := cfg.Validate(); err != nil {
+		s.serverLogger.Error("failed to validate reloaded config", zap.Error(err))
+		return
+	}
+
+	if err := s.logReloader.Reload(cfg.Log); err != nil {
+		s.serverLogger.Error("failed to reload log config", zap.Error(err))
+	}
+
+	if level, err := serverHTTP.ParseAccessLogLevel(cfg.HTTP.AccessLogLevel); err != nil {
+		s.serverLogger.Error("failed to reload HTTP access log level", zap.Error(err))
+	} else {
+		s.accessLevel.SetLevel(level)
+	}
+
+	s.reloadBinds(cfg.HTTP)
+
+	if err := s.forwardTransport.Reload(cfg.HTTP.ForwardTLS()); err != nil {
+		s.serverLogger.Error("failed to reload HTTP forward TLS certificates", zap.Error(err))
+	}
+	s.logRestartRequired(cfg)
+
+	s.cfg = cfg
+	s.serverLogger.Info("successfully reloaded server configuration")
+}
+
+// reloadBinds stops the HTTP servers for binds which have been removed and
+// starts those which have been added. Binds which remain have their TLS
+// certificates reloaded, so they can be rotated.
+func (s *Server) reloadBinds(httpCfg *HTTPConfig) {
+
+	s.srvsLock.Lock()
+	defer s.srvsLock.Unlock()
+
+	wanted := make(map[string]*BindConfig, len(httpCfg.Binds))
+	for _, bind := range httpCfg.Binds {
+		wanted[bind.Addr] = bind
+	}
+
+	srvs := make([]*httpServer, 0, len(httpCfg.Binds))
+	existing := make(map[string]struct{}, len(s.srvs))
+
+	for _, srv := range s.srvs {
+		bind, ok := wanted[srv.addr]
+		if !ok {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			srv.stop(ctx)
+			cancel()
+			continue
+		}
+
+		if srv.tls != nil {
+			if err := srv.tls.Reload(httpCfg.BindTLS(bind)); err != nil {
+				srv.logger.Error("failed to reload HTTP TLS certificates", zap.Error(err))
+			} else {
+				srv.logger.Info("successfully reloaded HTTP TLS certificates")
+			}
+		}
+
+		existing[srv.addr] = struct{}{}
+		srvs = append(srvs, srv)
+	}
+
+	for _, bind := range httpCfg.Binds {
+		if _, ok := existing[bind.Addr]; ok {
+			continue
+		}
+
+		srv, err := s.newHTTPServer(bind, httpCfg.BindTLS(bind))
+		if err != nil {
+			s.serverLogger.Error("failed to setup HTTP server",
+				zap.String("address", bind.Addr), zap.Error(err))
+			continue
+		}
+
+		srv.start()
+		srvs = append(srvs, srv)
+	}
+
+	s.srvs = srvs
+}
+
+// logRestartRequired logs each changed setting which cannot be applied while
+// the server is running.
+func (s *Server) logRestartRequired(cfg *Config) {
+
+	var settings []string
+
+	if !reflect.DeepEqual(s.cfg.State, cfg.State) {
+		settings = append(settings, "state")
+	}
+	if s.cfg.HTTP.RequestTimeout != cfg.HTTP.RequestTimeout {
+		settings = append(settings, "http.request_timeout")
+	}
+	if s.cfg.ACL.Enabled() != cfg.ACL.Enabled() {
+		settings = append(settings, "acl.enabled")
+	}
+	if *s.cfg.Log.IncludeLine != *cfg.Log.IncludeLine {
+		settings = append(settings, "log.include_line")
+	}
+	if !reflect.DeepEqual(s.cfg.Tracing, cfg.Tracing) {
+		settings = append(settings, "tracing")
+	}
+	if !reflect.DeepEqual(s.cfg.FanOut, cfg.FanOut) {
+		settings = append(settings, "fan_out")
+	}
+	if !reflect.DeepEqual(s.cfg.Plan, cfg.Plan) {
+		settings = append(settings, "plan")
+	}
+	if !reflect.DeepEqual(s.cfg.Drift, cfg.Drift) {
+		settings = append(settings, "drift")
+	}
+
+	for _, setting := range settings {
+		s.serverLogger.Warn("config setting changed but requires a restart to apply",
+			zap.String("setting", setting))
+	}
+}
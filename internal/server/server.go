@@ -11,110 +11,226 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/rasorp/attila/internal/event"
 	"github.com/rasorp/attila/internal/logger"
 	nomadControler "github.com/rasorp/attila/internal/nomad"
 	serverHTTP "github.com/rasorp/attila/internal/server/http"
 	"github.com/rasorp/attila/internal/server/nomad"
 	"github.com/rasorp/attila/internal/store"
 	storebackend "github.com/rasorp/attila/internal/store/backend"
+	"github.com/rasorp/attila/internal/tracing"
 )
 
 type Server struct {
 	baseLogger   *zap.Logger
 	serverLogger *zap.Logger
-	srvs         []*httpServer
 	state        store.State
 
+	// cfg is the currently applied configuration, which is replaced when the
+	// server reloads.
+	cfg *Config
+
+	// logReloader and accessLevel allow the log and access log configuration
+	// to be changed while the server is running.
+	logReloader *logger.Reloader
+	accessLevel zap.AtomicLevel
+
+	// srvs is the HTTP server for each bind. Binds can be added and removed
+	// when the server reloads, so access must be performed while holding
+	// srvsLock.
+	srvs     []*httpServer
+	srvsLock sync.Mutex
+
+	// eventBroker publishes state and registration changes to subscribers of
+	// the event stream.
+	eventBroker *event.Broker
+
 	// nomadController
 	nomadController nomad.Controller
+
+	// planReaper deletes expired job registration plans. It runs alongside
+	// the Nomad controllers.
+	planReaper *planReaper
+
+	// driftReconciler checks the regions of successful job registration runs
+	// for drift. It runs alongside the Nomad controllers when enabled.
+	driftReconciler *driftReconciler
+
+	// tracingShutdown flushes any buffered spans and stops the tracer
+	// provider.
+	tracingShutdown func(context.Context) error
+
+	// forwardTransport is used by every HTTP server to forward requests to the
+	// leader of a replicated state backend.
+	forwardTransport *forwardTransport
 }
 
 type httpServer struct {
+	addr   string
 	logger *zap.Logger
 	ln     net.Listener
 	mux    *chi.Mux
 	server *http.Server
+
+	// tls is set when the bind uses HTTPS and allows the certificates to be
+	// reloaded.
+	tls *tlsReloader
 }
 
 func NewServer(cfg *Config) (*Server, error) {
 
-	baseLogger, err := logger.New(cfg.Log)
+	baseLogger, logReloader, err := logger.NewReloadable(cfg.Log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup logger: %w", err)
 	}
 
-	backend, err := storebackend.NewBackend(cfg.State)
+	accessLevel, err := serverHTTP.ParseAccessLogLevel(cfg.HTTP.AccessLogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup access logger: %w", err)
+	}
+
+	tracingShutdown, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup tracing: %w", err)
+	}
+
+	backend, err := storebackend.NewBackend(baseLogger, cfg.State)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup state: %w", err)
 	}
 
+	eventBroker := event.NewBroker(baseLogger)
+
+	// Wrap the state and controller, so that writes and job registration runs
+	// are published to event stream subscribers.
 	server := Server{
-		baseLogger:      baseLogger,
-		serverLogger:    baseLogger.Named("server"),
-		state:           backend,
-		nomadController: nomadControler.NewController(baseLogger),
+		cfg:          cfg,
+		baseLogger:   baseLogger,
+		logReloader:  logReloader,
+		serverLogger: baseLogger.Named("server"),
+		accessLevel:  zap.NewAtomicLevelAt(accessLevel),
+		state:        event.NewState(backend, eventBroker),
+		eventBroker:  eventBroker,
+		nomadController: event.NewController(
+			nomadControler.NewController(baseLogger, cfg.FanOut, cfg.Plan), eventBroker),
+		tracingShutdown: tracingShutdown,
 	}
 
+	server.forwardTransport, err = newForwardTransport(cfg.HTTP.ForwardTLS())
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup HTTP forward TLS: %w", err)
+	}
+
+	server.planReaper = newPlanReaper(baseLogger, server.state, cfg.Plan.ReapIntervalDuration())
+	server.driftReconciler = newDriftReconciler(
+		baseLogger, server.nomadController, server.state, cfg.Drift.IntervalDuration())
+
 	server.serverLogger.Info("successfully setup state backend")
 
-	if err := server.restore(); err != nil {
+	server.registerStateMetrics()
+
+	// A replicated backend means only the leader runs the Nomad controllers,
+	// so restoration happens each time this server gains leadership.
+	// Otherwise, this server is the only one and can restore immediately.
+	if replicated, ok := backend.(store.Replicated); ok {
+		go server.monitorLeadership(replicated)
+	} else if err := server.restore(); err != nil {
 		return nil, fmt.Errorf("failed to perform server restore: %w", err)
 	}
 
 	for _, bind := range cfg.HTTP.Binds {
+		srv, err := server.newHTTPServer(bind, cfg.HTTP.BindTLS(bind))
+		if err != nil {
+			return nil, err
+		}
+		server.srvs = append(server.srvs, srv)
+	}
 
-		serverLogger := server.serverLogger.With(
-			zap.String("address", bind.Addr),
-		)
+	return &server, nil
+}
 
-		srv := httpServer{
-			logger: serverLogger,
-			mux:    serverHTTP.NewRouter(serverLogger, cfg.HTTP.AccessLogLevel, backend, server.nomadController),
-		}
+// newHTTPServer sets up the HTTP server and listener for the passed bind. The
+// tlsCfg is only used when the bind address has the https scheme.
+func (s *Server) newHTTPServer(bind *BindConfig, tlsCfg *TLSConfig) (*httpServer, error) {
+
+	serverLogger := s.serverLogger.With(
+		zap.String("address", bind.Addr),
+	)
+
+	srv := httpServer{
+		addr:   bind.Addr,
+		logger: serverLogger,
+		mux: serverHTTP.NewRouter(
+			serverLogger,
+			s.accessLevel,
+			s.state,
+			s.nomadController,
+			s.eventBroker,
+			s.cfg.ACL.Enabled(),
+			s.cfg.HTTP.RequestTimeoutDuration(),
+			s.forwardTransport,
+		),
+	}
 
-		// Configure the HTTP server to the most basic level.
-		srv.server = &http.Server{
-			Addr:         bind.Addr,
-			Handler:      srv.mux,
-			ReadTimeout:  5 * time.Second,
-			WriteTimeout: 15 * time.Second,
-			IdleTimeout:  15 * time.Second,
-		}
+	// Configure the HTTP server to the most basic level.
+	srv.server = &http.Server{
+		Addr:         bind.Addr,
+		Handler:      srv.mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  15 * time.Second,
+	}
 
-		parsedURL, err := url.Parse(srv.server.Addr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse bind address: %w", err)
-		}
+	parsedURL, err := url.Parse(srv.server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bind address: %w", err)
+	}
 
-		network := "tcp"
-		listenAddr := parsedURL.Host
-		if parsedURL.Scheme == "unix" {
-			network = parsedURL.Scheme
-		}
+	network := "tcp"
+	listenAddr := parsedURL.Host
+	if parsedURL.Scheme == "unix" {
+		network = parsedURL.Scheme
+	}
 
-		ln, err := net.Listen(network, listenAddr)
+	if parsedURL.Scheme == "https" {
+		reloader, err := newTLSReloader(tlsCfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to setup HTTP listener: %w", err)
+			return nil, fmt.Errorf("failed to setup HTTP TLS: %w", err)
 		}
-		srv.ln = ln
+		srv.tls = reloader
+		srv.server.TLSConfig = reloader.TLSConfig()
+	}
 
-		server.srvs = append(server.srvs, &srv)
-		serverLogger.Info("successfully setup HTTP server")
+	ln, err := net.Listen(network, listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup HTTP listener: %w", err)
 	}
+	srv.ln = ln
 
-	return &server, nil
+	serverLogger.Info("successfully setup HTTP server")
+	return &srv, nil
 }
 
 // restore handles restoration of Attila systems once the state backend has been
-// set up and is accessible.
+// set up and is accessible. It can be called repeatedly, as setting a region
+// which the controller already tracks replaces its client.
 func (s *Server) restore() error {
 
+	// Expired plans only need deleting by the server running the
+	// controllers, as deletes are replicated.
+	s.planReaper.start()
+
+	if s.cfg.Drift.IsEnabled() {
+		s.driftReconciler.start()
+	}
+
 	// List all the regions within our state, so we can restore the API clients.
 	regionList, err := s.state.Region().List(nil)
 	if err != nil {
@@ -144,35 +260,127 @@ func (s *Server) restore() error {
 	return nil
 }
 
+// restoreRetryInterval is the time waited before retrying a failed restore
+// after this server gains leadership of the replicated state backend.
+var restoreRetryInterval = 5 * time.Second
+
+// monitorLeadership starts and stops the Nomad controllers as this server
+// gains and loses leadership of the replicated state backend. A failed restore
+// is retried until it succeeds or leadership is lost. The function returns
+// once the backend is shutdown.
+func (s *Server) monitorLeadership(replicated store.Replicated) {
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case isLeader, ok := <-replicated.LeaderCh():
+			if !ok {
+				return
+			}
+
+			retryCh = nil
+
+			if !isLeader {
+				s.serverLogger.Info("lost state leadership, stopping controllers")
+				s.revoke()
+				continue
+			}
+			s.serverLogger.Info("gained state leadership, starting controllers")
+		case <-retryCh:
+			retryCh = nil
+		}
+
+		if err := s.restore(); err != nil {
+			s.serverLogger.Error("failed to perform server restore",
+				zap.Duration("retry_interval", restoreRetryInterval), zap.Error(err))
+			retryCh = time.After(restoreRetryInterval)
+		}
+	}
+}
+
+// revoke stops the region topology collectors, the plan reaper, and the drift
+// reconciler. It is called when the server loses leadership, as only the
+// leader should perform topology collection, delete expired plans, and check
+// for drift. The region clients are kept, so a failed restore on regaining
+// leadership does not leave the controller without them.
+func (s *Server) revoke() {
+	s.planReaper.stop()
+	s.driftReconciler.stop()
+	s.nomadController.StopCollectors()
+}
+
 // Start is used to serve the HTTP server. The function will block and should be
 // run via a go-routine. Unless http.Server.Serve panics/fails, the server can
 // be stopped by calling the Stop function.
 func (s *Server) Start() {
+	s.srvsLock.Lock()
+	defer s.srvsLock.Unlock()
+
 	for _, srv := range s.srvs {
-		srv.logger.Info("server now listening for connections")
-		go func() {
-			_ = srv.server.Serve(srv.ln)
-		}()
+		srv.start()
 	}
 }
 
+func (h *httpServer) start() {
+	h.logger.Info("server now listening for connections")
+	go func() {
+		if h.tls != nil {
+			_ = h.server.ServeTLS(h.ln, "", "")
+		} else {
+			_ = h.server.Serve(h.ln)
+		}
+	}()
+}
+
+// stop gracefully shuts down the HTTP server, waiting for in-flight requests
+// until the context is cancelled.
+func (h *httpServer) stop(ctx context.Context) {
+	if err := h.server.Shutdown(ctx); err != nil {
+		h.logger.Error("failed to gracefully shutdown HTTP server", zap.Error(err))
+	} else {
+		h.logger.Info("successfully shutdown HTTP server")
+	}
+
+	_ = h.ln.Close()
+}
+
 func (s *Server) Stop() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Close the event subscriptions first, otherwise the open streams would
+	// prevent the HTTP servers from gracefully shutting down.
+	s.eventBroker.Shutdown()
+	s.planReaper.stop()
+	s.driftReconciler.stop()
+
+	s.srvsLock.Lock()
 	for _, srv := range s.srvs {
-		if err := srv.server.Shutdown(ctx); err != nil {
-			srv.logger.Error("failed to gracefully shutdown HTTP server", zap.Error(err))
-		} else {
-			srv.logger.Info("successfully shutdown HTTP server")
+		srv.stop(ctx)
+	}
+	s.srvsLock.Unlock()
+
+	if replicated, ok := s.state.(store.Replicated); ok {
+		if err := replicated.Shutdown(); err != nil {
+			s.serverLogger.Error("failed to shutdown state backend", zap.Error(err))
 		}
+	}
 
-		_ = srv.ln.Close()
+	if err := s.tracingShutdown(ctx); err != nil {
+		s.serverLogger.Error("failed to shutdown tracing", zap.Error(err))
 	}
 }
 
-func (s *Server) WaitForSignals() {
+// ConfigLoader returns the latest server configuration. It is called when the
+// server receives a SIGHUP, so should re-read any config files.
+type ConfigLoader func() (*Config, error)
+
+// WaitForSignals blocks until the server receives a signal which causes it to
+// stop. A SIGHUP reloads the server using the configuration returned by the
+// loader.
+func (s *Server) WaitForSignals(loader ConfigLoader) {
 
 	signalCh := make(chan os.Signal, 3)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
@@ -184,11 +392,17 @@ func (s *Server) WaitForSignals() {
 		sig := <-signalCh
 		s.serverLogger.Info("received signal", zap.String("signal", sig.String()))
 
-		// Check the signal we received. If it was a SIGHUP when the
-		// functionality is added, we perform the reload tasks and then
-		// continue to wait for another signal. Everything else means exit.
+		// Check the signal we received. If it was a SIGHUP, we perform the
+		// reload tasks and then continue to wait for another signal.
+		// Everything else means exit.
 		switch sig {
 		case syscall.SIGHUP:
+			cfg, err := loader()
+			if err != nil {
+				s.serverLogger.Error("failed to load config for reload", zap.Error(err))
+				continue
+			}
+			s.Reload(cfg)
 		default:
 			s.Stop()
 			return
@@ -4,13 +4,35 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	nomadapi "github.com/hashicorp/nomad/api"
+	"github.com/oklog/ulid/v2"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
 
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/certs"
 	"github.com/rasorp/attila/internal/helper/test/mock"
 	"github.com/rasorp/attila/internal/store"
 	storebackend "github.com/rasorp/attila/internal/store/backend"
+	"github.com/rasorp/attila/internal/tracing"
+	"github.com/rasorp/attila/pkg/api"
 )
 
 func TestServer_restore(t *testing.T) {
@@ -44,3 +66,797 @@ func TestServer_restore(t *testing.T) {
 
 	restoreServer.Stop()
 }
+
+// freeAddr returns a loopback address with a port which is not in use.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	must.NoError(t, ln.Close())
+	return ln.Addr().String()
+}
+
+func TestServer_monitorLeadership(t *testing.T) {
+
+	// Run a fake Nomad API, so the topology of the region can be collected.
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+
+		switch r.URL.Path {
+		case "/v1/agent/members":
+			resp = nomadapi.ServerMembers{}
+		case "/v1/nodes":
+			resp = []*nomadapi.NodeListStub{}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	ca := certs.NewCA(t)
+
+	peers := make([]*storebackend.RaftPeerConfig, 3)
+	for i := range peers {
+		id := fmt.Sprintf("attila-%v", i)
+		peers[i] = &storebackend.RaftPeerConfig{ID: id, Address: freeAddr(t), HTTPAddress: "http://" + id}
+	}
+
+	servers := make([]*Server, len(peers))
+
+	for i, peer := range peers {
+		certFile, keyFile := ca.Issue(t, peer.ID)
+
+		cfg := DefaultConfig()
+		cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+		cfg.State.Raft = &storebackend.RaftConfig{
+			Enable:           new(true),
+			NodeID:           peer.ID,
+			BindAddr:         peer.Address,
+			DataDir:          t.TempDir(),
+			HeartbeatTimeout: "100ms",
+			ElectionTimeout:  "100ms",
+			TLS:              &storebackend.RaftTLSConfig{CAFile: ca.CertFile, CertFile: certFile, KeyFile: keyFile},
+			Keyring: &storebackend.KeyringConfig{
+				ActiveKey: "k1",
+				Keys: []*storebackend.KeyringKeyConfig{
+					{ID: "k1", Secret: "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="},
+				},
+			},
+			Peers: peers,
+		}
+
+		srv, err := NewServer(cfg)
+		must.NoError(t, err)
+
+		servers[i] = srv
+	}
+
+	// Stop any server which the test has not already stopped.
+	stopped := make(map[*Server]bool)
+	t.Cleanup(func() {
+		for _, srv := range servers {
+			if !stopped[srv] {
+				srv.Stop()
+			}
+		}
+	})
+
+	var leader *Server
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			for _, srv := range servers {
+				if srv.state.(store.Replicated).IsLeader() {
+					leader = srv
+					return true
+				}
+			}
+			return false
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	mockRegion := mock.Region()
+	mockRegion.API = []*domain.RegionAPI{{Address: nomadServer.URL, Default: true}}
+	mockRegion.TLS = nil
+
+	_, stateErr := leader.state.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, stateErr)
+
+	// The region endpoint sets the region within the controller once it is
+	// written to state, which starts the collection of its topology.
+	nomadClient, err := mockRegion.GenerateNomadClient()
+	must.NoError(t, err)
+	leader.nomadController.RegionSet(mockRegion.Name, nomadClient)
+
+	waitForTopology := func(expected bool) {
+		t.Helper()
+		must.Wait(t, wait.InitialSuccess(
+			wait.BoolFunc(func() bool {
+				return (leader.nomadController.GetTopology(mockRegion.Name) != nil) == expected
+			}),
+			wait.Timeout(10*time.Second),
+			wait.Gap(10*time.Millisecond),
+		))
+	}
+
+	waitForTopology(true)
+
+	// Stopping the followers means the leader loses quorum and steps down,
+	// which stops the topology collection, while the region client is kept.
+	for _, srv := range servers {
+		if srv != leader {
+			srv.Stop()
+			stopped[srv] = true
+		}
+	}
+
+	waitForTopology(false)
+	must.Eq(t, 1, leader.nomadController.RegionNum())
+
+	// Restoring can be performed repeatedly, and starts the collection of the
+	// regions within state again.
+	must.NoError(t, leader.restore())
+	must.NoError(t, leader.restore())
+
+	waitForTopology(true)
+	must.Eq(t, 1, leader.nomadController.RegionNum())
+}
+
+func TestServer_Reload(t *testing.T) {
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	srv.Start()
+	t.Cleanup(srv.Stop)
+
+	// Replace the bind and change the log levels, which should all be applied
+	// without restarting the server.
+	reloadCfg := DefaultConfig()
+	reloadCfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	reloadCfg.Log.Level = "debug"
+	reloadCfg.HTTP.AccessLogLevel = "debug"
+	reloadCfg.HTTP.Binds = []*BindConfig{{Addr: "http://localhost:0"}}
+
+	srv.Reload(reloadCfg)
+
+	must.Len(t, 1, srv.srvs)
+	must.Eq(t, reloadCfg.HTTP.Binds[0].Addr, srv.srvs[0].addr)
+	must.Eq(t, zapcore.DebugLevel, srv.accessLevel.Level())
+	must.True(t, srv.baseLogger.Core().Enabled(zapcore.DebugLevel))
+	must.Eq(t, reloadCfg, srv.cfg)
+
+	// An invalid config should not be applied.
+	invalidCfg := DefaultConfig()
+	invalidCfg.HTTP.AccessLogLevel = "invalid"
+
+	srv.Reload(invalidCfg)
+	must.Eq(t, reloadCfg, srv.cfg)
+}
+
+func TestServer_tracing(t *testing.T) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	// Perform a request within a client span, which should be propagated to
+	// the server, so its span joins the client trace.
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+
+	ctx, clientSpan := tracing.Start(context.Background(), "client")
+	_, _, err = client.Regions().List(ctx)
+	must.NoError(t, err)
+	clientSpan.End()
+
+	spans := exporter.GetSpans()
+	must.Len(t, 2, spans)
+
+	must.Eq(t, "GET /v1alpha1/regions", spans[0].Name)
+	must.Eq(t, trace.SpanKindServer, spans[0].SpanKind)
+	must.Eq(t, clientSpan.SpanContext().TraceID(), spans[0].SpanContext.TraceID())
+	must.Eq(t, clientSpan.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	must.True(t, spans[0].Parent.IsRemote())
+}
+
+func TestServer_requestTimeout(t *testing.T) {
+
+	// Run a fake Nomad API which only responds once the request is cancelled,
+	// so we can check the server cancels outstanding calls.
+	cancelled := make(chan struct{}, 1)
+
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/job/example/plan" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// The request body must be read, otherwise the server does not notice
+		// the client closing the connection.
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+		cancelled <- struct{}{}
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+	cfg.HTTP.RequestTimeout = "100ms"
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	// Create a method and rule which match every job, and a region which uses
+	// the fake Nomad API.
+	rule := mock.JobRegistrationRule()
+	rule.RegionContexts = nil
+	rule.RegionPickers = nil
+
+	method := mock.JobRegistrationMethod()
+	method.Selectors[0].ProviderConfig = map[string]any{"expression": "true"}
+	method.Rules = []*domain.JobRegisterMethodRuleLink{{Name: rule.Name}}
+
+	region := mock.Region()
+
+	_, stateErr := srv.state.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: rule})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.JobRegister().Method().Create(&store.JobRegisterMethodCreateReq{Method: method})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.Region().Create(&store.RegionCreateReq{Region: region})
+	must.Nil(t, stateErr)
+
+	nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: nomadServer.URL})
+	must.NoError(t, err)
+	srv.nomadController.RegionSet(region.Name, nomadClient)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+
+	_, _, err = client.JobRegisterPlans().Create(context.Background(), &api.JobRegisterPlanCreateReq{
+		Job: &nomadapi.Job{ID: new("example"), Namespace: new("default")},
+	})
+
+	var respErr *api.ResponseError
+	must.True(t, errors.As(err, &respErr))
+	must.Eq(t, http.StatusGatewayTimeout, respErr.StatusCode())
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Nomad plan request was not cancelled")
+	}
+}
+
+func TestServer_waitForHealthy(t *testing.T) {
+
+	// Run a fake Nomad API where the registration creates an evaluation which
+	// is linked to a successful deployment. The registration request is kept,
+	// so the job source can be checked.
+	registerReqCh := make(chan *nomadapi.JobRegisterRequest, 1)
+
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+
+		switch r.URL.Path {
+		case "/v1/job/example/plan":
+			_, _ = io.Copy(io.Discard, r.Body)
+			resp = nomadapi.JobPlanResponse{}
+		case "/v1/jobs":
+			var registerReq nomadapi.JobRegisterRequest
+			_ = json.NewDecoder(r.Body).Decode(&registerReq)
+			registerReqCh <- &registerReq
+			resp = nomadapi.JobRegisterResponse{EvalID: "eval-1"}
+		case "/v1/evaluation/eval-1":
+			resp = nomadapi.Evaluation{ID: "eval-1", Status: nomadapi.EvalStatusComplete, DeploymentID: "deploy-1"}
+		case "/v1/deployment/deploy-1":
+			resp = nomadapi.Deployment{ID: "deploy-1", Status: nomadapi.DeploymentStatusSuccessful}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	// Create a method and rule which match every job and wait for healthy
+	// deployments, and a region which uses the fake Nomad API.
+	rule := mock.JobRegistrationRule()
+	rule.RegionContexts = nil
+	rule.RegionPickers = nil
+	rule.Rollout = &domain.JobRegisterRollout{WaitForHealthy: true}
+
+	method := mock.JobRegistrationMethod()
+	method.Selectors[0].ProviderConfig = map[string]any{"expression": "true"}
+	method.Rules = []*domain.JobRegisterMethodRuleLink{{Name: rule.Name}}
+
+	region := mock.Region()
+
+	_, stateErr := srv.state.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: rule})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.JobRegister().Method().Create(&store.JobRegisterMethodCreateReq{Method: method})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.Region().Create(&store.RegionCreateReq{Region: region})
+	must.Nil(t, stateErr)
+
+	nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: nomadServer.URL})
+	must.NoError(t, err)
+	srv.nomadController.RegionSet(region.Name, nomadClient)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+	job := &nomadapi.Job{ID: new("example"), Namespace: new("default")}
+
+	submission := &nomadapi.JobSubmission{Source: `job "example" {}`, Format: "hcl2"}
+
+	planResp, _, err := client.JobRegisterPlans().Create(context.Background(), &api.JobRegisterPlanCreateReq{
+		Job:        job,
+		Submission: submission,
+	})
+	must.NoError(t, err)
+	must.Eq(t, job, planResp.Plan.Job)
+	must.Eq(t, submission, planResp.Plan.Submission)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msgCh, err := client.Events().Stream(ctx, &api.EventStreamReq{
+		Topics: map[string][]string{api.EventTopicJobRegisterRun: {planResp.Plan.ID.String()}},
+	})
+	must.NoError(t, err)
+
+	// Run the plan without a job, so the job stored with the plan is
+	// registered along with its source.
+	runResp, _, err := client.JobRegisterPlans().Run(context.Background(), &api.JobsRegisterPlanRunReq{
+		ID: planResp.Plan.ID,
+	})
+	must.NoError(t, err)
+
+	registerReq := <-registerReqCh
+	must.Eq(t, "example", *registerReq.Job.ID)
+	must.Eq(t, submission.Source, registerReq.Submission.Source)
+
+	regionRun := runResp.Run.Regions[region.Name]
+	must.NotNil(t, regionRun)
+	must.Eq(t, &api.JobRegisterRegionHealth{
+		Status:       api.JobRegisterHealthStatusHealthy,
+		DeploymentID: "deploy-1",
+	}, regionRun.Health)
+
+	// The progress of the region is published before the run finishes.
+	var progress []string
+
+	for msg := range msgCh {
+		must.NoError(t, msg.Err)
+
+		if msg.Event.Type == api.EventTypeFinished {
+			break
+		}
+
+		var regionProgress api.JobRegisterRegionProgress
+		must.NoError(t, msg.Event.DecodePayload(&regionProgress))
+		must.Eq(t, region.Name, regionProgress.Region)
+		progress = append(progress, regionProgress.Phase+" "+regionProgress.Status)
+	}
+
+	must.Eq(t, []string{"register registered", "health waiting", "health healthy"}, progress)
+
+	// The run is recorded, so it can be read once the plan has been deleted.
+	listResp, _, err := client.JobRegisterRuns().List(context.Background(), &api.JobRegisterRunListReq{
+		JobID:     "example",
+		Namespace: "default",
+	})
+	must.NoError(t, err)
+	must.Len(t, 1, listResp.Runs)
+	must.Eq(t, runResp.Run.ID, listResp.Runs[0].ID)
+	must.Eq(t, planResp.Plan.ID, listResp.Runs[0].PlanID)
+	must.Eq(t, regionRun.Health, listResp.Runs[0].Regions[region.Name].Health)
+}
+
+func TestServer_planStaleness(t *testing.T) {
+
+	// Run a fake Nomad API which only supports planning, as stale plans must
+	// be rejected before any region is registered.
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		if r.URL.Path != "/v1/job/example/plan" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(nomadapi.JobPlanResponse{Diff: &nomadapi.JobDiff{Type: "None"}})
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	rule := mock.JobRegistrationRule()
+	rule.RegionContexts = nil
+	rule.RegionPickers = nil
+
+	method := mock.JobRegistrationMethod()
+	method.Selectors[0].ProviderConfig = map[string]any{"expression": "true"}
+	method.Rules = []*domain.JobRegisterMethodRuleLink{{Name: rule.Name}}
+
+	region := mock.Region()
+
+	_, stateErr := srv.state.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: rule})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.JobRegister().Method().Create(&store.JobRegisterMethodCreateReq{Method: method})
+	must.Nil(t, stateErr)
+	_, stateErr = srv.state.Region().Create(&store.RegionCreateReq{Region: region})
+	must.Nil(t, stateErr)
+
+	nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: nomadServer.URL})
+	must.NoError(t, err)
+	srv.nomadController.RegionSet(region.Name, nomadClient)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+	job := &nomadapi.Job{ID: new("example"), Namespace: new("default")}
+
+	planResp, _, err := client.JobRegisterPlans().Create(context.Background(), &api.JobRegisterPlanCreateReq{Job: job})
+	must.NoError(t, err)
+	must.NotEq(t, "", planResp.Plan.JobHash)
+	must.True(t, planResp.Plan.ExpireTime.After(planResp.Plan.Metadata.CreateTime))
+	must.True(t, planResp.Plan.Regions[region.Name].NoChange())
+
+	// Running the plan with a job which differs from the planned job is a bad
+	// request.
+	_, _, err = client.JobRegisterPlans().Run(context.Background(), &api.JobsRegisterPlanRunReq{
+		ID:  planResp.Plan.ID,
+		Job: &nomadapi.Job{ID: new("example"), Namespace: new("default"), Priority: new(70)},
+	})
+
+	var respErr *api.ResponseError
+	must.True(t, errors.As(err, &respErr))
+	must.Eq(t, http.StatusBadRequest, respErr.StatusCode())
+
+	// Write a plan of the same job which has expired, which must conflict
+	// when run.
+	expiredPlan := mock.JobRegistrationPlan()
+	expiredPlan.JobHash = planResp.Plan.JobHash
+	expiredPlan.ExpireTime = time.Now().Add(-time.Minute)
+
+	_, stateErr = srv.state.JobRegister().Plan().Create(&store.JobRegisterPlanCreateReq{Plan: expiredPlan})
+	must.Nil(t, stateErr)
+
+	_, _, err = client.JobRegisterPlans().Run(context.Background(), &api.JobsRegisterPlanRunReq{
+		ID:  expiredPlan.ID,
+		Job: job,
+	})
+	must.True(t, errors.As(err, &respErr))
+	must.Eq(t, http.StatusConflict, respErr.StatusCode())
+
+	// The reaper deletes the expired plan, but leaves the plan which has not
+	// expired.
+	srv.planReaper.reap(time.Now())
+
+	listResp, stateErr := srv.state.JobRegister().Plan().List(nil)
+	must.Nil(t, stateErr)
+	must.Len(t, 1, listResp.Plans)
+	must.Eq(t, planResp.Plan.ID, listResp.Plans[0].ID)
+}
+
+func TestServer_jobStop(t *testing.T) {
+
+	// Run a fake Nomad API which holds the job, and another which does not.
+	// The deregister requests are kept, so the purge flag can be checked.
+	deregisterReqCh := make(chan *http.Request, 1)
+
+	holdingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/job/example" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var resp any
+
+		switch r.Method {
+		case http.MethodGet:
+			resp = nomadapi.Job{
+				ID:        new("example"),
+				Namespace: new("default"),
+				Status:    new("running"),
+				Version:   new(uint64(3)),
+			}
+		case http.MethodDelete:
+			deregisterReqCh <- r
+			resp = nomadapi.JobDeregisterResponse{EvalID: "eval-1"}
+		}
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(holdingServer.Close)
+
+	emptyServer := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(emptyServer.Close)
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	holdingRegion := mock.Region()
+	emptyRegion := mock.Region()
+
+	for region, nomadServer := range map[*domain.Region]*httptest.Server{
+		holdingRegion: holdingServer,
+		emptyRegion:   emptyServer,
+	} {
+		_, stateErr := srv.state.Region().Create(&store.RegionCreateReq{Region: region})
+		must.Nil(t, stateErr)
+
+		nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: nomadServer.URL})
+		must.NoError(t, err)
+		srv.nomadController.RegionSet(region.Name, nomadClient)
+	}
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+
+	// Planning the stop finds the region holding the job, without stopping
+	// it.
+	planResp, _, err := client.Jobs().Stop(context.Background(), &api.JobStopReq{
+		Namespace: "default",
+		ID:        "example",
+		Purge:     true,
+		Plan:      true,
+	})
+	must.NoError(t, err)
+	must.True(t, planResp.Stop.Plan)
+	must.MapLen(t, 1, planResp.Stop.Regions)
+	must.Eq(t, &api.JobStopRegion{
+		Region:     holdingRegion.Name,
+		JobVersion: new(uint64(3)),
+		JobStatus:  "running",
+	}, planResp.Stop.Regions[holdingRegion.Name])
+	must.Zero(t, len(deregisterReqCh))
+
+	stopResp, _, err := client.Jobs().Stop(context.Background(), &api.JobStopReq{
+		Namespace: "default",
+		ID:        "example",
+		Purge:     true,
+	})
+	must.NoError(t, err)
+	must.False(t, stopResp.Stop.Plan)
+	must.MapLen(t, 1, stopResp.Stop.Regions)
+	must.Eq(t, "eval-1", stopResp.Stop.Regions[holdingRegion.Name].EvalID)
+
+	deregisterReq := <-deregisterReqCh
+	must.Eq(t, "true", deregisterReq.URL.Query().Get("purge"))
+	must.Eq(t, "default", deregisterReq.URL.Query().Get("namespace"))
+}
+
+func TestServer_jobStatus(t *testing.T) {
+
+	// newNomadServer runs a fake Nomad API which holds the job at the passed
+	// modify index, or does not hold the job if the index is zero.
+	newNomadServer := func(jobModifyIndex uint64) *httptest.Server {
+		nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if jobModifyIndex == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			var resp any
+
+			switch r.URL.Path {
+			case "/v1/job/example":
+				resp = nomadapi.Job{
+					ID:             new("example"),
+					Namespace:      new("default"),
+					Status:         new("running"),
+					Version:        new(uint64(2)),
+					JobModifyIndex: new(jobModifyIndex),
+				}
+			case "/v1/job/example/allocations":
+				resp = []*nomadapi.AllocationListStub{
+					{ClientStatus: nomadapi.AllocClientStatusRunning},
+					{ClientStatus: nomadapi.AllocClientStatusRunning},
+					{ClientStatus: nomadapi.AllocClientStatusFailed},
+				}
+			case "/v1/job/example/deployment":
+				resp = nomadapi.Deployment{ID: "deploy-1", Status: nomadapi.DeploymentStatusSuccessful}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("X-Nomad-Index", "1")
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+		t.Cleanup(nomadServer.Close)
+		return nomadServer
+	}
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	// The last run registered the job in "matching" and "missing". The job
+	// has since been removed from "missing", and registered directly into
+	// "modified".
+	for regionName, jobModifyIndex := range map[string]uint64{"matching": 10, "missing": 0, "modified": 30} {
+		nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: newNomadServer(jobModifyIndex).URL})
+		must.NoError(t, err)
+		srv.nomadController.RegionSet(regionName, nomadClient)
+	}
+
+	run := domain.NewJobRegisterPlanRun(ulid.Make(), "example", "default")
+	run.AddRegion("matching", &nomadapi.JobRegisterResponse{JobModifyIndex: 10}, nil)
+	run.AddRegion("missing", &nomadapi.JobRegisterResponse{JobModifyIndex: 11}, nil)
+
+	_, stateErr := srv.state.JobRegister().Run().Create(&store.JobRegisterRunCreateReq{Run: run})
+	must.Nil(t, stateErr)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+
+	statusResp, _, err := client.Jobs().Status(context.Background(), &api.JobStatusReq{
+		Namespace: "default",
+		ID:        "example",
+	})
+	must.NoError(t, err)
+	must.Eq(t, run.ID, *statusResp.Status.RunID)
+	must.MapLen(t, 3, statusResp.Status.Regions)
+
+	expectedRegion := func(regionName string, mismatch bool) *api.JobStatusRegion {
+		return &api.JobStatusRegion{
+			Region:           regionName,
+			JobStatus:        "running",
+			JobVersion:       new(uint64(2)),
+			Allocations:      map[string]int{"running": 2, "failed": 1},
+			DeploymentID:     "deploy-1",
+			DeploymentStatus: nomadapi.DeploymentStatusSuccessful,
+			Mismatch:         mismatch,
+		}
+	}
+
+	must.Eq(t, expectedRegion("matching", false), statusResp.Status.Regions["matching"])
+	must.Eq(t, expectedRegion("modified", true), statusResp.Status.Regions["modified"])
+	must.Eq(t, &api.JobStatusRegion{Region: "missing", Missing: true}, statusResp.Status.Regions["missing"])
+}
+
+func TestServer_jobDrift(t *testing.T) {
+
+	// Run a fake Nomad API which holds the job at the version set by the
+	// test, so the job can be changed outside of Attila.
+	var jobVersion atomic.Uint64
+
+	nomadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/job/example" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		version := jobVersion.Load()
+
+		w.Header().Set("X-Nomad-Index", "1")
+		_ = json.NewEncoder(w).Encode(nomadapi.Job{
+			ID:             new("example"),
+			Namespace:      new("default"),
+			Status:         new("running"),
+			Version:        new(version),
+			JobModifyIndex: new(10 + version),
+		})
+	}))
+	t.Cleanup(nomadServer.Close)
+
+	cfg := DefaultConfig()
+	cfg.State.Memory = &storebackend.MemoryConfig{Enable: new(true)}
+	cfg.HTTP.Binds = []*BindConfig{{Addr: "http://127.0.0.1:0"}}
+
+	srv, err := NewServer(cfg)
+	must.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	nomadClient, err := nomadapi.NewClient(&nomadapi.Config{Address: nomadServer.URL})
+	must.NoError(t, err)
+	srv.nomadController.RegionSet("euw1", nomadClient)
+
+	// The run registered the job in a region which holds it, and a region
+	// which no longer does.
+	run := domain.NewJobRegisterPlanRun(ulid.Make(), "example", "default")
+	run.AddRegion("euw1", &nomadapi.JobRegisterResponse{JobModifyIndex: 10}, nil)
+	run.AddRegion("euw2", &nomadapi.JobRegisterResponse{JobModifyIndex: 10}, nil)
+
+	_, stateErr := srv.state.JobRegister().Run().Create(&store.JobRegisterRunCreateReq{Run: run})
+	must.Nil(t, stateErr)
+
+	testServer := httptest.NewServer(srv.srvs[0].mux)
+	t.Cleanup(testServer.Close)
+
+	client := api.NewClient(&api.Config{Address: testServer.URL})
+
+	listDrift := func() []*api.JobDrift {
+		must.NoError(t, srv.nomadController.JobDriftReconcile(context.Background(), srv.state))
+
+		listResp, _, err := client.Jobs().Drift(context.Background(), &api.JobDriftListReq{JobID: "example"})
+		must.NoError(t, err)
+		must.Len(t, 2, listResp.Drifts)
+		return listResp.Drifts
+	}
+
+	// The job in "euw1" matches the run, so its version is recorded. There is
+	// no client for "euw2", so its status is unknown.
+	drifts := listDrift()
+	must.Eq(t, api.JobDriftStatusInSync, drifts[0].Status)
+	must.Eq(t, new(uint64(0)), drifts[0].JobVersion)
+	must.Eq(t, run.ID, drifts[0].RunID)
+	must.Eq(t, api.JobDriftStatusUnknown, drifts[1].Status)
+
+	// Changing the job outside of Attila marks the region as drifted, and it
+	// stays drifted once the job is changed back.
+	jobVersion.Store(1)
+
+	drifts = listDrift()
+	must.Eq(t, api.JobDriftStatusDrifted, drifts[0].Status)
+	must.Eq(t, "job version changed from 0 to 1", drifts[0].Reason)
+	must.False(t, drifts[0].DriftTime.IsZero())
+
+	jobVersion.Store(0)
+
+	drifts = listDrift()
+	must.Eq(t, api.JobDriftStatusDrifted, drifts[0].Status)
+}
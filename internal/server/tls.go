@@ -0,0 +1,146 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+)
+
+// tlsReloader holds the TLS configuration of an HTTPS bind. The configuration
+// is loaded from the files detailed within the config block and can be
+// reloaded, so certificates can be rotated without restarting the listener.
+type tlsReloader struct {
+	config atomic.Pointer[tls.Config]
+}
+
+func newTLSReloader(cfg *TLSConfig) (*tlsReloader, error) {
+	var t tlsReloader
+	if err := t.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Reload reads the certificate, key, and client CA files detailed within the
+// passed config. New connections use the reloaded configuration, while
+// existing connections are unaffected. If any file cannot be loaded, the
+// current configuration is kept.
+func (t *tlsReloader) Reload(cfg *TLSConfig) error {
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	config := tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if cfg.ClientCAFile != "" {
+		caBytes, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return errors.New("failed to parse client CA: no certificates found")
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	if cfg.VerifyClientEnabled() {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	t.config.Store(&config)
+	return nil
+}
+
+// TLSConfig returns the configuration for the HTTP server. Each connection
+// uses the latest loaded configuration.
+func (t *tlsReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return t.config.Load(), nil
+		},
+	}
+}
+
+// forwardTransport is the HTTP transport used to forward requests to the
+// leader of a replicated state backend. When HTTPS is configured, it presents
+// the server certificate, so the leader accepts the request when it verifies
+// clients. The client CA is trusted alongside the system roots, so a leader
+// with a certificate issued by a private CA can be verified. The transport is
+// rebuilt when the TLS configuration is reloaded.
+type forwardTransport struct {
+	transport atomic.Pointer[http.Transport]
+}
+
+func newForwardTransport(cfg *TLSConfig) (*forwardTransport, error) {
+	var f forwardTransport
+	if err := f.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Reload rebuilds the transport from the passed config, which may be nil if
+// HTTPS is not configured. If any file cannot be loaded, the current transport
+// is kept.
+func (f *forwardTransport) Reload(cfg *TLSConfig) error {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg != nil {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load certificate: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if cfg.ClientCAFile != "" {
+			caBytes, err := os.ReadFile(cfg.ClientCAFile)
+			if err != nil {
+				return fmt.Errorf("failed to read client CA: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(caBytes) {
+				return errors.New("failed to parse client CA: no certificates found")
+			}
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	if previous := f.transport.Swap(transport); previous != nil {
+		previous.CloseIdleConnections()
+	}
+	return nil
+}
+
+// RoundTrip implements the http.RoundTripper interface using the latest loaded
+// transport.
+func (f *forwardTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f.transport.Load().RoundTrip(r)
+}
@@ -0,0 +1,81 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/helper/test/certs"
+)
+
+func TestForwardTransport(t *testing.T) {
+	ca := certs.NewCA(t)
+
+	leaderCertFile, leaderKeyFile := ca.Issue(t, "leader")
+	leaderCert, err := tls.LoadX509KeyPair(leaderCertFile, leaderKeyFile)
+	must.NoError(t, err)
+
+	caBytes, err := os.ReadFile(ca.CertFile)
+	must.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	must.True(t, pool.AppendCertsFromPEM(caBytes))
+
+	// The leader uses a certificate from the private CA and verifies clients,
+	// as configured by verify_client.
+	leader := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	leader.TLS = &tls.Config{
+		Certificates: []tls.Certificate{leaderCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	leader.StartTLS()
+	t.Cleanup(leader.Close)
+
+	forward := func(transport http.RoundTripper) error {
+		req, err := http.NewRequest(http.MethodGet, leader.URL, nil)
+		must.NoError(t, err)
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		must.Eq(t, http.StatusOK, resp.StatusCode)
+		return nil
+	}
+
+	// Without TLS configuration, the leader certificate cannot be verified.
+	plainTransport, err := newForwardTransport(nil)
+	must.NoError(t, err)
+	must.Error(t, forward(plainTransport))
+
+	// The server certificate and client CA allow the leader to be verified and
+	// the request to be accepted.
+	certFile, keyFile := ca.Issue(t, "follower")
+	tlsCfg := TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ca.CertFile}
+
+	transport, err := newForwardTransport(&tlsCfg)
+	must.NoError(t, err)
+	must.NoError(t, forward(transport))
+
+	// A failed reload keeps the existing transport.
+	must.Error(t, transport.Reload(&TLSConfig{CertFile: "missing.pem", KeyFile: keyFile}))
+	must.NoError(t, forward(transport))
+
+	// Reloading with a certificate from another CA is rejected by the leader.
+	otherCertFile, otherKeyFile := certs.NewCA(t).Issue(t, "other")
+	must.NoError(t, transport.Reload(&TLSConfig{
+		CertFile: otherCertFile, KeyFile: otherKeyFile, ClientCAFile: ca.CertFile}))
+	must.Error(t, forward(transport))
+}
@@ -0,0 +1,157 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/rasorp/attila/internal/domain"
+)
+
+type ACLState interface {
+	Policy() ACLPolicyState
+	Token() ACLTokenState
+}
+
+type ACLPolicyState interface {
+	Create(*ACLPolicyCreateReq) (*ACLPolicyCreateResp, *ErrorResp)
+	Delete(*ACLPolicyDeleteReq) (*ACLPolicyDeleteResp, *ErrorResp)
+	Get(*ACLPolicyGetReq) (*ACLPolicyGetResp, *ErrorResp)
+	List(*ACLPolicyListReq) (*ACLPolicyListResp, *ErrorResp)
+	Update(*ACLPolicyUpdateReq) (*ACLPolicyUpdateResp, *ErrorResp)
+}
+
+type ACLPolicyCreateReq struct {
+	Policy *domain.ACLPolicy `json:"policy"`
+}
+
+type ACLPolicyCreateResp struct {
+	Policy *domain.ACLPolicy `json:"policy"`
+}
+
+type ACLPolicyDeleteReq struct {
+	Name string `json:"name"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
+}
+
+type ACLPolicyDeleteResp struct{}
+
+type ACLPolicyGetReq struct {
+	Name string `json:"name"`
+
+	QueryOptions `json:"-"`
+}
+
+type ACLPolicyGetResp struct {
+	Policy *domain.ACLPolicy `json:"policy"`
+
+	QueryMeta `json:"-"`
+}
+
+type ACLPolicyListReq struct {
+	QueryOptions `json:"-"`
+}
+
+type ACLPolicyListResp struct {
+	Policies []*domain.ACLPolicy `json:"policies"`
+
+	QueryMeta `json:"-"`
+}
+
+type ACLPolicyUpdateReq struct {
+	Policy *domain.ACLPolicy `json:"policy"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
+
+	// UpdateTime is an optional time to record as the update time of the
+	// object. When zero, the current time is used. Replicated backends set
+	// this before the write is replicated, so every server records the same
+	// value.
+	UpdateTime time.Time `json:"update_time"`
+}
+
+type ACLPolicyUpdateResp struct {
+	Policy *domain.ACLPolicy `json:"policy"`
+}
+
+type ACLTokenState interface {
+
+	// Bootstrap creates the initial management token. It must fail if any
+	// token already exists within state, so the ACL system can only be
+	// bootstrapped once.
+	Bootstrap(*ACLTokenBootstrapReq) (*ACLTokenBootstrapResp, *ErrorResp)
+
+	Create(*ACLTokenCreateReq) (*ACLTokenCreateResp, *ErrorResp)
+	Delete(*ACLTokenDeleteReq) (*ACLTokenDeleteResp, *ErrorResp)
+	Get(*ACLTokenGetReq) (*ACLTokenGetResp, *ErrorResp)
+
+	// GetBySecret looks up a token using its secret ID and is used to resolve
+	// the token sent with each API request.
+	GetBySecret(*ACLTokenGetBySecretReq) (*ACLTokenGetBySecretResp, *ErrorResp)
+
+	List(*ACLTokenListReq) (*ACLTokenListResp, *ErrorResp)
+}
+
+type ACLTokenBootstrapReq struct {
+	Token *domain.ACLToken `json:"token"`
+}
+
+type ACLTokenBootstrapResp struct {
+	Token *domain.ACLToken `json:"token"`
+}
+
+type ACLTokenCreateReq struct {
+	Token *domain.ACLToken `json:"token"`
+}
+
+type ACLTokenCreateResp struct {
+	Token *domain.ACLToken `json:"token"`
+}
+
+type ACLTokenDeleteReq struct {
+	AccessorID ulid.ULID `json:"accessor_id"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
+}
+
+type ACLTokenDeleteResp struct{}
+
+type ACLTokenGetReq struct {
+	AccessorID ulid.ULID `json:"accessor_id"`
+
+	QueryOptions `json:"-"`
+}
+
+type ACLTokenGetResp struct {
+	Token *domain.ACLToken `json:"token"`
+
+	QueryMeta `json:"-"`
+}
+
+type ACLTokenGetBySecretReq struct {
+	SecretID string `json:"secret_id"`
+}
+
+type ACLTokenGetBySecretResp struct {
+	Token *domain.ACLToken `json:"token"`
+}
+
+type ACLTokenListReq struct {
+	QueryOptions `json:"-"`
+}
+
+type ACLTokenListResp struct {
+	Tokens []*domain.ACLToken `json:"tokens"`
+
+	QueryMeta `json:"-"`
+}
@@ -5,20 +5,102 @@ package backend
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/rasorp/attila/internal/store"
 	"github.com/rasorp/attila/internal/store/file"
 	"github.com/rasorp/attila/internal/store/mem"
+	"github.com/rasorp/attila/internal/store/raft"
 )
 
-func NewBackend(cfg *Config) (store.State, error) {
+func NewBackend(logger *zap.Logger, cfg *Config) (store.State, error) {
 	if cfg.Memory.Enabled() {
 		return mem.New()
 	}
 
 	if cfg.File.Enabled() {
-		return file.New(cfg.File.Path)
+		keyring, err := newFileKeyring(cfg.File.Keyring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup keyring: %w", err)
+		}
+		return file.New(cfg.File.Path, keyring)
+	}
+
+	if cfg.Raft.Enabled() {
+		return newRaftBackend(logger, cfg.Raft)
 	}
 
 	return nil, errors.New("no state backend configured")
 }
+
+// RotateFileKeyring re-encrypts all objects stored by the file backend using the
+// active keyring key. It returns the number of objects rewritten and must not be
+// called while a server is using the backend.
+func RotateFileKeyring(cfg *Config) (int, error) {
+	if !cfg.File.Enabled() {
+		return 0, errors.New("file state backend not enabled")
+	}
+	if cfg.File.Keyring == nil {
+		return 0, errors.New("file state backend keyring not configured")
+	}
+
+	keyring, err := newFileKeyring(cfg.File.Keyring)
+	if err != nil {
+		return 0, fmt.Errorf("failed to setup keyring: %w", err)
+	}
+
+	return file.RotateKeyring(cfg.File.Path, keyring)
+}
+
+func newRaftBackend(logger *zap.Logger, cfg *RaftConfig) (store.State, error) {
+	raftCfg := raft.Config{
+		NodeID:   cfg.NodeID,
+		BindAddr: cfg.BindAddr,
+		DataDir:  cfg.DataDir,
+		Peers:    make([]*raft.Peer, len(cfg.Peers)),
+		Logger:   logger,
+	}
+
+	for i, peer := range cfg.Peers {
+		raftCfg.Peers[i] = &raft.Peer{
+			ID:          peer.ID,
+			Address:     peer.Address,
+			HTTPAddress: peer.HTTPAddress,
+		}
+	}
+
+	if cfg.HeartbeatTimeout != "" {
+		timeout, err := time.ParseDuration(cfg.HeartbeatTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse heartbeat timeout: %w", err)
+		}
+		raftCfg.HeartbeatTimeout = timeout
+	}
+
+	if cfg.ElectionTimeout != "" {
+		timeout, err := time.ParseDuration(cfg.ElectionTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse election timeout: %w", err)
+		}
+		raftCfg.ElectionTimeout = timeout
+	}
+
+	if cfg.TLS != nil {
+		tlsConfig, err := raft.NewTLSConfig(cfg.TLS.CAFile, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ServerName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup raft tls: %w", err)
+		}
+		raftCfg.TLSConfig = tlsConfig
+	}
+
+	keyring, err := newFileKeyring(cfg.Keyring)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup keyring: %w", err)
+	}
+	raftCfg.Keyring = keyring
+
+	return raft.New(&raftCfg)
+}
@@ -8,6 +8,10 @@ import (
 	"testing"
 
 	"github.com/shoenig/test/must"
+	"go.uber.org/zap"
+
+	"github.com/rasorp/attila/internal/helper/test/certs"
+	"github.com/rasorp/attila/internal/store"
 )
 
 func TestNewBackend(t *testing.T) {
@@ -35,6 +39,27 @@ func TestNewBackend(t *testing.T) {
 			expectedError: nil,
 			expectedName:  "file",
 		},
+		{
+			name: "raft backend",
+			inputConfig: &Config{
+				Raft: &RaftConfig{
+					Enable:   new(true),
+					NodeID:   "attila-1",
+					BindAddr: "127.0.0.1:0",
+					Keyring: &KeyringConfig{
+						ActiveKey: "k1",
+						Keys: []*KeyringKeyConfig{
+							{ID: "k1", Secret: "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="},
+						},
+					},
+					Peers: []*RaftPeerConfig{
+						{ID: "attila-1", Address: "127.0.0.1:0", HTTPAddress: "http://127.0.0.1:8080"},
+					},
+				},
+			},
+			expectedError: nil,
+			expectedName:  "raft",
+		},
 		{
 			name:          "no backend",
 			inputConfig:   &Config{},
@@ -58,7 +83,20 @@ func TestNewBackend(t *testing.T) {
 				cfg.File = &fileCfg
 			}
 
-			stateBackend, actualErr := NewBackend(&cfg)
+			if tc.inputConfig.Raft != nil {
+				raftCfg := *tc.inputConfig.Raft
+				if raftCfg.Enabled() && raftCfg.DataDir == "" {
+					raftCfg.DataDir = t.TempDir()
+				}
+				if raftCfg.Enabled() && raftCfg.TLS == nil {
+					ca := certs.NewCA(t)
+					certFile, keyFile := ca.Issue(t, raftCfg.NodeID)
+					raftCfg.TLS = &RaftTLSConfig{CAFile: ca.CertFile, CertFile: certFile, KeyFile: keyFile}
+				}
+				cfg.Raft = &raftCfg
+			}
+
+			stateBackend, actualErr := NewBackend(zap.NewNop(), &cfg)
 
 			if tc.expectedError != nil {
 				must.ErrorContains(t, actualErr, tc.expectedError.Error())
@@ -66,6 +104,10 @@ func TestNewBackend(t *testing.T) {
 			} else {
 				must.NoError(t, actualErr)
 				must.Eq(t, tc.expectedName, stateBackend.Name())
+
+				if replicated, ok := stateBackend.(store.Replicated); ok {
+					must.NoError(t, replicated.Shutdown())
+				}
 			}
 		})
 	}
@@ -13,6 +13,7 @@ import (
 type Config struct {
 	Memory *MemoryConfig `hcl:"memory,block"`
 	File   *FileConfig   `hcl:"file,block"`
+	Raft   *RaftConfig   `hcl:"raft,block"`
 }
 
 // DefaultConfig returns the default configuration for the Attila storage
@@ -49,6 +50,13 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Raft.Enabled() {
+		numEnabled++
+		if err := c.Raft.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	switch numEnabled {
 	case 0:
 		errs = append(errs, errors.New("no state backend enabled"))
@@ -89,6 +97,14 @@ func (c *Config) Merge(z *Config) *Config {
 		if z.File.Path != "" {
 			result.File.Path = z.File.Path
 		}
+		result.File.Keyring = result.File.Keyring.Merge(z.File.Keyring)
+	}
+
+	if z.Raft != nil {
+		if result.Raft == nil {
+			result.Raft = &RaftConfig{}
+		}
+		result.Raft = result.Raft.Merge(z.Raft)
 	}
 
 	return &result
@@ -107,6 +123,10 @@ func (m *MemoryConfig) Enabled() bool {
 type FileConfig struct {
 	Enable *bool  `hcl:"enabled"`
 	Path   string `hcl:"path"`
+
+	// Keyring enables encryption of sensitive object fields, such as region
+	// Nomad ACL tokens and TLS private keys, before they are written to disk.
+	Keyring *KeyringConfig `hcl:"keyring,block"`
 }
 
 // Enabled is a helper function that informs the caller if the file store
@@ -141,5 +161,9 @@ func (f *FileConfig) Validate() error {
 		errs = append(errs, fmt.Errorf("path %q is not a dir", f.Path))
 	}
 
+	if err := f.Keyring.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to validate keyring: %w", err))
+	}
+
 	return errors.Join(errs...)
 }
@@ -0,0 +1,134 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/rasorp/attila/internal/store/file"
+)
+
+// KeyringConfig is the configuration block which enables encryption of
+// sensitive object fields written by the file backend. Keys are rotated by
+// adding a new key block, making it active, and then running the keyring
+// rotate operator command. The previous key can be removed once this
+// completes.
+//
+// The Raft backend uses the same block to encrypt its log and snapshots. New
+// writes use the active key, and the previous key can be removed once a
+// snapshot has been taken and the older log entries compacted.
+type KeyringConfig struct {
+
+	// ActiveKey is the ID of the key used to encrypt all new and rotated
+	// objects.
+	ActiveKey string `hcl:"active_key"`
+
+	Keys []*KeyringKeyConfig `hcl:"key,block"`
+}
+
+// KeyringKeyConfig details a single key within the keyring.
+type KeyringKeyConfig struct {
+	ID string `hcl:"id,label"`
+
+	// Secret is the base64 encoded 32 byte AES-256 key.
+	Secret string `hcl:"secret"`
+}
+
+// Validate performs validation of the keyring configuration block. The
+// function can be called safely without checking if the object is nil. The
+// returned error could wrap multiple errors.
+func (k *KeyringConfig) Validate() error {
+	if k == nil {
+		return nil
+	}
+
+	var (
+		errs        []error
+		foundActive bool
+	)
+
+	if k.ActiveKey == "" {
+		errs = append(errs, errors.New("must set active_key parameter"))
+	}
+
+	keyIDs := make(map[string]struct{}, len(k.Keys))
+
+	for _, key := range k.Keys {
+		if _, ok := keyIDs[key.ID]; ok {
+			errs = append(errs, fmt.Errorf("duplicate key %q", key.ID))
+		}
+		keyIDs[key.ID] = struct{}{}
+
+		if key.ID == k.ActiveKey {
+			foundActive = true
+		}
+		if _, err := key.decode(); err != nil {
+			errs = append(errs, fmt.Errorf("key %q: %w", key.ID, err))
+		}
+	}
+
+	if k.ActiveKey != "" && !foundActive {
+		errs = append(errs, fmt.Errorf("active_key %q not found within key blocks", k.ActiveKey))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Merge combines the passed keyring configuration with the current object.
+// Key blocks are not merged individually, so a non-empty set of keys replaces
+// the existing set.
+func (k *KeyringConfig) Merge(z *KeyringConfig) *KeyringConfig {
+	if k == nil {
+		return z
+	}
+	if z == nil {
+		return k
+	}
+
+	result := *k
+
+	if z.ActiveKey != "" {
+		result.ActiveKey = z.ActiveKey
+	}
+	if len(z.Keys) > 0 {
+		result.Keys = z.Keys
+	}
+
+	return &result
+}
+
+// decode returns the raw key bytes, ensuring they are the correct length.
+func (k *KeyringKeyConfig) decode() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(k.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %w", err)
+	}
+	if len(key) != file.KeyringKeySize {
+		return nil, fmt.Errorf("secret must decode to %d bytes, got %d", file.KeyringKeySize, len(key))
+	}
+	return key, nil
+}
+
+// newFileKeyring builds the keyring used by the file and Raft backends from the
+// passed config. A nil config results in a nil keyring, which disables
+// encryption.
+func newFileKeyring(cfg *KeyringConfig) (*file.Keyring, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	keys := make(map[string][]byte, len(cfg.Keys))
+
+	for _, key := range cfg.Keys {
+		decoded, err := key.decode()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		keys[key.ID] = decoded
+	}
+
+	return file.NewKeyring(cfg.ActiveKey, keys)
+}
@@ -0,0 +1,78 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestKeyringConfig_Validate(t *testing.T) {
+
+	const validSecret = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+
+	testCases := []struct {
+		name          string
+		inputConfig   *KeyringConfig
+		expectedError error
+	}{
+		{
+			name:          "nil config",
+			inputConfig:   nil,
+			expectedError: nil,
+		},
+		{
+			name: "valid",
+			inputConfig: &KeyringConfig{
+				ActiveKey: "k2",
+				Keys: []*KeyringKeyConfig{
+					{ID: "k1", Secret: validSecret},
+					{ID: "k2", Secret: validSecret},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "active key missing",
+			inputConfig: &KeyringConfig{
+				ActiveKey: "k2",
+				Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+			},
+			expectedError: errors.New(`active_key "k2" not found within key blocks`),
+		},
+		{
+			name: "duplicate key",
+			inputConfig: &KeyringConfig{
+				ActiveKey: "k1",
+				Keys: []*KeyringKeyConfig{
+					{ID: "k1", Secret: validSecret},
+					{ID: "k1", Secret: validSecret},
+				},
+			},
+			expectedError: errors.New(`duplicate key "k1"`),
+		},
+		{
+			name: "invalid secret length",
+			inputConfig: &KeyringConfig{
+				ActiveKey: "k1",
+				Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: "c2hvcnQ="}},
+			},
+			expectedError: errors.New("secret must decode to 32 bytes"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualError := tc.inputConfig.Validate()
+
+			if tc.expectedError != nil {
+				must.ErrorContains(t, actualError, tc.expectedError.Error())
+			} else {
+				must.NoError(t, actualError)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RaftConfig is the configuration block for the Raft replicated state backend.
+// Each server within the cluster must list every member, including itself, as
+// a peer block.
+type RaftConfig struct {
+	Enable *bool `hcl:"enabled"`
+
+	// NodeID is the identifier of this server and must match the label of one
+	// of the configured peer blocks.
+	NodeID string `hcl:"node_id"`
+
+	// BindAddr is the TCP address the Raft transport listens on for requests
+	// from other servers within the cluster.
+	BindAddr string `hcl:"bind_addr"`
+
+	// DataDir is the absolute path to the directory used to persist the Raft
+	// log, vote information, and state snapshots.
+	DataDir string `hcl:"data_dir"`
+
+	// HeartbeatTimeout and ElectionTimeout tune the failure detection of the
+	// cluster. They are parsed as Go durations. A follower starts an election
+	// once it has not heard from the leader within the heartbeat timeout, which
+	// must not exceed the election timeout.
+	HeartbeatTimeout string `hcl:"heartbeat_timeout,optional"`
+	ElectionTimeout  string `hcl:"election_timeout,optional"`
+
+	// TLS secures the transport between servers using mutual TLS, so only
+	// servers holding a certificate signed by the CA can join the cluster and
+	// send Raft RPCs.
+	TLS *RaftTLSConfig `hcl:"tls,block"`
+
+	// Keyring encrypts the Raft log and snapshots written to the data
+	// directory, as both contain secrets such as region tokens.
+	Keyring *KeyringConfig `hcl:"keyring,block"`
+
+	Peers []*RaftPeerConfig `hcl:"peer,block"`
+}
+
+// RaftTLSConfig is the mutual TLS configuration of the Raft transport. Each
+// server presents its certificate to the peers it connects to and requires the
+// same of peers connecting to it.
+type RaftTLSConfig struct {
+
+	// CAFile is the path to the PEM encoded CA certificates used to verify the
+	// certificates of other servers.
+	CAFile string `hcl:"ca_file"`
+
+	// CertFile and KeyFile are the paths to the PEM encoded certificate and
+	// private key this server presents to its peers.
+	CertFile string `hcl:"cert_file"`
+	KeyFile  string `hcl:"key_file"`
+
+	// ServerName is the name used to verify the certificates of peers. If it
+	// is not set, the host of the peer address is used.
+	ServerName string `hcl:"server_name,optional"`
+}
+
+// Validate performs validation of the Raft TLS configuration block. It does not
+// read the configured files, as this happens when the transport is set up.
+func (r *RaftTLSConfig) Validate() error {
+
+	var errs []error
+
+	if r.CAFile == "" {
+		errs = append(errs, errors.New("must set tls ca_file parameter"))
+	}
+	if r.CertFile == "" {
+		errs = append(errs, errors.New("must set tls cert_file parameter"))
+	}
+	if r.KeyFile == "" {
+		errs = append(errs, errors.New("must set tls key_file parameter"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Merge combines the passed Raft TLS configuration with the current object.
+func (r *RaftTLSConfig) Merge(z *RaftTLSConfig) *RaftTLSConfig {
+
+	if r == nil {
+		return z
+	}
+	if z == nil {
+		return r
+	}
+
+	result := *r
+
+	if z.CAFile != "" {
+		result.CAFile = z.CAFile
+	}
+	if z.CertFile != "" {
+		result.CertFile = z.CertFile
+	}
+	if z.KeyFile != "" {
+		result.KeyFile = z.KeyFile
+	}
+	if z.ServerName != "" {
+		result.ServerName = z.ServerName
+	}
+
+	return &result
+}
+
+// RaftPeerConfig details a single member of the Raft cluster.
+type RaftPeerConfig struct {
+	ID string `hcl:"id,label"`
+
+	// Address is the Raft transport address of the peer.
+	Address string `hcl:"address"`
+
+	// HTTPAddress is the Attila HTTP API address of the peer and is used to
+	// forward write requests to the leader.
+	HTTPAddress string `hcl:"http_address"`
+}
+
+// Enabled is a helper function that informs the caller if the Raft store
+// backend is enabled.
+func (r *RaftConfig) Enabled() bool {
+	return r != nil && r.Enable != nil && *r.Enable
+}
+
+// Validate performs validation of the Raft configuration block. If it is not
+// enabled, the validation functionality will not run. The returned error could
+// wrap multiple errors and should indicate a terminal error in the process
+// which intends to use the config object.
+func (r *RaftConfig) Validate() error {
+	if !r.Enabled() {
+		return nil
+	}
+
+	var errs []error
+
+	if r.NodeID == "" {
+		errs = append(errs, errors.New("must set node_id parameter"))
+	}
+	if r.BindAddr == "" {
+		errs = append(errs, errors.New("must set bind_addr parameter"))
+	}
+
+	if r.DataDir == "" {
+		errs = append(errs, errors.New("must set data_dir parameter"))
+	} else if !filepath.IsAbs(r.DataDir) {
+		errs = append(errs, fmt.Errorf("data_dir %q is not an absolute path", r.DataDir))
+	} else if dir, err := os.Stat(r.DataDir); err != nil {
+		errs = append(errs, err)
+	} else if !dir.IsDir() {
+		errs = append(errs, fmt.Errorf("data_dir %q is not a dir", r.DataDir))
+	}
+
+	for _, durationStr := range []string{r.HeartbeatTimeout, r.ElectionTimeout} {
+		if durationStr == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(durationStr); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse duration: %w", err))
+		}
+	}
+
+	if r.TLS == nil {
+		errs = append(errs, errors.New("must set tls block"))
+	} else if err := r.TLS.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if r.Keyring == nil {
+		errs = append(errs, errors.New("must set keyring block"))
+	} else if err := r.Keyring.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to validate keyring: %w", err))
+	}
+
+	var foundSelf bool
+
+	peerIDs := make(map[string]struct{}, len(r.Peers))
+
+	for _, peer := range r.Peers {
+		if _, ok := peerIDs[peer.ID]; ok {
+			errs = append(errs, fmt.Errorf("duplicate peer %q", peer.ID))
+		}
+		peerIDs[peer.ID] = struct{}{}
+
+		if peer.ID == r.NodeID {
+			foundSelf = true
+		}
+		if peer.Address == "" {
+			errs = append(errs, fmt.Errorf("peer %q must set address parameter", peer.ID))
+		}
+		if _, err := url.Parse(peer.HTTPAddress); err != nil || peer.HTTPAddress == "" {
+			errs = append(errs, fmt.Errorf("peer %q must set valid http_address parameter", peer.ID))
+		}
+	}
+
+	if !foundSelf {
+		errs = append(errs, fmt.Errorf("node_id %q not found within peer blocks", r.NodeID))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Merge combines the passed Raft configuration with the current object. Peer
+// blocks are not merged individually, so a non-empty set of peers replaces the
+// existing set.
+func (r *RaftConfig) Merge(z *RaftConfig) *RaftConfig {
+	if r == nil {
+		return z
+	}
+	if z == nil {
+		return r
+	}
+
+	result := *r
+
+	if z.Enable != nil {
+		result.Enable = z.Enable
+	}
+	if z.NodeID != "" {
+		result.NodeID = z.NodeID
+	}
+	if z.BindAddr != "" {
+		result.BindAddr = z.BindAddr
+	}
+	if z.DataDir != "" {
+		result.DataDir = z.DataDir
+	}
+	if z.HeartbeatTimeout != "" {
+		result.HeartbeatTimeout = z.HeartbeatTimeout
+	}
+	if z.ElectionTimeout != "" {
+		result.ElectionTimeout = z.ElectionTimeout
+	}
+	result.TLS = r.TLS.Merge(z.TLS)
+	result.Keyring = r.Keyring.Merge(z.Keyring)
+
+	if len(z.Peers) > 0 {
+		result.Peers = z.Peers
+	}
+
+	return &result
+}
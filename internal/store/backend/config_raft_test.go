@@ -0,0 +1,256 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestRaftConfig_Validate(t *testing.T) {
+
+	const validSecret = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+
+	dataDir := t.TempDir()
+
+	testCases := []struct {
+		name          string
+		inputConfig   *RaftConfig
+		expectedError error
+	}{
+		{
+			name:          "not enabled",
+			inputConfig:   &RaftConfig{},
+			expectedError: nil,
+		},
+		{
+			name: "valid",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+					{ID: "attila-2", Address: "127.0.0.2:4647", HTTPAddress: "http://127.0.0.2:8080"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "self not within peers",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-3",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New(`node_id "attila-3" not found within peer blocks`),
+		},
+		{
+			name: "duplicate peer",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New(`duplicate peer "attila-1"`),
+		},
+		{
+			name: "missing tls",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New("must set tls block"),
+		},
+		{
+			name: "missing keyring",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New("must set keyring block"),
+		},
+		{
+			name: "incomplete tls",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  dataDir,
+				TLS:      &RaftTLSConfig{CertFile: "/etc/attila/raft.pem", KeyFile: "/etc/attila/raft-key.pem"},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New("must set tls ca_file parameter"),
+		},
+		{
+			name: "relative data dir",
+			inputConfig: &RaftConfig{
+				Enable:   new(true),
+				NodeID:   "attila-1",
+				BindAddr: "127.0.0.1:4647",
+				DataDir:  "data",
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New(`data_dir "data" is not an absolute path`),
+		},
+		{
+			name: "invalid election timeout",
+			inputConfig: &RaftConfig{
+				Enable:          new(true),
+				NodeID:          "attila-1",
+				BindAddr:        "127.0.0.1:4647",
+				DataDir:         dataDir,
+				ElectionTimeout: "soon",
+				TLS: &RaftTLSConfig{
+					CAFile:   "/etc/attila/ca.pem",
+					CertFile: "/etc/attila/raft.pem",
+					KeyFile:  "/etc/attila/raft-key.pem",
+				},
+				Keyring: &KeyringConfig{
+					ActiveKey: "k1",
+					Keys:      []*KeyringKeyConfig{{ID: "k1", Secret: validSecret}},
+				},
+				Peers: []*RaftPeerConfig{
+					{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+				},
+			},
+			expectedError: errors.New("failed to parse duration"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualError := tc.inputConfig.Validate()
+
+			if tc.expectedError != nil {
+				must.ErrorContains(t, actualError, tc.expectedError.Error())
+			} else {
+				must.NoError(t, actualError)
+			}
+		})
+	}
+}
+
+func TestRaftConfig_Merge(t *testing.T) {
+	inputConfig := &RaftConfig{
+		Enable:   new(false),
+		NodeID:   "attila-1",
+		BindAddr: "127.0.0.1:4647",
+		TLS: &RaftTLSConfig{
+			CAFile:   "/etc/attila/ca.pem",
+			CertFile: "/etc/attila/raft.pem",
+			KeyFile:  "/etc/attila/raft-key.pem",
+		},
+		Peers: []*RaftPeerConfig{
+			{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+		},
+	}
+
+	mergeConfig := &RaftConfig{
+		Enable:          new(true),
+		DataDir:         "/var/lib/attila",
+		ElectionTimeout: "2s",
+		TLS: &RaftTLSConfig{
+			CertFile:   "/etc/attila/rotated.pem",
+			ServerName: "server.attila",
+		},
+		Keyring: &KeyringConfig{ActiveKey: "k1"},
+	}
+
+	expectedOutput := &RaftConfig{
+		Enable:          new(true),
+		NodeID:          "attila-1",
+		BindAddr:        "127.0.0.1:4647",
+		DataDir:         "/var/lib/attila",
+		ElectionTimeout: "2s",
+		TLS: &RaftTLSConfig{
+			CAFile:     "/etc/attila/ca.pem",
+			CertFile:   "/etc/attila/rotated.pem",
+			KeyFile:    "/etc/attila/raft-key.pem",
+			ServerName: "server.attila",
+		},
+		Keyring: &KeyringConfig{ActiveKey: "k1"},
+		Peers: []*RaftPeerConfig{
+			{ID: "attila-1", Address: "127.0.0.1:4647", HTTPAddress: "http://127.0.0.1:8080"},
+		},
+	}
+
+	must.Eq(t, expectedOutput, inputConfig.Merge(mergeConfig))
+	must.Eq(t, inputConfig, inputConfig.Merge(nil))
+}
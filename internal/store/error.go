@@ -3,6 +3,12 @@
 
 package store
 
+import (
+	"fmt"
+
+	"github.com/rasorp/attila/internal/domain"
+)
+
 type ErrorResp struct {
 	ErrorBody `json:"error"`
 }
@@ -30,3 +36,23 @@ func (e *ErrorResp) Err() error { return e.err }
 func (e *ErrorResp) StatusCode() int { return e.Code }
 
 func (e *ErrorResp) String() string { return e.Msg }
+
+// CheckIndex performs a check-and-set comparison of the optional check index
+// supplied within a write request against the metadata of the existing object.
+// A nil check index indicates the caller does not want to perform the check. A
+// mismatch results in a conflict error response which should be returned to
+// the caller unmodified.
+func CheckIndex(checkIndex *uint64, existing *domain.Metadata) *ErrorResp {
+	if checkIndex == nil {
+		return nil
+	}
+
+	if modifyIndex := existing.GetModifyIndex(); *checkIndex != modifyIndex {
+		return NewErrorResp(
+			fmt.Errorf("check index %v does not match modify index %v", *checkIndex, modifyIndex),
+			409,
+		)
+	}
+
+	return nil
+}
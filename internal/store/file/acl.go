@@ -0,0 +1,13 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import "github.com/rasorp/attila/internal/store"
+
+type ACL struct {
+	store *Store
+}
+
+func (a *ACL) Policy() store.ACLPolicyState { return &ACLPolicy{store: a.store} }
+func (a *ACL) Token() store.ACLTokenState   { return &ACLToken{store: a.store} }
@@ -0,0 +1,171 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type ACLPolicy struct {
+	store *Store
+}
+
+func (a *ACLPolicy) Create(req *store.ACLPolicyCreateReq) (*store.ACLPolicyCreateResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	path := filepath.Join(a.store.aclPolicyDir, req.Policy.Name+".json")
+
+	index, errResp := a.store.nextIndex(aclPolicyDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Policy.Metadata = req.Policy.Metadata.WithModifyIndex(index)
+
+	if code, err := createStoreFile(path, req.Policy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	a.store.notifyChange()
+
+	return &store.ACLPolicyCreateResp{Policy: req.Policy}, nil
+}
+
+func (a *ACLPolicy) Delete(req *store.ACLPolicyDeleteReq) (*store.ACLPolicyDeleteResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	path := filepath.Join(a.store.aclPolicyDir, req.Name+".json")
+
+	var existingPolicy domain.ACLPolicy
+
+	if code, err := getStoreFile(path, &existingPolicy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingPolicy.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := a.store.nextIndex(aclPolicyDir); errResp != nil {
+		return nil, errResp
+	}
+
+	if err := os.Remove(path); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+
+	a.store.notifyChange()
+
+	return &store.ACLPolicyDeleteResp{}, nil
+}
+
+func (a *ACLPolicy) Get(req *store.ACLPolicyGetReq) (*store.ACLPolicyGetResp, *store.ErrorResp) {
+	var reply store.ACLPolicyGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		a.store.lock.RLock()
+		defer a.store.lock.RUnlock()
+
+		path := filepath.Join(a.store.aclPolicyDir, req.Name+".json")
+
+		var decodedPolicy domain.ACLPolicy
+
+		if code, err := getStoreFile(path, &decodedPolicy); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := a.store.queryIndex(aclPolicyDir)
+
+		reply = store.ACLPolicyGetResp{
+			Policy:    &decodedPolicy,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (a *ACLPolicy) List(req *store.ACLPolicyListReq) (*store.ACLPolicyListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.ACLPolicyListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		a.store.lock.RLock()
+		defer a.store.lock.RUnlock()
+
+		index, waitFn := a.store.queryIndex(aclPolicyDir)
+		resp = store.ACLPolicyListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		err := listStoreFiles(a.store.aclPolicyDir, func(bytes []byte) error {
+			var decodedPolicy domain.ACLPolicy
+
+			if err := json.Unmarshal(bytes, &decodedPolicy); err != nil {
+				return err
+			}
+
+			resp.Policies = append(resp.Policies, &decodedPolicy)
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &resp, nil
+}
+
+func (a *ACLPolicy) Update(req *store.ACLPolicyUpdateReq) (*store.ACLPolicyUpdateResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	path := filepath.Join(a.store.aclPolicyDir, req.Policy.Name+".json")
+
+	var existingPolicy domain.ACLPolicy
+
+	if code, err := getStoreFile(path, &existingPolicy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingPolicy.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	index, errResp := a.store.nextIndex(aclPolicyDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Policy.Metadata = existingPolicy.Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
+
+	if code, err := updateStoreFile(path, req.Policy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	a.store.notifyChange()
+
+	return &store.ACLPolicyUpdateResp{Policy: req.Policy}, nil
+}
@@ -0,0 +1,119 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestACLPolicy_Create(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockPolicy := mock.ACLPolicy()
+
+	createResp1, errResp1 := testState.ACL().Policy().Create(
+		&store.ACLPolicyCreateReq{Policy: mockPolicy},
+	)
+	must.Nil(t, errResp1)
+	must.Eq(t, mockPolicy, createResp1.Policy)
+
+	createResp2, errResp2 := testState.ACL().Policy().Create(
+		&store.ACLPolicyCreateReq{Policy: mockPolicy},
+	)
+	must.NotNil(t, errResp2)
+	must.Nil(t, createResp2)
+}
+
+func TestACLToken_Bootstrap(t *testing.T) {
+	dir := t.TempDir()
+
+	testState, err := New(dir, nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	bootstrapToken := domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)
+
+	bootstrapResp1, errResp1 := testState.ACL().Token().Bootstrap(
+		&store.ACLTokenBootstrapReq{Token: bootstrapToken},
+	)
+	must.Nil(t, errResp1)
+	must.Eq(t, bootstrapToken, bootstrapResp1.Token)
+
+	// Bootstrapping can only happen once.
+	bootstrapResp2, errResp2 := testState.ACL().Token().Bootstrap(
+		&store.ACLTokenBootstrapReq{Token: domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)},
+	)
+	must.NotNil(t, errResp2)
+	must.ErrorContains(t, errResp2, "already bootstrapped")
+	must.Nil(t, bootstrapResp2)
+
+	// Deleting every token does not allow the system to be bootstrapped again,
+	// including once the store is reopened.
+	_, errResp := testState.ACL().Token().Delete(
+		&store.ACLTokenDeleteReq{AccessorID: bootstrapToken.AccessorID},
+	)
+	must.Nil(t, errResp)
+
+	testState, err = New(dir, nil)
+	must.NoError(t, err)
+
+	bootstrapResp3, errResp3 := testState.ACL().Token().Bootstrap(
+		&store.ACLTokenBootstrapReq{Token: domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)},
+	)
+	must.NotNil(t, errResp3)
+	must.ErrorContains(t, errResp3, "already bootstrapped")
+	must.Nil(t, bootstrapResp3)
+}
+
+func TestACLToken_Create(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockPolicy := mock.ACLPolicy()
+
+	// The token cannot be created until the linked policy exists.
+	clientToken := domain.NewACLToken("client", domain.ACLTokenTypeClient, []string{mockPolicy.Name})
+
+	createResp1, errResp1 := testState.ACL().Token().Create(
+		&store.ACLTokenCreateReq{Token: clientToken},
+	)
+	must.NotNil(t, errResp1)
+	must.Eq(t, 400, errResp1.StatusCode())
+	must.Nil(t, createResp1)
+
+	_, errResp := testState.ACL().Policy().Create(&store.ACLPolicyCreateReq{Policy: mockPolicy})
+	must.Nil(t, errResp)
+
+	createResp2, errResp2 := testState.ACL().Token().Create(
+		&store.ACLTokenCreateReq{Token: clientToken},
+	)
+	must.Nil(t, errResp2)
+	must.Eq(t, clientToken, createResp2.Token)
+
+	getResp, errResp := testState.ACL().Token().GetBySecret(
+		&store.ACLTokenGetBySecretReq{SecretID: clientToken.SecretID},
+	)
+	must.Nil(t, errResp)
+	must.Eq(t, clientToken.AccessorID, getResp.Token.AccessorID)
+
+	_, errResp = testState.ACL().Token().Delete(
+		&store.ACLTokenDeleteReq{AccessorID: clientToken.AccessorID},
+	)
+	must.Nil(t, errResp)
+
+	_, errResp = testState.ACL().Token().GetBySecret(
+		&store.ACLTokenGetBySecretReq{SecretID: clientToken.SecretID},
+	)
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+}
@@ -0,0 +1,217 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type ACLToken struct {
+	store *Store
+}
+
+func (a *ACLToken) Bootstrap(req *store.ACLTokenBootstrapReq) (*store.ACLTokenBootstrapResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	tokens, err := a.list()
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+	if a.store.aclBootstrapIndex != 0 || len(tokens) > 0 {
+		return nil, store.NewErrorResp(errors.New("acl system already bootstrapped"), 400)
+	}
+
+	if errResp := a.create(req.Token); errResp != nil {
+		return nil, errResp
+	}
+
+	// Should recording the bootstrap fail, the token still prevents another
+	// bootstrap until it is deleted.
+	if errResp := a.store.setACLBootstrapIndex(req.Token.Metadata.ModifyIndex); errResp != nil {
+		return nil, errResp
+	}
+
+	return &store.ACLTokenBootstrapResp{Token: req.Token}, nil
+}
+
+func (a *ACLToken) Create(req *store.ACLTokenCreateReq) (*store.ACLTokenCreateResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	// Ensure the linked policies exist within state.
+	for _, policyName := range req.Token.Policies {
+		var policy domain.ACLPolicy
+
+		path := filepath.Join(a.store.aclPolicyDir, policyName+".json")
+
+		if code, err := getStoreFile(path, &policy); err != nil {
+			if code == 404 {
+				return nil, store.NewErrorResp(fmt.Errorf("acl policy %q not found", policyName), 400)
+			}
+			return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+	}
+
+	if errResp := a.create(req.Token); errResp != nil {
+		return nil, errResp
+	}
+
+	return &store.ACLTokenCreateResp{Token: req.Token}, nil
+}
+
+// create writes the token to state. The caller must hold the write lock.
+func (a *ACLToken) create(token *domain.ACLToken) *store.ErrorResp {
+	path := filepath.Join(a.store.aclTokenDir, token.AccessorID.String()+".json")
+
+	index, errResp := a.store.nextIndex(aclTokenDir)
+	if errResp != nil {
+		return errResp
+	}
+
+	token.Metadata = token.Metadata.WithModifyIndex(index)
+
+	if code, err := createStoreFile(path, token); err != nil {
+		return store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	a.store.notifyChange()
+	return nil
+}
+
+func (a *ACLToken) Delete(req *store.ACLTokenDeleteReq) (*store.ACLTokenDeleteResp, *store.ErrorResp) {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	path := filepath.Join(a.store.aclTokenDir, req.AccessorID.String()+".json")
+
+	var existingToken domain.ACLToken
+
+	if code, err := getStoreFile(path, &existingToken); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingToken.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := a.store.nextIndex(aclTokenDir); errResp != nil {
+		return nil, errResp
+	}
+
+	if err := os.Remove(path); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+
+	a.store.notifyChange()
+
+	return &store.ACLTokenDeleteResp{}, nil
+}
+
+func (a *ACLToken) Get(req *store.ACLTokenGetReq) (*store.ACLTokenGetResp, *store.ErrorResp) {
+	var reply store.ACLTokenGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		a.store.lock.RLock()
+		defer a.store.lock.RUnlock()
+
+		path := filepath.Join(a.store.aclTokenDir, req.AccessorID.String()+".json")
+
+		var decodedToken domain.ACLToken
+
+		if code, err := getStoreFile(path, &decodedToken); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := a.store.queryIndex(aclTokenDir)
+
+		reply = store.ACLTokenGetResp{
+			Token:     &decodedToken,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+// GetBySecret scans all tokens for the one matching the secret ID. The number
+// of tokens is expected to be small, so this avoids maintaining a secondary
+// index on disk.
+func (a *ACLToken) GetBySecret(req *store.ACLTokenGetBySecretReq) (*store.ACLTokenGetBySecretResp, *store.ErrorResp) {
+	a.store.lock.RLock()
+	defer a.store.lock.RUnlock()
+
+	tokens, err := a.list()
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+
+	for _, token := range tokens {
+		if token.SecretID == req.SecretID {
+			return &store.ACLTokenGetBySecretResp{Token: token}, nil
+		}
+	}
+
+	return nil, store.NewErrorResp(errors.New("acl token not found"), 404)
+}
+
+func (a *ACLToken) List(req *store.ACLTokenListReq) (*store.ACLTokenListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.ACLTokenListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		a.store.lock.RLock()
+		defer a.store.lock.RUnlock()
+
+		index, waitFn := a.store.queryIndex(aclTokenDir)
+
+		tokens, err := a.list()
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+
+		resp = store.ACLTokenListResp{Tokens: tokens, QueryMeta: store.QueryMeta{Index: index}}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &resp, nil
+}
+
+// list reads all tokens from disk. The caller must hold the lock.
+func (a *ACLToken) list() ([]*domain.ACLToken, error) {
+	var tokens []*domain.ACLToken
+
+	err := listStoreFiles(a.store.aclTokenDir, func(bytes []byte) error {
+		var decodedToken domain.ACLToken
+
+		if err := json.Unmarshal(bytes, &decodedToken); err != nil {
+			return err
+		}
+
+		tokens = append(tokens, &decodedToken)
+		return nil
+	})
+
+	return tokens, err
+}
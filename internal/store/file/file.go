@@ -4,6 +4,7 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,30 +19,83 @@ import (
 
 type Store struct {
 	dir             string
+	aclPolicyDir    string
+	aclTokenDir     string
 	jobRegMethodDir string
 	jobRegPlanDir   string
 	jobRegRuleDir   string
+	jobRegRunDir    string
 	regionDir       string
+	indexPath       string
 	lock            sync.RWMutex
+
+	// keyring encrypts the sensitive fields of objects written to disk. It is
+	// nil when encryption is not configured, in which case objects are written
+	// in plaintext.
+	keyring *Keyring
+
+	// index is the latest modify index written to state and tableIndexes is
+	// the latest modify index of each object type, keyed by its directory. Both
+	// are loaded from disk when the store is created and must only be accessed
+	// while holding the lock.
+	index        uint64
+	tableIndexes map[string]uint64
+
+	// aclBootstrapIndex is the index the ACL system was bootstrapped at, or
+	// zero if it has not been. It is loaded from disk when the store is
+	// created and must only be accessed while holding the lock.
+	aclBootstrapIndex uint64
+
+	// changeCh is closed and replaced each time a write is committed, which
+	// notifies any blocking queries that state has changed. It must only be
+	// accessed while holding the lock.
+	changeCh chan struct{}
+}
+
+// indexFile is the on-disk representation of the latest modify indexes.
+type indexFile struct {
+	Index             uint64            `json:"index"`
+	Tables            map[string]uint64 `json:"tables,omitempty"`
+	ACLBootstrapIndex uint64            `json:"acl_bootstrap_index,omitempty"`
 }
 
 const (
+	aclPolicyDir    = "acl/policy"
+	aclTokenDir     = "acl/token"
 	jobRegMethodDir = "job/registration/method"
 	jobRegPlanDir   = "job/registration/plan"
 	jobRegRuleDir   = "job/registration/rule"
+	jobRegRunDir    = "job/registration/run"
 	regionDir       = "region"
+	indexFileName   = "index.json"
 )
 
-func New(dir string) (store.State, error) {
+// New returns the file state backend which persists objects within dir. The
+// keyring is optional and enables encryption of sensitive object fields.
+func New(dir string, keyring *Keyring) (store.State, error) {
+	return newStore(dir, keyring)
+}
+
+func newStore(dir string, keyring *Keyring) (*Store, error) {
 	s := Store{
 		dir:             dir,
+		aclPolicyDir:    filepath.Join(dir, aclPolicyDir),
+		aclTokenDir:     filepath.Join(dir, aclTokenDir),
 		jobRegMethodDir: filepath.Join(dir, jobRegMethodDir),
 		jobRegPlanDir:   filepath.Join(dir, jobRegPlanDir),
 		jobRegRuleDir:   filepath.Join(dir, jobRegRuleDir),
+		jobRegRunDir:    filepath.Join(dir, jobRegRunDir),
 		regionDir:       filepath.Join(dir, regionDir),
+		indexPath:       filepath.Join(dir, indexFileName),
+		keyring:         keyring,
+		tableIndexes:    make(map[string]uint64),
+		changeCh:        make(chan struct{}),
 	}
 
-	for _, subDir := range []string{s.jobRegPlanDir, s.jobRegMethodDir, s.jobRegRuleDir, s.regionDir} {
+	for _, subDir := range []string{
+		s.aclPolicyDir, s.aclTokenDir, s.jobRegPlanDir, s.jobRegMethodDir, s.jobRegRuleDir, s.jobRegRunDir,
+		s.regionDir,
+	} {
 		// Check the existence of directory. Any error is terminal, except one
 		// indicating the directory doesn't exist, as this is normal expected
 		// behaviour of a new server.
@@ -62,9 +116,85 @@ func New(dir string) (store.State, error) {
 		}
 	}
 
+	// Load the latest modify index. The file will not exist on a new server,
+	// or one which has not performed any writes, so the index starts at zero.
+	var existingIndex indexFile
+
+	if code, err := getStoreFile(s.indexPath, &existingIndex); err != nil && code != 404 {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	s.index = existingIndex.Index
+	s.aclBootstrapIndex = existingIndex.ACLBootstrapIndex
+
+	for table, index := range existingIndex.Tables {
+		s.tableIndexes[table] = index
+	}
+
 	return &s, nil
 }
 
+// nextIndex advances the latest modify index of state, and of the object type
+// identified by table, and returns it for use by the next write. The index is
+// persisted before the object is written, so a write which then fails leaves a
+// gap rather than allowing a later write to reuse the index. The caller must
+// hold the write lock and call notifyChange once the object is written.
+func (s *Store) nextIndex(table string) (uint64, *store.ErrorResp) {
+	index := s.index + 1
+
+	tables := make(map[string]uint64, len(s.tableIndexes)+1)
+	for k, v := range s.tableIndexes {
+		tables[k] = v
+	}
+	tables[table] = index
+
+	newIndexFile := indexFile{Index: index, Tables: tables, ACLBootstrapIndex: s.aclBootstrapIndex}
+
+	if code, err := updateStoreFile(s.indexPath, &newIndexFile); err != nil {
+		return 0, store.NewErrorResp(fmt.Errorf("state: failed to write index: %w", err), code)
+	}
+
+	s.index = index
+	s.tableIndexes = tables
+
+	return index, nil
+}
+
+// setACLBootstrapIndex persists the index the ACL system was bootstrapped at.
+// It is never cleared, so the ACL system cannot be bootstrapped again once
+// every token is deleted. The caller must hold the write lock.
+func (s *Store) setACLBootstrapIndex(index uint64) *store.ErrorResp {
+	newIndexFile := indexFile{Index: s.index, Tables: s.tableIndexes, ACLBootstrapIndex: index}
+
+	if code, err := updateStoreFile(s.indexPath, &newIndexFile); err != nil {
+		return store.NewErrorResp(fmt.Errorf("state: failed to write index: %w", err), code)
+	}
+
+	s.aclBootstrapIndex = index
+	return nil
+}
+
+// notifyChange notifies any blocking queries that a write has been committed.
+// The caller must hold the write lock.
+func (s *Store) notifyChange() {
+	close(s.changeCh)
+	s.changeCh = make(chan struct{})
+}
+
+// queryIndex returns the latest modify index of the object type identified by
+// table, along with a function which blocks until the next write is committed.
+// The caller must hold the read lock.
+func (s *Store) queryIndex(table string) (uint64, func(context.Context)) {
+	changeCh := s.changeCh
+
+	return s.tableIndexes[table], func(ctx context.Context) {
+		select {
+		case <-changeCh:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (s *Store) ACL() store.ACLState                 { return &ACL{store: s} }
 func (s *Store) JobRegister() store.JobRegisterState { return &JobRegister{store: s} }
 func (s *Store) Region() store.RegionState           { return &Region{store: s} }
 func (s *Store) Name() string                        { return "file" }
@@ -90,6 +220,22 @@ func createStoreFile(path string, data any) (int, error) {
 	return 0, nil
 }
 
+// updateStoreFile overwrites the existing object at the passed path. The caller
+// is responsible for ensuring the object already exists, which is usually
+// achieved by reading it via getStoreFile.
+func updateStoreFile(path string, data any) (int, error) {
+	objBytes, err := json.Marshal(data)
+	if err != nil {
+		return 500, err
+	}
+
+	if err := file.AtomicWrite(path, objBytes, 0600); err != nil {
+		return 500, err
+	}
+
+	return 0, nil
+}
+
 func getStoreFile(path string, obj any) (int, error) {
 	existing, err := os.ReadFile(path)
 	if err != nil {
@@ -20,7 +20,7 @@ func Test_New(t *testing.T) {
 
 		dir := t.TempDir()
 
-		fileStore, err := New(dir)
+		fileStore, err := New(dir, nil)
 		must.NoError(t, err)
 		must.NotNil(t, fileStore)
 
@@ -38,7 +38,7 @@ func Test_New(t *testing.T) {
 		dir := t.TempDir()
 
 		// Create an initial instance of the file store.
-		fileStore, err := New(dir)
+		fileStore, err := New(dir, nil)
 		must.NoError(t, err)
 		must.NotNil(t, fileStore)
 
@@ -50,7 +50,7 @@ func Test_New(t *testing.T) {
 		must.Eq(t, createResp.Region, mockRegion)
 
 		// Create another instance of the file store.
-		fileStore, err = New(dir)
+		fileStore, err = New(dir, nil)
 		must.NoError(t, err)
 		must.NotNil(t, fileStore)
 
@@ -58,6 +58,11 @@ func Test_New(t *testing.T) {
 		getResp, err := fileStore.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
 		must.Nil(t, err)
 		must.Eq(t, getResp.Region, mockRegion)
+
+		// Ensure the modify index continues from the persisted value.
+		createResp, err = fileStore.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+		must.Nil(t, err)
+		must.Eq(t, 2, createResp.Region.Metadata.ModifyIndex)
 	})
 
 	t.Run("irregular dir", func(t *testing.T) {
@@ -65,7 +70,7 @@ func Test_New(t *testing.T) {
 		exec, err := os.Executable()
 		must.NoError(t, err)
 
-		fileStore, err := New(exec)
+		fileStore, err := New(exec, nil)
 		must.Error(t, err)
 		must.Nil(t, fileStore)
 	})
@@ -75,3 +80,57 @@ func TestStore_Name(t *testing.T) {
 	fileStore := &Store{}
 	must.Eq(t, "file", fileStore.Name())
 }
+
+func TestStore_nextIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	fileStore, err := New(dir, nil)
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	createResp, errResp := fileStore.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, createResp.Region.Metadata.ModifyIndex)
+
+	// A write which fails once the index is advanced leaves a gap, so the
+	// index is never reused.
+	_, errResp = fileStore.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.NotNil(t, errResp)
+	must.Eq(t, 400, errResp.StatusCode())
+
+	createResp, errResp = fileStore.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+	must.Nil(t, errResp)
+	must.Eq(t, 3, createResp.Region.Metadata.ModifyIndex)
+
+	// Replace the index file with a directory, so persisting the index fails.
+	// The object must not be written, as it would hold an index which was
+	// never persisted.
+	indexPath := filepath.Join(dir, indexFileName)
+	must.NoError(t, os.Remove(indexPath))
+	must.NoError(t, os.Mkdir(indexPath, 0700))
+
+	failedRegion := mock.Region()
+
+	_, errResp = fileStore.Region().Create(&store.RegionCreateReq{Region: failedRegion})
+	must.NotNil(t, errResp)
+
+	_, errResp = fileStore.Region().Get(&store.RegionGetReq{RegionName: failedRegion.Name})
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+
+	must.NoError(t, os.Remove(indexPath))
+
+	createResp, errResp = fileStore.Region().Create(&store.RegionCreateReq{Region: failedRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 4, createResp.Region.Metadata.ModifyIndex)
+
+	// The index continues from the last persisted value once the store is
+	// reopened.
+	fileStore, err = New(dir, nil)
+	must.NoError(t, err)
+
+	createResp, errResp = fileStore.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+	must.Nil(t, errResp)
+	must.Eq(t, 5, createResp.Region.Metadata.ModifyIndex)
+}
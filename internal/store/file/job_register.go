@@ -14,3 +14,4 @@ func (j *JobRegister) Method() store.JobRegisterMethodState {
 }
 func (j *JobRegister) Plan() store.JobRegisterPlanState { return &JobRegisterPlan{store: j.store} }
 func (j *JobRegister) Rule() store.JobRegisterRuleState { return &JobRegisterRule{store: j.store} }
+func (j *JobRegister) Run() store.JobRegisterRunState   { return &JobRegisterRun{store: j.store} }
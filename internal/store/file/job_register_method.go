@@ -4,6 +4,7 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,10 +24,19 @@ func (j *JobRegisterMethod) Create(req *store.JobRegisterMethodCreateReq) (*stor
 
 	filePath := filepath.Join(j.store.jobRegMethodDir, req.Method.Name+".json")
 
+	index, errResp := j.store.nextIndex(jobRegMethodDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Method.Metadata = req.Method.Metadata.WithModifyIndex(index)
+
 	if code, err := createStoreFile(filePath, req.Method); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterMethodCreateResp{Method: req.Method}, nil
 }
 
@@ -36,47 +46,128 @@ func (j *JobRegisterMethod) Delete(req *store.JobRegisterMethodDeleteReq) (*stor
 
 	path := filepath.Join(j.store.jobRegMethodDir, req.Name+".json")
 
+	// Read the existing object before advancing the index, so deleting an
+	// object which does not exist is rejected without a write.
+	var existingMethod domain.JobRegisterMethod
+
+	if code, err := getStoreFile(path, &existingMethod); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingMethod.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := j.store.nextIndex(jobRegMethodDir); errResp != nil {
+		return nil, errResp
+	}
+
 	if err := os.Remove(path); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterMethodDeleteResp{}, nil
 }
 
 func (j *JobRegisterMethod) Get(req *store.JobRegisterMethodGetReq) (*store.JobRegisterMethodGetResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+	var reply store.JobRegisterMethodGetResp
 
-	path := filepath.Join(j.store.jobRegMethodDir, req.Name+".json")
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-	var decodedMethod domain.JobRegisterMethod
+		path := filepath.Join(j.store.jobRegMethodDir, req.Name+".json")
 
-	if code, err := getStoreFile(path, &decodedMethod); err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		var decodedMethod domain.JobRegisterMethod
+
+		if code, err := getStoreFile(path, &decodedMethod); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := j.store.queryIndex(jobRegMethodDir)
+
+		reply = store.JobRegisterMethodGetResp{
+			Method:    &decodedMethod,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	return &store.JobRegisterMethodGetResp{Method: &decodedMethod}, nil
+	return &reply, nil
 }
 
-func (j *JobRegisterMethod) List(_ *store.JobRegisterMethodListReq) (*store.JobRegisterMethodListResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+func (j *JobRegisterMethod) List(req *store.JobRegisterMethodListReq) (*store.JobRegisterMethodListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.JobRegisterMethodListResp
+	)
 
-	var resp store.JobRegisterMethodListResp
+	if req != nil {
+		opts = &req.QueryOptions
+	}
 
-	err := listStoreFiles(j.store.jobRegMethodDir, func(bytes []byte) error {
-		var decodedMethod domain.JobRegisterMethod
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-		if err := json.Unmarshal(bytes, &decodedMethod); err != nil {
-			return err
-		}
+		index, waitFn := j.store.queryIndex(jobRegMethodDir)
+		resp = store.JobRegisterMethodListResp{QueryMeta: store.QueryMeta{Index: index}}
 
-		resp.Methods = append(resp.Methods, &decodedMethod)
-		return nil
-	})
+		err := listStoreFiles(j.store.jobRegMethodDir, func(bytes []byte) error {
+			var decodedMethod domain.JobRegisterMethod
 
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+			if err := json.Unmarshal(bytes, &decodedMethod); err != nil {
+				return err
+			}
+
+			resp.Methods = append(resp.Methods, &decodedMethod)
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
+
 	return &resp, nil
 }
+
+func (j *JobRegisterMethod) Update(req *store.JobRegisterMethodUpdateReq) (*store.JobRegisterMethodUpdateResp, *store.ErrorResp) {
+	j.store.lock.Lock()
+	defer j.store.lock.Unlock()
+
+	path := filepath.Join(j.store.jobRegMethodDir, req.Method.Name+".json")
+
+	var existingMethod domain.JobRegisterMethod
+
+	if code, err := getStoreFile(path, &existingMethod); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingMethod.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	index, errResp := j.store.nextIndex(jobRegMethodDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Method.Metadata = existingMethod.Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
+
+	if code, err := updateStoreFile(path, req.Method); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	j.store.notifyChange()
+
+	return &store.JobRegisterMethodUpdateResp{Method: req.Method}, nil
+}
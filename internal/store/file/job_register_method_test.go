@@ -14,7 +14,7 @@ import (
 )
 
 func TestJobRegisterMethod_Create(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -34,7 +34,7 @@ func TestJobRegisterMethod_Create(t *testing.T) {
 }
 
 func TestJobRegisterMethod_Delete(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -56,11 +56,17 @@ func TestJobRegisterMethod_Delete(t *testing.T) {
 		&store.JobRegisterMethodDeleteReq{Name: mockMethod.Name},
 	)
 	must.NotNil(t, errResp2)
+	must.Eq(t, 404, errResp2.StatusCode())
 	must.Nil(t, deleteResp2)
+
+	// Deleting an object which does not exist must not advance the index.
+	listResp, errResp := testState.JobRegister().Method().List(&store.JobRegisterMethodListReq{})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, listResp.Index)
 }
 
 func TestJobRegisterMethod_Get(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -82,7 +88,7 @@ func TestJobRegisterMethod_Get(t *testing.T) {
 }
 
 func TestJobRegisterMethod_List(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -105,3 +111,36 @@ func TestJobRegisterMethod_List(t *testing.T) {
 	must.Nil(t, err)
 	must.SliceContainsAll(t, listResp2.Methods, mockMethods)
 }
+
+func TestJobRegisterMethod_Update(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockMethod := mock.JobRegistrationMethod()
+
+	updateResp1, errResp1 := testState.JobRegister().Method().Update(&store.JobRegisterMethodUpdateReq{Method: mockMethod})
+	must.NotNil(t, errResp1)
+	must.Eq(t, 404, errResp1.StatusCode())
+	must.Nil(t, updateResp1)
+
+	mockMethod.Metadata = domain.NewMetadata()
+
+	createResp1, errResp2 := testState.JobRegister().Method().Create(&store.JobRegisterMethodCreateReq{Method: mockMethod})
+	must.Nil(t, errResp2)
+	must.Eq(t, mockMethod, createResp1.Method)
+
+	updatedMethod := mock.JobRegistrationMethod()
+	updatedMethod.Name = mockMethod.Name
+	updatedMethod.Rules = []*domain.JobRegisterMethodRuleLink{{Name: "updated-rule"}}
+
+	updateResp2, errResp3 := testState.JobRegister().Method().Update(&store.JobRegisterMethodUpdateReq{Method: updatedMethod})
+	must.Nil(t, errResp3)
+	must.Eq(t, updatedMethod, updateResp2.Method)
+	must.Eq(t, mockMethod.Metadata.CreateTime.UTC(), updateResp2.Method.Metadata.CreateTime.UTC())
+	must.True(t, updateResp2.Method.Metadata.UpdateTime.After(mockMethod.Metadata.UpdateTime))
+
+	getResp, errResp4 := testState.JobRegister().Method().Get(&store.JobRegisterMethodGetReq{Name: mockMethod.Name})
+	must.Nil(t, errResp4)
+	must.Eq(t, updatedMethod.Rules, getResp.Method.Rules)
+}
@@ -4,6 +4,7 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,10 +24,19 @@ func (j *JobRegisterPlan) Create(req *store.JobRegisterPlanCreateReq) (*store.Jo
 
 	path := filepath.Join(j.store.jobRegPlanDir, req.Plan.ID.String()+".json")
 
+	index, errResp := j.store.nextIndex(jobRegPlanDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Plan.Metadata = req.Plan.Metadata.WithModifyIndex(index)
+
 	if code, err := createStoreFile(path, req.Plan); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterPlanCreateResp{Plan: req.Plan}, nil
 }
 
@@ -36,47 +46,96 @@ func (j *JobRegisterPlan) Delete(req *store.JobRegisterPlanDeleteReq) (*store.Jo
 
 	path := filepath.Join(j.store.jobRegPlanDir, req.ID.String()+".json")
 
+	// Read the existing object before advancing the index, so deleting an
+	// object which does not exist is rejected without a write.
+	var existingPlan domain.JobRegisterPlan
+
+	if code, err := getStoreFile(path, &existingPlan); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingPlan.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := j.store.nextIndex(jobRegPlanDir); errResp != nil {
+		return nil, errResp
+	}
+
 	if err := os.Remove(path); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterPlanDeleteResp{}, nil
 }
 
 func (j *JobRegisterPlan) Get(req *store.JobRegisterPlanGetReq) (*store.JobRegisterPlanGetResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+	var reply store.JobRegisterPlanGetResp
 
-	path := filepath.Join(j.store.jobRegPlanDir, req.ID.String()+".json")
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-	var decodedPlan domain.JobRegisterPlan
+		path := filepath.Join(j.store.jobRegPlanDir, req.ID.String()+".json")
 
-	if code, err := getStoreFile(path, &decodedPlan); err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		var decodedPlan domain.JobRegisterPlan
+
+		if code, err := getStoreFile(path, &decodedPlan); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := j.store.queryIndex(jobRegPlanDir)
+
+		reply = store.JobRegisterPlanGetResp{
+			Plan:      &decodedPlan,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	return &store.JobRegisterPlanGetResp{Plan: &decodedPlan}, nil
+	return &reply, nil
 }
 
-func (j *JobRegisterPlan) List(_ *store.JobRegisterPlanListReq) (*store.JobRegisterPlanListResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+func (j *JobRegisterPlan) List(req *store.JobRegisterPlanListReq) (*store.JobRegisterPlanListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.JobRegisterPlanListResp
+	)
 
-	var resp store.JobRegisterPlanListResp
+	if req != nil {
+		opts = &req.QueryOptions
+	}
 
-	err := listStoreFiles(j.store.jobRegPlanDir, func(bytes []byte) error {
-		var decodedPlan domain.JobRegisterPlan
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-		if err := json.Unmarshal(bytes, &decodedPlan); err != nil {
-			return err
-		}
+		index, waitFn := j.store.queryIndex(jobRegPlanDir)
+		resp = store.JobRegisterPlanListResp{QueryMeta: store.QueryMeta{Index: index}}
 
-		resp.Plans = append(resp.Plans, &decodedPlan)
-		return nil
-	})
+		err := listStoreFiles(j.store.jobRegPlanDir, func(bytes []byte) error {
+			var decodedPlan domain.JobRegisterPlan
 
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+			if err := json.Unmarshal(bytes, &decodedPlan); err != nil {
+				return err
+			}
+
+			resp.Plans = append(resp.Plans, &decodedPlan)
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
+
 	return &resp, nil
 }
@@ -15,7 +15,7 @@ import (
 )
 
 func TestJobRegisterPlan_Create(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -35,7 +35,7 @@ func TestJobRegisterPlan_Create(t *testing.T) {
 }
 
 func TestJobRegisterPlan_Delete(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -57,11 +57,17 @@ func TestJobRegisterPlan_Delete(t *testing.T) {
 		&store.JobRegisterPlanDeleteReq{ID: mockPlan.ID},
 	)
 	must.NotNil(t, errResp2)
+	must.Eq(t, 404, errResp2.StatusCode())
 	must.Nil(t, deleteResp2)
+
+	// Deleting an object which does not exist must not advance the index.
+	listResp, errResp := testState.JobRegister().Plan().List(&store.JobRegisterPlanListReq{})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, listResp.Index)
 }
 
 func TestJobRegisterPlan_Get(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -83,7 +89,7 @@ func TestJobRegisterPlan_Get(t *testing.T) {
 }
 
 func TestJobRegisterPlan_List(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -4,6 +4,7 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,10 +24,19 @@ func (j *JobRegisterRule) Create(req *store.JobRegisterRuleCreateReq) (*store.Jo
 
 	path := filepath.Join(j.store.jobRegRuleDir, req.Rule.Name+".json")
 
+	index, errResp := j.store.nextIndex(jobRegRuleDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Rule.Metadata = req.Rule.Metadata.WithModifyIndex(index)
+
 	if code, err := createStoreFile(path, req.Rule); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterRuleCreateResp{Rule: req.Rule}, nil
 }
 
@@ -36,47 +46,128 @@ func (j *JobRegisterRule) Delete(req *store.JobRegisterRuleDeleteReq) (*store.Jo
 
 	path := filepath.Join(j.store.jobRegRuleDir, req.Name+".json")
 
+	// Read the existing object before advancing the index, so deleting an
+	// object which does not exist is rejected without a write.
+	var existingRule domain.JobRegisterRule
+
+	if code, err := getStoreFile(path, &existingRule); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingRule.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := j.store.nextIndex(jobRegRuleDir); errResp != nil {
+		return nil, errResp
+	}
+
 	if err := os.Remove(path); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
 	}
 
+	j.store.notifyChange()
+
 	return &store.JobRegisterRuleDeleteResp{}, nil
 }
 
 func (j *JobRegisterRule) Get(req *store.JobRegisterRuleGetReq) (*store.JobRegisterRuleGetResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+	var reply store.JobRegisterRuleGetResp
 
-	path := filepath.Join(j.store.jobRegRuleDir, req.Name+".json")
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-	var decodedRule domain.JobRegisterRule
+		path := filepath.Join(j.store.jobRegRuleDir, req.Name+".json")
 
-	if code, err := getStoreFile(path, &decodedRule); err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		var decodedRule domain.JobRegisterRule
+
+		if code, err := getStoreFile(path, &decodedRule); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := j.store.queryIndex(jobRegRuleDir)
+
+		reply = store.JobRegisterRuleGetResp{
+			Rule:      &decodedRule,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	return &store.JobRegisterRuleGetResp{Rule: &decodedRule}, nil
+	return &reply, nil
 }
 
-func (j *JobRegisterRule) List(_ *store.JobRegisterRuleListReq) (*store.JobRegisterRuleListResp, *store.ErrorResp) {
-	j.store.lock.RLock()
-	defer j.store.lock.RUnlock()
+func (j *JobRegisterRule) List(req *store.JobRegisterRuleListReq) (*store.JobRegisterRuleListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.JobRegisterRuleListResp
+	)
 
-	var resp store.JobRegisterRuleListResp
+	if req != nil {
+		opts = &req.QueryOptions
+	}
 
-	err := listStoreFiles(j.store.jobRegRuleDir, func(bytes []byte) error {
-		var decodedRule domain.JobRegisterRule
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
 
-		if err := json.Unmarshal(bytes, &decodedRule); err != nil {
-			return err
-		}
+		index, waitFn := j.store.queryIndex(jobRegRuleDir)
+		resp = store.JobRegisterRuleListResp{QueryMeta: store.QueryMeta{Index: index}}
 
-		resp.Rules = append(resp.Rules, &decodedRule)
-		return nil
-	})
+		err := listStoreFiles(j.store.jobRegRuleDir, func(bytes []byte) error {
+			var decodedRule domain.JobRegisterRule
 
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+			if err := json.Unmarshal(bytes, &decodedRule); err != nil {
+				return err
+			}
+
+			resp.Rules = append(resp.Rules, &decodedRule)
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
+
 	return &resp, nil
 }
+
+func (j *JobRegisterRule) Update(req *store.JobRegisterRuleUpdateReq) (*store.JobRegisterRuleUpdateResp, *store.ErrorResp) {
+	j.store.lock.Lock()
+	defer j.store.lock.Unlock()
+
+	path := filepath.Join(j.store.jobRegRuleDir, req.Rule.Name+".json")
+
+	var existingRule domain.JobRegisterRule
+
+	if code, err := getStoreFile(path, &existingRule); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingRule.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	index, errResp := j.store.nextIndex(jobRegRuleDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Rule.Metadata = existingRule.Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
+
+	if code, err := updateStoreFile(path, req.Rule); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	j.store.notifyChange()
+
+	return &store.JobRegisterRuleUpdateResp{Rule: req.Rule}, nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func TestJobRegisterRule_Create(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -34,7 +34,7 @@ func TestJobRegisterRule_Create(t *testing.T) {
 }
 
 func TestJobRegisterRule_Delete(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -56,11 +56,17 @@ func TestJobRegisterRule_Delete(t *testing.T) {
 		&store.JobRegisterRuleDeleteReq{Name: mockRule.Name},
 	)
 	must.NotNil(t, errResp2)
+	must.Eq(t, 404, errResp2.StatusCode())
 	must.Nil(t, deleteResp2)
+
+	// Deleting an object which does not exist must not advance the index.
+	listResp, errResp := testState.JobRegister().Rule().List(&store.JobRegisterRuleListReq{})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, listResp.Index)
 }
 
 func TestJobRegisterRule_Get(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -82,7 +88,7 @@ func TestJobRegisterRule_Get(t *testing.T) {
 }
 
 func TestJobRegisterRule_List(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -105,3 +111,36 @@ func TestJobRegisterRule_List(t *testing.T) {
 	must.Nil(t, err)
 	must.SliceContainsAll(t, listResp2.Rules, mockRules)
 }
+
+func TestJobRegisterRule_Update(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockRule := mock.JobRegistrationRule()
+
+	updateResp1, errResp1 := testState.JobRegister().Rule().Update(&store.JobRegisterRuleUpdateReq{Rule: mockRule})
+	must.NotNil(t, errResp1)
+	must.Eq(t, 404, errResp1.StatusCode())
+	must.Nil(t, updateResp1)
+
+	mockRule.Metadata = domain.NewMetadata()
+
+	createResp1, errResp2 := testState.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: mockRule})
+	must.Nil(t, errResp2)
+	must.Eq(t, mockRule, createResp1.Rule)
+
+	updatedRule := mock.JobRegistrationRule()
+	updatedRule.Name = mockRule.Name
+	updatedRule.RegionContexts = []domain.JobRegisterRuleRegionContext{{Kind: domain.JobRegisterRuleContextKindNodepool}}
+
+	updateResp2, errResp3 := testState.JobRegister().Rule().Update(&store.JobRegisterRuleUpdateReq{Rule: updatedRule})
+	must.Nil(t, errResp3)
+	must.Eq(t, updatedRule, updateResp2.Rule)
+	must.Eq(t, mockRule.Metadata.CreateTime.UTC(), updateResp2.Rule.Metadata.CreateTime.UTC())
+	must.True(t, updateResp2.Rule.Metadata.UpdateTime.After(mockRule.Metadata.UpdateTime))
+
+	getResp, errResp4 := testState.JobRegister().Rule().Get(&store.JobRegisterRuleGetReq{Name: mockRule.Name})
+	must.Nil(t, errResp4)
+	must.Eq(t, updatedRule.RegionContexts, getResp.Rule.RegionContexts)
+}
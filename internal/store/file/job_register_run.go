@@ -0,0 +1,112 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type JobRegisterRun struct {
+	store *Store
+}
+
+func (j *JobRegisterRun) Create(req *store.JobRegisterRunCreateReq) (*store.JobRegisterRunCreateResp, *store.ErrorResp) {
+	j.store.lock.Lock()
+	defer j.store.lock.Unlock()
+
+	path := filepath.Join(j.store.jobRegRunDir, req.Run.ID.String()+".json")
+
+	index, errResp := j.store.nextIndex(jobRegRunDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Run.Metadata = req.Run.Metadata.WithModifyIndex(index)
+
+	if code, err := createStoreFile(path, req.Run); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	j.store.notifyChange()
+
+	return &store.JobRegisterRunCreateResp{Run: req.Run}, nil
+}
+
+func (j *JobRegisterRun) Get(req *store.JobRegisterRunGetReq) (*store.JobRegisterRunGetResp, *store.ErrorResp) {
+	var reply store.JobRegisterRunGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
+
+		path := filepath.Join(j.store.jobRegRunDir, req.ID.String()+".json")
+
+		var decodedRun domain.JobRegisterPlanRun
+
+		if code, err := getStoreFile(path, &decodedRun); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		index, waitFn := j.store.queryIndex(jobRegRunDir)
+
+		reply = store.JobRegisterRunGetResp{
+			Run:       &decodedRun,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (j *JobRegisterRun) List(req *store.JobRegisterRunListReq) (*store.JobRegisterRunListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.JobRegisterRunListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		j.store.lock.RLock()
+		defer j.store.lock.RUnlock()
+
+		index, waitFn := j.store.queryIndex(jobRegRunDir)
+		resp = store.JobRegisterRunListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		err := listStoreFiles(j.store.jobRegRunDir, func(bytes []byte) error {
+			var decodedRun domain.JobRegisterPlanRun
+
+			if err := json.Unmarshal(bytes, &decodedRun); err != nil {
+				return err
+			}
+
+			if req.Matches(&decodedRun) {
+				resp.Runs = append(resp.Runs, &decodedRun)
+			}
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &resp, nil
+}
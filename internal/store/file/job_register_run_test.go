@@ -0,0 +1,122 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestJobRegisterRun_Create(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockRun := mock.JobRegistrationRun()
+
+	createResp1, errResp1 := testState.JobRegister().Run().Create(
+		&store.JobRegisterRunCreateReq{Run: mockRun},
+	)
+	must.Nil(t, errResp1)
+	must.Eq(t, mockRun, createResp1.Run)
+
+	createResp2, errResp2 := testState.JobRegister().Run().Create(
+		&store.JobRegisterRunCreateReq{Run: mockRun},
+	)
+	must.NotNil(t, errResp2)
+	must.Nil(t, createResp2)
+}
+
+func TestJobRegisterRun_Get(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	getResp1, err := testState.JobRegister().Run().Get(&store.JobRegisterRunGetReq{ID: ulid.Make()})
+	must.Error(t, err)
+	must.Nil(t, getResp1)
+
+	mockRun := mock.JobRegistrationRun()
+	mockRun.Regions["euw1"].Error = "failed to register job"
+	mockRun.Error = "region \"euw1\": failed to register job"
+
+	createResp1, errResp1 := testState.JobRegister().Run().Create(
+		&store.JobRegisterRunCreateReq{Run: mockRun},
+	)
+	must.Nil(t, errResp1)
+	must.Eq(t, mockRun, createResp1.Run)
+
+	getResp2, err := testState.JobRegister().Run().Get(&store.JobRegisterRunGetReq{ID: mockRun.ID})
+	must.Nil(t, err)
+	must.Eq(t, mockRun, getResp2.Run)
+}
+
+func TestJobRegisterRun_List(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	listResp1, err := testState.JobRegister().Run().List(nil)
+	must.Nil(t, err)
+	must.Len(t, 0, listResp1.Runs)
+
+	mockRuns := make([]*domain.JobRegisterPlanRun, 4)
+
+	for i := range mockRuns {
+		mockRuns[i] = mock.JobRegistrationRun()
+		if i%2 == 1 {
+			mockRuns[i].JobID = "other"
+		}
+		if i > 1 {
+			mockRuns[i].JobNamespace = "platform"
+		}
+
+		createResp, err := testState.JobRegister().Run().Create(
+			&store.JobRegisterRunCreateReq{Run: mockRuns[i]},
+		)
+		must.Nil(t, err)
+		must.NotNil(t, createResp)
+	}
+
+	testCases := []struct {
+		name         string
+		inputReq     *store.JobRegisterRunListReq
+		expectedRuns []*domain.JobRegisterPlanRun
+	}{
+		{
+			name:         "no filter",
+			inputReq:     nil,
+			expectedRuns: mockRuns,
+		},
+		{
+			name:         "job ID",
+			inputReq:     &store.JobRegisterRunListReq{JobID: "example"},
+			expectedRuns: []*domain.JobRegisterPlanRun{mockRuns[0], mockRuns[2]},
+		},
+		{
+			name:         "namespace",
+			inputReq:     &store.JobRegisterRunListReq{JobNamespace: "platform"},
+			expectedRuns: []*domain.JobRegisterPlanRun{mockRuns[2], mockRuns[3]},
+		},
+		{
+			name:         "job ID and namespace",
+			inputReq:     &store.JobRegisterRunListReq{JobID: "other", JobNamespace: "default"},
+			expectedRuns: []*domain.JobRegisterPlanRun{mockRuns[1]},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listResp, err := testState.JobRegister().Run().List(tc.inputReq)
+			must.Nil(t, err)
+			must.SliceContainsAll(t, listResp.Runs, tc.expectedRuns)
+		})
+	}
+}
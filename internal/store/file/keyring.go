@@ -0,0 +1,278 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// KeyringKeySize is the required length in bytes of each keyring key, which
+// selects AES-256.
+const KeyringKeySize = 32
+
+// maxKeyIDLength is the longest key ID which can be used as the active key, as
+// its length is stored within a single byte of values returned by Encrypt.
+const maxKeyIDLength = 255
+
+// Keyring holds the key encryption keys used to protect sensitive object
+// fields written to disk. Each object is encrypted with its own random data
+// key, which is then encrypted with the active key and stored alongside the
+// object. Inactive keys are retained, so objects written before a rotation can
+// still be decrypted.
+type Keyring struct {
+	activeID string
+	keys     map[string]cipher.AEAD
+}
+
+// NewKeyring builds a keyring from the passed keys, which are keyed by their
+// ID. The activeID must identify one of the keys and is used for all new
+// encryption.
+func NewKeyring(activeID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key %q not found", activeID)
+	}
+	if len(activeID) > maxKeyIDLength {
+		return nil, fmt.Errorf("active key ID must not exceed %d bytes", maxKeyIDLength)
+	}
+
+	k := Keyring{
+		activeID: activeID,
+		keys:     make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+
+	return &k, nil
+}
+
+// envelope is stored alongside an encrypted object and details the data key
+// used to encrypt its sensitive fields. The data key is encrypted with the
+// keyring key identified by KeyID.
+type envelope struct {
+	KeyID   string `json:"key_id"`
+	DataKey string `json:"data_key"`
+}
+
+// envelopeDataKeyField is the field name bound to the encrypted data key of
+// each envelope.
+const envelopeDataKeyField = "envelope.data_key"
+
+// seal generates a new data key and returns it along with the envelope which
+// should be stored with the object it encrypts. The kind and name identify the
+// object, so the envelope cannot be moved to another object.
+func (k *Keyring) seal(kind, name string) (*envelope, cipher.AEAD, error) {
+	dataKey := make([]byte, KeyringKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrappedKey, err := encryptValue(k.keys[k.activeID], dataKey,
+		keyAdditionalData(k.activeID, objectAdditionalData(kind, name, envelopeDataKeyField)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt data key: %w", err)
+	}
+
+	return &envelope{KeyID: k.activeID, DataKey: wrappedKey}, aead, nil
+}
+
+// open decrypts the data key held within the envelope of the object identified
+// by the kind and name.
+func (k *Keyring) open(env *envelope, kind, name string) (cipher.AEAD, error) {
+	keyAEAD, ok := k.keys[env.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("keyring key %q not found", env.KeyID)
+	}
+
+	dataKey, err := decryptValue(keyAEAD, env.DataKey,
+		keyAdditionalData(env.KeyID, objectAdditionalData(kind, name, envelopeDataKeyField)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+
+	return newAEAD(dataKey)
+}
+
+// Encrypt encrypts the plaintext directly with the active key, for callers
+// which protect whole payloads rather than individual object fields. The
+// additional data is authenticated but not encrypted, and the same value must
+// be passed to Decrypt. The returned value records the ID of the key used, so
+// it can still be decrypted once another key is made active.
+func (k *Keyring) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	aead := k.keys[k.activeID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 1+len(k.activeID)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, byte(len(k.activeID)))
+	out = append(out, k.activeID...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plaintext, keyAdditionalData(k.activeID, additionalData)), nil
+}
+
+// Decrypt reverses Encrypt, using the keyring key recorded within the value.
+func (k *Keyring) Decrypt(value, additionalData []byte) ([]byte, error) {
+	if len(value) < 1 || len(value) < 1+int(value[0]) {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	keyID := string(value[1 : 1+int(value[0])])
+	sealed := value[1+len(keyID):]
+
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("keyring key %q not found", keyID)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, keyAdditionalData(keyID, additionalData))
+}
+
+// keyAdditionalData prefixes the additional data with the key ID, so the key
+// ID recorded within an encrypted value cannot be modified.
+func keyAdditionalData(keyID string, additionalData []byte) []byte {
+	out := make([]byte, 0, len(keyID)+1+len(additionalData))
+	out = append(out, keyID...)
+	out = append(out, 0)
+	return append(out, additionalData...)
+}
+
+// objectAdditionalData returns the data authenticated alongside an encrypted
+// field of an object. It binds the ciphertext to the kind and name of the
+// object, as well as the field, so it cannot be moved between objects or
+// fields without detection.
+func objectAdditionalData(kind, name, field string) []byte {
+	out := make([]byte, 0, len(kind)+len(name)+len(field)+2)
+	out = append(out, kind...)
+	out = append(out, 0)
+	out = append(out, name...)
+	out = append(out, 0)
+	return append(out, field...)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeyringKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeyringKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptValue encrypts the plaintext and returns the nonce and ciphertext as a
+// single base64 encoded string, so it can be stored within a JSON string field.
+func encryptValue(aead cipher.AEAD, plaintext, additionalData []byte) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptValue reverses encryptValue.
+func decryptValue(aead cipher.AEAD, value string, additionalData []byte) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// RotateKeyring re-encrypts the sensitive fields of every object within the
+// state directory using the active key of the passed keyring, so keys which
+// are no longer active can be removed. Objects written before the keyring was
+// configured are encrypted for the first time. It returns the number of
+// objects rewritten.
+//
+// The function does not coordinate with other processes, so must not be run
+// while an Attila server is using the state directory.
+func RotateKeyring(dir string, keyring *Keyring) (int, error) {
+	if keyring == nil {
+		return 0, errors.New("keyring required")
+	}
+
+	s, err := newStore(dir, keyring)
+	if err != nil {
+		return 0, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := os.ReadDir(s.regionDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read dir: %w", err)
+	}
+
+	var rotated int
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(s.regionDir, entry.Name())
+
+		var existingRegion regionFile
+
+		if _, err := getStoreFile(path, &existingRegion); err != nil {
+			return rotated, fmt.Errorf("failed to read %q: %w", path, err)
+		}
+
+		decodedRegion, err := s.decodeRegion(&existingRegion)
+		if err != nil {
+			return rotated, err
+		}
+
+		encodedRegion, err := s.encodeRegion(decodedRegion)
+		if err != nil {
+			return rotated, err
+		}
+
+		// The modify index is not changed, as the region itself has not been
+		// modified and clients should not observe a write.
+		if _, err := updateStoreFile(path, encodedRegion); err != nil {
+			return rotated, fmt.Errorf("failed to write %q: %w", path, err)
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
@@ -0,0 +1,223 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring("k2", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.ErrorContains(t, err, `active key "k2" not found`)
+
+	_, err = NewKeyring("k1", map[string][]byte{"k1": []byte("short")})
+	must.ErrorContains(t, err, "key must be 32 bytes")
+
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.NoError(t, err)
+	must.NotNil(t, keyring)
+}
+
+func TestKeyring_Encrypt(t *testing.T) {
+	keyring1, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	plaintext := []byte("very-secret-token")
+
+	encrypted, err := keyring1.Encrypt(plaintext, []byte("aad"))
+	must.NoError(t, err)
+	must.False(t, bytes.Contains(encrypted, plaintext))
+
+	decrypted, err := keyring1.Decrypt(encrypted, []byte("aad"))
+	must.NoError(t, err)
+	must.Eq(t, plaintext, decrypted)
+
+	// The additional data must match, and modifications to the value must be
+	// detected.
+	_, err = keyring1.Decrypt(encrypted, []byte("other"))
+	must.Error(t, err)
+
+	tampered := bytes.Clone(encrypted)
+	tampered[len(tampered)-1] ^= 1
+	_, err = keyring1.Decrypt(tampered, []byte("aad"))
+	must.Error(t, err)
+
+	_, err = keyring1.Decrypt(encrypted[:3], []byte("aad"))
+	must.Error(t, err)
+
+	// Once rotated, values encrypted with the previous key can be decrypted
+	// while it is retained within the keyring.
+	keyring2, err := NewKeyring("k2", map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, KeyringKeySize),
+		"k2": bytes.Repeat([]byte{2}, KeyringKeySize),
+	})
+	must.NoError(t, err)
+
+	decrypted, err = keyring2.Decrypt(encrypted, []byte("aad"))
+	must.NoError(t, err)
+	must.Eq(t, plaintext, decrypted)
+
+	keyring3, err := NewKeyring("k2", map[string][]byte{"k2": bytes.Repeat([]byte{2}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	_, err = keyring3.Decrypt(encrypted, []byte("aad"))
+	must.ErrorContains(t, err, `keyring key "k1" not found`)
+}
+
+func TestRegion_Keyring(t *testing.T) {
+	dir := t.TempDir()
+
+	keyring1, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	testState, err := New(dir, keyring1)
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	_, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	// The secrets must not be written to disk in plaintext, but all other
+	// fields should be untouched.
+	regionPath := filepath.Join(dir, regionDir, mockRegion.Name+".json")
+
+	fileBytes, err := os.ReadFile(regionPath)
+	must.NoError(t, err)
+	must.StrNotContains(t, string(fileBytes), mockRegion.Auth.Token)
+	must.StrNotContains(t, string(fileBytes), mockRegion.TLS.ClientKey)
+	must.StrContains(t, string(fileBytes), mockRegion.Name)
+
+	getResp, errResp := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion, getResp.Region)
+
+	// A store without the keyring cannot read the encrypted region.
+	plainState, err := New(dir, nil)
+	must.NoError(t, err)
+
+	_, errResp = plainState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.NotNil(t, errResp)
+
+	// Rotate to a new key and ensure the region can be read once the old key
+	// has been removed from the keyring.
+	keyring2, err := NewKeyring("k2", map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, KeyringKeySize),
+		"k2": bytes.Repeat([]byte{2}, KeyringKeySize),
+	})
+	must.NoError(t, err)
+
+	num, err := RotateKeyring(dir, keyring2)
+	must.NoError(t, err)
+	must.Eq(t, 1, num)
+
+	keyring3, err := NewKeyring("k2", map[string][]byte{"k2": bytes.Repeat([]byte{2}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	rotatedState, err := New(dir, keyring3)
+	must.NoError(t, err)
+
+	getResp, errResp = rotatedState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion, getResp.Region)
+
+	listResp, errResp := rotatedState.Region().List(nil)
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion, listResp.Regions[0])
+}
+
+func TestRotateKeyring_Plaintext(t *testing.T) {
+	dir := t.TempDir()
+
+	// Regions written before the keyring was configured should be readable
+	// once it is, and encrypted by a rotation.
+	plainState, err := New(dir, nil)
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	_, errResp := plainState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	encryptedState, err := New(dir, keyring)
+	must.NoError(t, err)
+
+	getResp, errResp := encryptedState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion, getResp.Region)
+
+	num, err := RotateKeyring(dir, keyring)
+	must.NoError(t, err)
+	must.Eq(t, 1, num)
+
+	fileBytes, err := os.ReadFile(filepath.Join(dir, regionDir, mockRegion.Name+".json"))
+	must.NoError(t, err)
+	must.StrNotContains(t, string(fileBytes), mockRegion.Auth.Token)
+
+	getResp, errResp = encryptedState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion, getResp.Region)
+}
+
+func TestRegion_KeyringAdditionalData(t *testing.T) {
+	dir := t.TempDir()
+
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, KeyringKeySize)})
+	must.NoError(t, err)
+
+	testState, err := New(dir, keyring)
+	must.NoError(t, err)
+
+	mockRegion1, mockRegion2 := mock.Region(), mock.Region()
+
+	for _, mockRegion := range []*domain.Region{mockRegion1, mockRegion2} {
+		_, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+		must.Nil(t, errResp)
+	}
+
+	regionPath := func(name string) string { return filepath.Join(dir, regionDir, name+".json") }
+
+	readRegionFile := func(name string) *regionFile {
+		var file regionFile
+		_, err := getStoreFile(regionPath(name), &file)
+		must.NoError(t, err)
+		return &file
+	}
+
+	// Moving the envelope and encrypted fields of one region into another
+	// must be detected, as they are bound to the region name.
+	region1File, region2File := readRegionFile(mockRegion1.Name), readRegionFile(mockRegion2.Name)
+
+	region2File.Envelope = region1File.Envelope
+	region2File.Auth = region1File.Auth
+	region2File.TLS = region1File.TLS
+
+	_, err = updateStoreFile(regionPath(mockRegion2.Name), region2File)
+	must.NoError(t, err)
+
+	_, errResp := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion2.Name})
+	must.NotNil(t, errResp)
+
+	// Swapping the encrypted fields of a region must be detected, as they are
+	// bound to the field.
+	region1File.Auth.Token, region1File.TLS.ClientKey = region1File.TLS.ClientKey, region1File.Auth.Token
+
+	_, err = updateStoreFile(regionPath(mockRegion1.Name), region1File)
+	must.NoError(t, err)
+
+	_, errResp = testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion1.Name})
+	must.NotNil(t, errResp)
+}
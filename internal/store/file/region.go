@@ -4,6 +4,7 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,10 +24,24 @@ func (r *Region) Create(req *store.RegionCreateReq) (*store.RegionCreateResp, *s
 
 	path := filepath.Join(r.store.regionDir, req.Region.Name+".json")
 
-	if code, err := createStoreFile(path, req.Region); err != nil {
+	index, errResp := r.store.nextIndex(regionDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Region.Metadata = req.Region.Metadata.WithModifyIndex(index)
+
+	encodedRegion, err := r.store.encodeRegion(req.Region)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+
+	if code, err := createStoreFile(path, encodedRegion); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
 	}
 
+	r.store.notifyChange()
+
 	return &store.RegionCreateResp{Region: req.Region}, nil
 }
 
@@ -36,47 +51,234 @@ func (r *Region) Delete(req *store.RegionDeleteReq) (*store.RegionDeleteResp, *s
 
 	path := filepath.Join(r.store.regionDir, req.RegionName+".json")
 
+	// Read the existing object before advancing the index, so deleting an
+	// object which does not exist is rejected without a write.
+	var existingRegion regionFile
+
+	if code, err := getStoreFile(path, &existingRegion); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+	if errResp := store.CheckIndex(req.CheckIndex, existingRegion.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, errResp := r.store.nextIndex(regionDir); errResp != nil {
+		return nil, errResp
+	}
+
 	if err := os.Remove(path); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
 	}
 
+	r.store.notifyChange()
+
 	return &store.RegionDeleteResp{}, nil
 }
 
 func (r *Region) Get(req *store.RegionGetReq) (*store.RegionGetResp, *store.ErrorResp) {
-	r.store.lock.RLock()
-	defer r.store.lock.RUnlock()
+	var reply store.RegionGetResp
 
-	path := filepath.Join(r.store.regionDir, req.RegionName+".json")
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		r.store.lock.RLock()
+		defer r.store.lock.RUnlock()
 
-	var decodedRegion domain.Region
+		path := filepath.Join(r.store.regionDir, req.RegionName+".json")
 
-	if code, err := getStoreFile(path, &decodedRegion); err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		var encodedRegion regionFile
+
+		if code, err := getStoreFile(path, &encodedRegion); err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+		}
+
+		decodedRegion, err := r.store.decodeRegion(&encodedRegion)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+
+		index, waitFn := r.store.queryIndex(regionDir)
+
+		reply = store.RegionGetResp{
+			Region:    decodedRegion,
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, waitFn, nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	return &store.RegionGetResp{Region: &decodedRegion}, nil
+	return &reply, nil
 }
 
-func (r *Region) List(_ *store.RegionListReq) (*store.RegionListResp, *store.ErrorResp) {
-	r.store.lock.RLock()
-	defer r.store.lock.RUnlock()
+func (r *Region) List(req *store.RegionListReq) (*store.RegionListResp, *store.ErrorResp) {
+	var (
+		opts *store.QueryOptions
+		resp store.RegionListResp
+	)
 
-	var resp store.RegionListResp
+	if req != nil {
+		opts = &req.QueryOptions
+	}
 
-	err := listStoreFiles(r.store.regionDir, func(bytes []byte) error {
-		var decodedRegion domain.Region
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		r.store.lock.RLock()
+		defer r.store.lock.RUnlock()
 
-		if err := json.Unmarshal(bytes, &decodedRegion); err != nil {
-			return err
-		}
+		index, waitFn := r.store.queryIndex(regionDir)
+		resp = store.RegionListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		err := listStoreFiles(r.store.regionDir, func(bytes []byte) error {
+			var encodedRegion regionFile
 
-		resp.Regions = append(resp.Regions, &decodedRegion)
-		return nil
+			if err := json.Unmarshal(bytes, &encodedRegion); err != nil {
+				return err
+			}
+
+			decodedRegion, err := r.store.decodeRegion(&encodedRegion)
+			if err != nil {
+				return err
+			}
+
+			resp.Regions = append(resp.Regions, decodedRegion)
+			return nil
+		})
+
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+		}
+		return index, waitFn, nil
 	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &resp, nil
+}
+
+func (r *Region) Update(req *store.RegionUpdateReq) (*store.RegionUpdateResp, *store.ErrorResp) {
+	r.store.lock.Lock()
+	defer r.store.lock.Unlock()
+
+	path := filepath.Join(r.store.regionDir, req.Region.Name+".json")
+
+	var existingRegion regionFile
+
+	if code, err := getStoreFile(path, &existingRegion); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingRegion.Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	index, errResp := r.store.nextIndex(regionDir)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	req.Region.Metadata = existingRegion.Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
 
+	encodedRegion, err := r.store.encodeRegion(req.Region)
 	if err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
 	}
-	return &resp, nil
+
+	if code, err := updateStoreFile(path, encodedRegion); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), code)
+	}
+
+	r.store.notifyChange()
+
+	return &store.RegionUpdateResp{Region: req.Region}, nil
+}
+
+// regionFile is the on-disk representation of a region. When the store has a
+// keyring, the secret fields of the region hold ciphertext and the envelope
+// details the data key required to decrypt them.
+type regionFile struct {
+	*domain.Region
+	Envelope *envelope `json:"envelope,omitempty"`
+}
+
+// The object kind and field names bound to each encrypted region field, so
+// ciphertext cannot be moved between regions or fields without detection.
+const (
+	regionKind              = "region"
+	regionAuthTokenField    = "auth.token"
+	regionTLSClientKeyField = "tls.client_key"
+)
+
+// encodeRegion returns the on-disk representation of the passed region. The
+// region is not modified, so the caller can continue to use the plaintext
+// object.
+func (s *Store) encodeRegion(region *domain.Region) (*regionFile, error) {
+	if s.keyring == nil {
+		return &regionFile{Region: region}, nil
+	}
+
+	env, aead, err := s.keyring.seal(regionKind, region.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := *region
+
+	if region.Auth != nil && region.Auth.Token != "" {
+		auth := *region.Auth
+		if auth.Token, err = encryptValue(aead, []byte(auth.Token),
+			objectAdditionalData(regionKind, region.Name, regionAuthTokenField)); err != nil {
+			return nil, fmt.Errorf("failed to encrypt auth token: %w", err)
+		}
+		encoded.Auth = &auth
+	}
+
+	if region.TLS != nil && region.TLS.ClientKey != "" {
+		tls := *region.TLS
+		if tls.ClientKey, err = encryptValue(aead, []byte(tls.ClientKey),
+			objectAdditionalData(regionKind, region.Name, regionTLSClientKeyField)); err != nil {
+			return nil, fmt.Errorf("failed to encrypt TLS client key: %w", err)
+		}
+		encoded.TLS = &tls
+	}
+
+	return &regionFile{Region: &encoded, Envelope: env}, nil
+}
+
+// decodeRegion returns the plaintext region from its on-disk representation.
+// Regions written before the keyring was configured do not have an envelope
+// and are returned as-is.
+func (s *Store) decodeRegion(file *regionFile) (*domain.Region, error) {
+	if file.Envelope == nil {
+		return file.Region, nil
+	}
+	if s.keyring == nil {
+		return nil, fmt.Errorf("region %q is encrypted but no keyring is configured", file.Name)
+	}
+
+	aead, err := s.keyring.open(file.Envelope, regionKind, file.Name)
+	if err != nil {
+		return nil, fmt.Errorf("region %q: %w", file.Name, err)
+	}
+
+	region := file.Region
+
+	if region.Auth != nil && region.Auth.Token != "" {
+		token, err := decryptValue(aead, region.Auth.Token,
+			objectAdditionalData(regionKind, region.Name, regionAuthTokenField))
+		if err != nil {
+			return nil, fmt.Errorf("region %q: failed to decrypt auth token: %w", file.Name, err)
+		}
+		region.Auth.Token = string(token)
+	}
+
+	if region.TLS != nil && region.TLS.ClientKey != "" {
+		clientKey, err := decryptValue(aead, region.TLS.ClientKey,
+			objectAdditionalData(regionKind, region.Name, regionTLSClientKeyField))
+		if err != nil {
+			return nil, fmt.Errorf("region %q: failed to decrypt TLS client key: %w", file.Name, err)
+		}
+		region.TLS.ClientKey = string(clientKey)
+	}
+
+	return region, nil
 }
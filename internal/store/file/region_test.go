@@ -5,6 +5,7 @@ package file
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shoenig/test/must"
 
@@ -14,7 +15,7 @@ import (
 )
 
 func TestRegion_Create(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -30,7 +31,7 @@ func TestRegion_Create(t *testing.T) {
 }
 
 func TestRegion_Delete(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -50,11 +51,17 @@ func TestRegion_Delete(t *testing.T) {
 		&store.RegionDeleteReq{RegionName: mockRegion.Name},
 	)
 	must.NotNil(t, errResp2)
+	must.Eq(t, 404, errResp2.StatusCode())
 	must.Nil(t, deleteResp2)
+
+	// Deleting an object which does not exist must not advance the index.
+	listResp, errResp := testState.Region().List(&store.RegionListReq{})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, listResp.Index)
 }
 
 func TestRegion_Get(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -74,7 +81,7 @@ func TestRegion_Get(t *testing.T) {
 }
 
 func TestRegion_List(t *testing.T) {
-	testState, err := New(t.TempDir())
+	testState, err := New(t.TempDir(), nil)
 	must.NoError(t, err)
 	must.NotNil(t, testState)
 
@@ -95,3 +102,120 @@ func TestRegion_List(t *testing.T) {
 	must.Nil(t, err)
 	must.SliceContainsAll(t, listResp2.Regions, mockRegions)
 }
+
+func TestRegion_Update(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockRegion := mock.Region()
+
+	updateResp1, errResp1 := testState.Region().Update(&store.RegionUpdateReq{Region: mockRegion})
+	must.NotNil(t, errResp1)
+	must.Eq(t, 404, errResp1.StatusCode())
+	must.Nil(t, updateResp1)
+
+	mockRegion.Metadata = domain.NewMetadata()
+
+	createResp1, errResp2 := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp2)
+	must.Eq(t, mockRegion, createResp1.Region)
+
+	updatedRegion := mock.Region()
+	updatedRegion.Name = mockRegion.Name
+	updatedRegion.API = []*domain.RegionAPI{{Address: "http://10.0.0.20:4646", Default: true}}
+
+	updateResp2, errResp3 := testState.Region().Update(&store.RegionUpdateReq{Region: updatedRegion})
+	must.Nil(t, errResp3)
+	must.Eq(t, updatedRegion, updateResp2.Region)
+	must.Eq(t, mockRegion.Metadata.CreateTime.UTC(), updateResp2.Region.Metadata.CreateTime.UTC())
+	must.True(t, updateResp2.Region.Metadata.UpdateTime.After(mockRegion.Metadata.UpdateTime))
+
+	getResp, errResp4 := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp4)
+	must.Eq(t, updatedRegion.API, getResp.Region.API)
+}
+
+func TestRegion_CheckIndex(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+	must.NotNil(t, testState)
+
+	mockRegion := mock.Region()
+
+	createResp, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, createResp.Region.Metadata.ModifyIndex)
+
+	staleIndex := uint64(0)
+	currentIndex := createResp.Region.Metadata.ModifyIndex
+
+	// A stale check index must not perform the update.
+	updateResp1, errResp := testState.Region().Update(
+		&store.RegionUpdateReq{Region: mockRegion, CheckIndex: &staleIndex},
+	)
+	must.NotNil(t, errResp)
+	must.Eq(t, 409, errResp.StatusCode())
+	must.Nil(t, updateResp1)
+
+	updateResp2, errResp := testState.Region().Update(
+		&store.RegionUpdateReq{Region: mockRegion, CheckIndex: &currentIndex},
+	)
+	must.Nil(t, errResp)
+	must.Eq(t, 2, updateResp2.Region.Metadata.ModifyIndex)
+
+	// The previously current index is now stale, so the delete must fail.
+	deleteResp1, errResp := testState.Region().Delete(
+		&store.RegionDeleteReq{RegionName: mockRegion.Name, CheckIndex: &currentIndex},
+	)
+	must.NotNil(t, errResp)
+	must.Eq(t, 409, errResp.StatusCode())
+	must.Nil(t, deleteResp1)
+
+	currentIndex = updateResp2.Region.Metadata.ModifyIndex
+
+	deleteResp2, errResp := testState.Region().Delete(
+		&store.RegionDeleteReq{RegionName: mockRegion.Name, CheckIndex: &currentIndex},
+	)
+	must.Nil(t, errResp)
+	must.NotNil(t, deleteResp2)
+}
+
+func TestRegion_BlockingQuery(t *testing.T) {
+	testState, err := New(t.TempDir(), nil)
+	must.NoError(t, err)
+
+	_, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+	must.Nil(t, errResp)
+
+	listResp, errResp := testState.Region().List(&store.RegionListReq{})
+	must.Nil(t, errResp)
+	must.Len(t, 1, listResp.Regions)
+	must.Eq(t, 1, listResp.Index)
+
+	// A blocking query at the current index should time out and return the
+	// unchanged data.
+	listResp, errResp = testState.Region().List(&store.RegionListReq{
+		QueryOptions: store.QueryOptions{MinIndex: 1, MaxWait: 50 * time.Millisecond},
+	})
+	must.Nil(t, errResp)
+	must.Len(t, 1, listResp.Regions)
+	must.Eq(t, 1, listResp.Index)
+
+	// A write to a different object type should not unblock the query, whereas
+	// a region write should.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = testState.JobRegister().Method().Create(
+			&store.JobRegisterMethodCreateReq{Method: mock.JobRegistrationMethod()})
+		time.Sleep(50 * time.Millisecond)
+		_, _ = testState.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+	}()
+
+	listResp, errResp = testState.Region().List(&store.RegionListReq{
+		QueryOptions: store.QueryOptions{MinIndex: 1, MaxWait: 5 * time.Second},
+	})
+	must.Nil(t, errResp)
+	must.Len(t, 2, listResp.Regions)
+	must.Eq(t, 3, listResp.Index)
+}
@@ -7,4 +7,5 @@ type JobRegisterState interface {
 	Plan() JobRegisterPlanState
 	Method() JobRegisterMethodState
 	Rule() JobRegisterRuleState
+	Run() JobRegisterRunState
 }
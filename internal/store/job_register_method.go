@@ -3,13 +3,18 @@
 
 package store
 
-import "github.com/rasorp/attila/internal/domain"
+import (
+	"time"
+
+	"github.com/rasorp/attila/internal/domain"
+)
 
 type JobRegisterMethodState interface {
 	Create(*JobRegisterMethodCreateReq) (*JobRegisterMethodCreateResp, *ErrorResp)
 	Delete(*JobRegisterMethodDeleteReq) (*JobRegisterMethodDeleteResp, *ErrorResp)
 	Get(*JobRegisterMethodGetReq) (*JobRegisterMethodGetResp, *ErrorResp)
 	List(*JobRegisterMethodListReq) (*JobRegisterMethodListResp, *ErrorResp)
+	Update(*JobRegisterMethodUpdateReq) (*JobRegisterMethodUpdateResp, *ErrorResp)
 }
 
 type JobRegisterMethodCreateReq struct {
@@ -22,20 +27,50 @@ type JobRegisterMethodCreateResp struct {
 
 type JobRegisterMethodDeleteReq struct {
 	Name string `json:"name"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
 }
 
 type JobRegisterMethodDeleteResp struct{}
 
 type JobRegisterMethodGetReq struct {
 	Name string `json:"name"`
+
+	QueryOptions `json:"-"`
 }
 
 type JobRegisterMethodGetResp struct {
 	Method *domain.JobRegisterMethod `json:"method"`
+
+	QueryMeta `json:"-"`
 }
 
-type JobRegisterMethodListReq struct{}
+type JobRegisterMethodListReq struct {
+	QueryOptions `json:"-"`
+}
 
 type JobRegisterMethodListResp struct {
 	Methods []*domain.JobRegisterMethod `json:"methods"`
+
+	QueryMeta `json:"-"`
+}
+
+type JobRegisterMethodUpdateReq struct {
+	Method *domain.JobRegisterMethod `json:"method"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
+
+	// UpdateTime is an optional time to record as the update time of the
+	// object. When zero, the current time is used. Replicated backends set
+	// this before the write is replicated, so every server records the same
+	// value.
+	UpdateTime time.Time `json:"update_time"`
+}
+
+type JobRegisterMethodUpdateResp struct {
+	Method *domain.JobRegisterMethod `json:"method"`
 }
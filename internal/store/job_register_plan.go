@@ -26,20 +26,32 @@ type JobRegisterPlanCreateResp struct {
 
 type JobRegisterPlanDeleteReq struct {
 	ID ulid.ULID `json:"id"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
 }
 
 type JobRegisterPlanDeleteResp struct{}
 
 type JobRegisterPlanGetReq struct {
 	ID ulid.ULID `json:"id"`
+
+	QueryOptions `json:"-"`
 }
 
 type JobRegisterPlanGetResp struct {
 	Plan *domain.JobRegisterPlan `json:"plan"`
+
+	QueryMeta `json:"-"`
 }
 
-type JobRegisterPlanListReq struct{}
+type JobRegisterPlanListReq struct {
+	QueryOptions `json:"-"`
+}
 
 type JobRegisterPlanListResp struct {
 	Plans []*domain.JobRegisterPlan `json:"plans"`
+
+	QueryMeta `json:"-"`
 }
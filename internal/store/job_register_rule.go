@@ -3,13 +3,18 @@
 
 package store
 
-import "github.com/rasorp/attila/internal/domain"
+import (
+	"time"
+
+	"github.com/rasorp/attila/internal/domain"
+)
 
 type JobRegisterRuleState interface {
 	Create(*JobRegisterRuleCreateReq) (*JobRegisterRuleCreateResp, *ErrorResp)
 	Delete(*JobRegisterRuleDeleteReq) (*JobRegisterRuleDeleteResp, *ErrorResp)
 	Get(*JobRegisterRuleGetReq) (*JobRegisterRuleGetResp, *ErrorResp)
 	List(*JobRegisterRuleListReq) (*JobRegisterRuleListResp, *ErrorResp)
+	Update(*JobRegisterRuleUpdateReq) (*JobRegisterRuleUpdateResp, *ErrorResp)
 }
 
 type JobRegisterRuleCreateReq struct {
@@ -22,20 +27,50 @@ type JobRegisterRuleCreateResp struct {
 
 type JobRegisterRuleDeleteReq struct {
 	Name string `json:"name"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
 }
 
 type JobRegisterRuleDeleteResp struct{}
 
 type JobRegisterRuleGetReq struct {
 	Name string `json:"name"`
+
+	QueryOptions `json:"-"`
 }
 
 type JobRegisterRuleGetResp struct {
 	Rule *domain.JobRegisterRule `json:"rule"`
+
+	QueryMeta `json:"-"`
 }
 
-type JobRegisterRuleListReq struct{}
+type JobRegisterRuleListReq struct {
+	QueryOptions `json:"-"`
+}
 
 type JobRegisterRuleListResp struct {
 	Rules []*domain.JobRegisterRule `json:"rules"`
+
+	QueryMeta `json:"-"`
+}
+
+type JobRegisterRuleUpdateReq struct {
+	Rule *domain.JobRegisterRule `json:"rule"`
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64 `json:"check_index"`
+
+	// UpdateTime is an optional time to record as the update time of the
+	// object. When zero, the current time is used. Replicated backends set
+	// this before the write is replicated, so every server records the same
+	// value.
+	UpdateTime time.Time `json:"update_time"`
+}
+
+type JobRegisterRuleUpdateResp struct {
+	Rule *domain.JobRegisterRule `json:"rule"`
 }
@@ -0,0 +1,67 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"github.com/oklog/ulid/v2"
+
+	"github.com/rasorp/attila/internal/domain"
+)
+
+// JobRegisterRunState stores the results of past job registration plan runs,
+// so there is a record of what was registered where once the plan has been
+// deleted.
+type JobRegisterRunState interface {
+	Create(*JobRegisterRunCreateReq) (*JobRegisterRunCreateResp, *ErrorResp)
+	Get(*JobRegisterRunGetReq) (*JobRegisterRunGetResp, *ErrorResp)
+	List(*JobRegisterRunListReq) (*JobRegisterRunListResp, *ErrorResp)
+}
+
+type JobRegisterRunCreateReq struct {
+	Run *domain.JobRegisterPlanRun
+}
+
+type JobRegisterRunCreateResp struct {
+	Run *domain.JobRegisterPlanRun `json:"run"`
+}
+
+type JobRegisterRunGetReq struct {
+	ID ulid.ULID `json:"id"`
+
+	QueryOptions `json:"-"`
+}
+
+type JobRegisterRunGetResp struct {
+	Run *domain.JobRegisterPlanRun `json:"run"`
+
+	QueryMeta `json:"-"`
+}
+
+type JobRegisterRunListReq struct {
+
+	// JobID and JobNamespace optionally filter the listed runs to those of
+	// the matching job. Each filter is ignored if empty.
+	JobID        string `json:"job_id"`
+	JobNamespace string `json:"job_namespace"`
+
+	QueryOptions `json:"-"`
+}
+
+// Matches returns whether the run passes the filters of the list request. It
+// is safe to call on a nil request, which matches all runs.
+func (r *JobRegisterRunListReq) Matches(run *domain.JobRegisterPlanRun) bool {
+	if r == nil {
+		return true
+	}
+	if r.JobID != "" && r.JobID != run.JobID {
+		return false
+	}
+	return r.JobNamespace == "" || r.JobNamespace == run.JobNamespace
+}
+
+type JobRegisterRunListResp struct {
+	Runs []*domain.JobRegisterPlanRun `json:"runs"`
+
+	QueryMeta `json:"-"`
+}
@@ -0,0 +1,188 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+type ACL struct {
+	db *memdb.MemDB
+}
+
+func (a *ACL) Policy() store.ACLPolicyState { return &ACLPolicy{db: a.db} }
+
+type ACLPolicy struct {
+	db *memdb.MemDB
+}
+
+func (a *ACLPolicy) Create(req *store.ACLPolicyCreateReq) (*store.ACLPolicyCreateResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	existingPolicy, err := txn.First(aclPolicyTableName, indexID, req.Policy.Name)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl policy: %w", err), 500)
+	}
+	if existingPolicy != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("acl policy %q already exists", req.Policy.Name), 400)
+	}
+
+	index, err := bumpIndex(txn, aclPolicyTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Policy.Metadata = req.Policy.Metadata.WithModifyIndex(index)
+
+	if err := txn.Insert(aclPolicyTableName, req.Policy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to create acl policy: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.ACLPolicyCreateResp{Policy: req.Policy}, nil
+}
+
+func (a *ACLPolicy) Delete(req *store.ACLPolicyDeleteReq) (*store.ACLPolicyDeleteResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	existingPolicy, err := txn.First(aclPolicyTableName, indexID, req.Name)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl policy: %w", err), 500)
+	}
+	if existingPolicy == nil {
+		return nil, store.NewErrorResp(fmt.Errorf("acl policy %q not found", req.Name), 404)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingPolicy.(*domain.ACLPolicy).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, aclPolicyTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	if err := txn.Delete(aclPolicyTableName, existingPolicy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to delete acl policy: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.ACLPolicyDeleteResp{}, nil
+}
+
+func (a *ACLPolicy) Get(req *store.ACLPolicyGetReq) (*store.ACLPolicyGetResp, *store.ErrorResp) {
+	var reply store.ACLPolicyGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := a.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingPolicy, err := txn.FirstWatch(aclPolicyTableName, indexID, req.Name)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read acl policy: %w", err), 500)
+		}
+		if existingPolicy == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("acl policy %q not found", req.Name), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, aclPolicyTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.ACLPolicyGetResp{
+			Policy:    existingPolicy.(*domain.ACLPolicy),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (a *ACLPolicy) List(req *store.ACLPolicyListReq) (*store.ACLPolicyListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.ACLPolicyListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := a.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(aclPolicyTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list acl policies: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, aclPolicyTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.ACLPolicyListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Policies = append(reply.Policies, raw.(*domain.ACLPolicy))
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (a *ACLPolicy) Update(req *store.ACLPolicyUpdateReq) (*store.ACLPolicyUpdateResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	existingPolicy, err := txn.First(aclPolicyTableName, indexID, req.Policy.Name)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl policy: %w", err), 500)
+	}
+	if existingPolicy == nil {
+		return nil, store.NewErrorResp(fmt.Errorf("acl policy %q not found", req.Policy.Name), 404)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingPolicy.(*domain.ACLPolicy).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	index, err := bumpIndex(txn, aclPolicyTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Policy.Metadata = existingPolicy.(*domain.ACLPolicy).Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
+
+	if err := txn.Insert(aclPolicyTableName, req.Policy); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to update acl policy: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.ACLPolicyUpdateResp{Policy: req.Policy}, nil
+}
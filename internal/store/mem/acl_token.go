@@ -0,0 +1,224 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func (a *ACL) Token() store.ACLTokenState { return &ACLToken{db: a.db} }
+
+type ACLToken struct {
+	db *memdb.MemDB
+}
+
+// aclBootstrapIndexKey is the key of the index table entry which records the
+// index the ACL system was bootstrapped at. The entry is never removed, so the
+// ACL system cannot be bootstrapped again once every token is deleted.
+const aclBootstrapIndexKey = "acl_bootstrap"
+
+func (a *ACLToken) Bootstrap(req *store.ACLTokenBootstrapReq) (*store.ACLTokenBootstrapResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	bootstrapIndex, err := txn.First(indexTableName, indexID, aclBootstrapIndexKey)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read index table: %w", err), 500)
+	}
+	existingToken, err := txn.First(aclTokenTableName, indexID)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl token: %w", err), 500)
+	}
+	if bootstrapIndex != nil || existingToken != nil {
+		return nil, store.NewErrorResp(errors.New("acl system already bootstrapped"), 400)
+	}
+
+	if errResp := a.insert(txn, req.Token); errResp != nil {
+		return nil, errResp
+	}
+
+	// Record the bootstrap using the index of the token, so the entry does not
+	// advance the latest index of state.
+	bootstrapEntry := IndexEntry{Key: aclBootstrapIndexKey, Value: req.Token.Metadata.ModifyIndex}
+
+	if err := txn.Insert(indexTableName, &bootstrapEntry); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to update index table: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.ACLTokenBootstrapResp{Token: req.Token}, nil
+}
+
+func (a *ACLToken) Create(req *store.ACLTokenCreateReq) (*store.ACLTokenCreateResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	existingToken, err := txn.First(aclTokenTableName, indexID, req.Token.AccessorID)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl token: %w", err), 500)
+	}
+	if existingToken != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("acl token %q already exists", req.Token.AccessorID), 400)
+	}
+
+	// Ensure the linked policies exist within state.
+	for _, policyName := range req.Token.Policies {
+		policy, err := txn.First(aclPolicyTableName, indexID, policyName)
+		if err != nil {
+			return nil, store.NewErrorResp(fmt.Errorf("failed to read acl policy: %w", err), 500)
+		}
+		if policy == nil {
+			return nil, store.NewErrorResp(fmt.Errorf("acl policy %q not found", policyName), 400)
+		}
+	}
+
+	if errResp := a.insert(txn, req.Token); errResp != nil {
+		return nil, errResp
+	}
+
+	txn.Commit()
+	return &store.ACLTokenCreateResp{Token: req.Token}, nil
+}
+
+func (a *ACLToken) insert(txn *memdb.Txn, token *domain.ACLToken) *store.ErrorResp {
+	index, err := bumpIndex(txn, aclTokenTableName)
+	if err != nil {
+		return store.NewErrorResp(err, 500)
+	}
+
+	token.Metadata = token.Metadata.WithModifyIndex(index)
+
+	if err := txn.Insert(aclTokenTableName, token); err != nil {
+		return store.NewErrorResp(fmt.Errorf("failed to create acl token: %w", err), 500)
+	}
+	return nil
+}
+
+func (a *ACLToken) Delete(req *store.ACLTokenDeleteReq) (*store.ACLTokenDeleteResp, *store.ErrorResp) {
+	txn := a.db.Txn(true)
+	defer txn.Abort()
+
+	existingToken, err := txn.First(aclTokenTableName, indexID, req.AccessorID)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl token: %w", err), 500)
+	}
+	if existingToken == nil {
+		return nil, store.NewErrorResp(fmt.Errorf("acl token %q not found", req.AccessorID), 404)
+	}
+
+	if errResp := store.CheckIndex(req.CheckIndex, existingToken.(*domain.ACLToken).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, aclTokenTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	if err := txn.Delete(aclTokenTableName, existingToken); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to delete acl token: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.ACLTokenDeleteResp{}, nil
+}
+
+func (a *ACLToken) Get(req *store.ACLTokenGetReq) (*store.ACLTokenGetResp, *store.ErrorResp) {
+	var reply store.ACLTokenGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := a.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingToken, err := txn.FirstWatch(aclTokenTableName, indexID, req.AccessorID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read acl token: %w", err), 500)
+		}
+		if existingToken == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("acl token %q not found", req.AccessorID), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, aclTokenTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.ACLTokenGetResp{
+			Token:     existingToken.(*domain.ACLToken),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (a *ACLToken) GetBySecret(req *store.ACLTokenGetBySecretReq) (*store.ACLTokenGetBySecretResp, *store.ErrorResp) {
+	txn := a.db.Txn(false)
+	defer txn.Abort()
+
+	existingToken, err := txn.First(aclTokenTableName, indexSecret, req.SecretID)
+	if err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read acl token: %w", err), 500)
+	}
+	if existingToken == nil {
+		return nil, store.NewErrorResp(errors.New("acl token not found"), 404)
+	}
+
+	return &store.ACLTokenGetBySecretResp{Token: existingToken.(*domain.ACLToken)}, nil
+}
+
+func (a *ACLToken) List(req *store.ACLTokenListReq) (*store.ACLTokenListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.ACLTokenListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := a.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(aclTokenTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list acl tokens: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, aclTokenTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.ACLTokenListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Tokens = append(reply.Tokens, raw.(*domain.ACLToken))
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
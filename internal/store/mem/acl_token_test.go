@@ -0,0 +1,57 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestACLToken_Bootstrap(t *testing.T) {
+	testState, err := NewStore()
+	must.NoError(t, err)
+
+	bootstrapToken := domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)
+
+	bootstrapResp1, errResp1 := testState.ACL().Token().Bootstrap(
+		&store.ACLTokenBootstrapReq{Token: bootstrapToken},
+	)
+	must.Nil(t, errResp1)
+	must.Eq(t, bootstrapToken, bootstrapResp1.Token)
+
+	// Bootstrapping can only happen once.
+	bootstrapResp2, errResp2 := testState.ACL().Token().Bootstrap(
+		&store.ACLTokenBootstrapReq{Token: domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)},
+	)
+	must.NotNil(t, errResp2)
+	must.ErrorContains(t, errResp2, "already bootstrapped")
+	must.Nil(t, bootstrapResp2)
+
+	// Deleting every token does not allow the system to be bootstrapped again,
+	// including once state is restored from a snapshot.
+	_, errResp := testState.ACL().Token().Delete(
+		&store.ACLTokenDeleteReq{AccessorID: bootstrapToken.AccessorID},
+	)
+	must.Nil(t, errResp)
+
+	snapshot, err := testState.Snapshot()
+	must.NoError(t, err)
+
+	restoredState, err := NewStore()
+	must.NoError(t, err)
+	must.NoError(t, restoredState.Restore(snapshot))
+
+	for _, state := range []*Store{testState, restoredState} {
+		bootstrapResp3, errResp3 := state.ACL().Token().Bootstrap(
+			&store.ACLTokenBootstrapReq{Token: domain.NewACLToken("bootstrap", domain.ACLTokenTypeManagement, nil)},
+		)
+		must.NotNil(t, errResp3)
+		must.ErrorContains(t, errResp3, "already bootstrapped")
+		must.Nil(t, bootstrapResp3)
+	}
+}
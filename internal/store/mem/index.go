@@ -0,0 +1,78 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+)
+
+// IndexEntry is the object stored within the index table and tracks the
+// latest modify index of the named table.
+type IndexEntry struct {
+	Key   string
+	Value uint64
+}
+
+// latestIndex returns the highest modify index across all tables.
+func latestIndex(txn *memdb.Txn) (uint64, error) {
+	iter, err := txn.Get(indexTableName, indexID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read index table: %w", err)
+	}
+
+	var latest uint64
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if entry := raw.(*IndexEntry); entry.Value > latest {
+			latest = entry.Value
+		}
+	}
+
+	return latest, nil
+}
+
+// bumpIndex calculates the next modify index and records it against the named
+// table within the write transaction. The new index is returned, so it can be
+// set on the object being written.
+func bumpIndex(txn *memdb.Txn, table string) (uint64, error) {
+	latest, err := latestIndex(txn)
+	if err != nil {
+		return 0, err
+	}
+
+	next := latest + 1
+
+	if err := txn.Insert(indexTableName, &IndexEntry{Key: table, Value: next}); err != nil {
+		return 0, fmt.Errorf("failed to update index table: %w", err)
+	}
+
+	return next, nil
+}
+
+// tableIndex returns the latest modify index of the named table. The watch
+// channel of the table's index entry is added to the watch set, so a blocking
+// query is notified when the table is next written.
+func tableIndex(txn *memdb.Txn, ws memdb.WatchSet, table string) (uint64, error) {
+	watchCh, raw, err := txn.FirstWatch(indexTableName, indexID, table)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read index table: %w", err)
+	}
+
+	ws.Add(watchCh)
+
+	if raw == nil {
+		return 0, nil
+	}
+	return raw.(*IndexEntry).Value, nil
+}
+
+// watchFunc returns a function which blocks until any channel within the watch
+// set fires, or the context is done. It satisfies the wait function returned
+// by a store.QueryFunc.
+func watchFunc(ws memdb.WatchSet) func(context.Context) {
+	return func(ctx context.Context) { _ = ws.WatchCtx(ctx) }
+}
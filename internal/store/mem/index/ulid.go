@@ -29,3 +29,19 @@ func WriteULIDIndex(raw any) ([]byte, error) {
 	}
 	return plan.ID.Bytes(), nil
 }
+
+func WriteJobRegisterRunIDIndex(raw any) ([]byte, error) {
+	run, ok := raw.(*domain.JobRegisterPlanRun)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for job register run", raw)
+	}
+	return run.ID.Bytes(), nil
+}
+
+func WriteACLTokenAccessorIDIndex(raw any) ([]byte, error) {
+	token, ok := raw.(*domain.ACLToken)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for acl token", raw)
+	}
+	return token.AccessorID.Bytes(), nil
+}
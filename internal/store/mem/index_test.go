@@ -0,0 +1,64 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/shoenig/test/must"
+)
+
+func Test_bumpIndex(t *testing.T) {
+	db, err := memdb.NewMemDB(newTableSchema())
+	must.NoError(t, err)
+
+	// The index is shared across tables, so a write to any table advances the
+	// next index of every other table.
+	txn := db.Txn(true)
+
+	index, err := bumpIndex(txn, regionTableName)
+	must.NoError(t, err)
+	must.Eq(t, 1, index)
+
+	index, err = bumpIndex(txn, jobRegisterRuleTableName)
+	must.NoError(t, err)
+	must.Eq(t, 2, index)
+
+	index, err = bumpIndex(txn, regionTableName)
+	must.NoError(t, err)
+	must.Eq(t, 3, index)
+
+	txn.Commit()
+
+	// An aborted write must not advance the index.
+	txn = db.Txn(true)
+
+	index, err = bumpIndex(txn, regionTableName)
+	must.NoError(t, err)
+	must.Eq(t, 4, index)
+
+	txn.Abort()
+
+	txn = db.Txn(false)
+	defer txn.Abort()
+
+	latest, err := latestIndex(txn)
+	must.NoError(t, err)
+	must.Eq(t, 3, latest)
+
+	ws := memdb.NewWatchSet()
+
+	regionIndex, err := tableIndex(txn, ws, regionTableName)
+	must.NoError(t, err)
+	must.Eq(t, 3, regionIndex)
+
+	ruleIndex, err := tableIndex(txn, ws, jobRegisterRuleTableName)
+	must.NoError(t, err)
+	must.Eq(t, 2, ruleIndex)
+
+	planIndex, err := tableIndex(txn, ws, jobRegisterPlanTableName)
+	must.NoError(t, err)
+	must.Zero(t, planIndex)
+}
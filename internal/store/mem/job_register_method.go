@@ -4,6 +4,7 @@
 package mem
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-memdb"
@@ -41,6 +42,13 @@ func (j *JobRegisterMethod) Create(req *store.JobRegisterMethodCreateReq) (*stor
 		}
 	}
 
+	index, err := bumpIndex(txn, jobRegisterMethodTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Method.Metadata = req.Method.Metadata.WithModifyIndex(index)
+
 	if err := txn.Insert(jobRegisterMethodTableName, req.Method); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to create job register method: %w", err), 500)
 	}
@@ -61,6 +69,14 @@ func (j *JobRegisterMethod) Delete(req *store.JobRegisterMethodDeleteReq) (*stor
 		return nil, store.NewErrorResp(fmt.Errorf("job register method %q not found", req.Name), 404)
 	}
 
+	if errResp := store.CheckIndex(req.CheckIndex, existingMethod.(*domain.JobRegisterMethod).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, jobRegisterMethodTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
 	if err := txn.Delete(jobRegisterMethodTableName, existingMethod); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to delete job register method: %w", err), 500)
 	}
@@ -70,35 +86,121 @@ func (j *JobRegisterMethod) Delete(req *store.JobRegisterMethodDeleteReq) (*stor
 }
 
 func (j *JobRegisterMethod) Get(req *store.JobRegisterMethodGetReq) (*store.JobRegisterMethodGetResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
+	var reply store.JobRegisterMethodGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingMethod, err := txn.FirstWatch(jobRegisterMethodTableName, indexID, req.Name)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read job register method: %w", err), 500)
+		}
+		if existingMethod == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("job register method %q not found", req.Name), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, jobRegisterMethodTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterMethodGetResp{
+			Method:    existingMethod.(*domain.JobRegisterMethod),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (j *JobRegisterMethod) List(req *store.JobRegisterMethodListReq) (*store.JobRegisterMethodListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.JobRegisterMethodListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(jobRegisterMethodTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list job register methods: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, jobRegisterMethodTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterMethodListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Methods = append(reply.Methods, raw.(*domain.JobRegisterMethod))
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (j *JobRegisterMethod) Update(req *store.JobRegisterMethodUpdateReq) (*store.JobRegisterMethodUpdateResp, *store.ErrorResp) {
+	txn := j.db.Txn(true)
 	defer txn.Abort()
 
-	existingMethod, err := txn.First(jobRegisterMethodTableName, indexID, req.Name)
+	existingMethod, err := txn.First(jobRegisterMethodTableName, indexID, req.Method.Name)
 	if err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to read job register method: %w", err), 500)
 	}
 	if existingMethod == nil {
-		return nil, store.NewErrorResp(fmt.Errorf("job register method %q not found", req.Name), 404)
+		return nil, store.NewErrorResp(fmt.Errorf("job register method %q not found", req.Method.Name), 404)
 	}
 
-	txn.Commit()
-	return &store.JobRegisterMethodGetResp{Method: existingMethod.(*domain.JobRegisterMethod)}, nil
-}
+	if errResp := store.CheckIndex(req.CheckIndex, existingMethod.(*domain.JobRegisterMethod).Metadata); errResp != nil {
+		return nil, errResp
+	}
 
-func (j *JobRegisterMethod) List(*store.JobRegisterMethodListReq) (*store.JobRegisterMethodListResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
-	defer txn.Abort()
+	// Ensure the linked registerment rules exist within state.
+	for _, ruleLink := range req.Method.Rules {
+		registerRule, err := txn.First(jobRegisterRuleTableName, indexID, ruleLink.Name)
+		if err != nil {
+			return nil, store.NewErrorResp(fmt.Errorf("failed to read job register rule: %w", err), 500)
+		}
+		if registerRule == nil {
+			return nil, store.NewErrorResp(fmt.Errorf("job register rule %q not found", ruleLink.Name), 400)
+		}
+	}
 
-	iter, err := txn.Get(jobRegisterMethodTableName, indexID)
+	index, err := bumpIndex(txn, jobRegisterMethodTableName)
 	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to list job register methods: %w", err), 500)
+		return nil, store.NewErrorResp(err, 500)
 	}
 
-	var reply store.JobRegisterMethodListResp
+	req.Method.Metadata = existingMethod.(*domain.JobRegisterMethod).Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
 
-	for raw := iter.Next(); raw != nil; raw = iter.Next() {
-		reply.Methods = append(reply.Methods, raw.(*domain.JobRegisterMethod))
+	if err := txn.Insert(jobRegisterMethodTableName, req.Method); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to update job register method: %w", err), 500)
 	}
 
-	return &reply, nil
+	txn.Commit()
+	return &store.JobRegisterMethodUpdateResp{Method: req.Method}, nil
 }
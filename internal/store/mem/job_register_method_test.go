@@ -0,0 +1,63 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestJobRegisterMethod_Update(t *testing.T) {
+	testState, err := New()
+	must.NoError(t, err)
+
+	mockRule := mock.JobRegistrationRule()
+
+	_, errResp := testState.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: mockRule})
+	must.Nil(t, errResp)
+
+	mockMethod := mock.JobRegistrationMethod()
+	mockMethod.Rules = []*domain.JobRegisterMethodRuleLink{{Name: mockRule.Name}}
+
+	// Updating a method which does not exist must not advance the index.
+	updateResp1, errResp := testState.JobRegister().Method().Update(
+		&store.JobRegisterMethodUpdateReq{Method: mockMethod})
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+	must.Nil(t, updateResp1)
+
+	createResp, errResp := testState.JobRegister().Method().Create(
+		&store.JobRegisterMethodCreateReq{Method: mockMethod})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, createResp.Method.Metadata.ModifyIndex)
+
+	// Linking a rule which does not exist is rejected.
+	updatedMethod := mock.JobRegistrationMethod()
+	updatedMethod.Name = mockMethod.Name
+
+	updateResp2, errResp := testState.JobRegister().Method().Update(
+		&store.JobRegisterMethodUpdateReq{Method: updatedMethod})
+	must.NotNil(t, errResp)
+	must.Eq(t, 400, errResp.StatusCode())
+	must.Nil(t, updateResp2)
+
+	updatedMethod.Rules = nil
+
+	updateResp3, errResp := testState.JobRegister().Method().Update(
+		&store.JobRegisterMethodUpdateReq{Method: updatedMethod})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedMethod, updateResp3.Method)
+	must.Eq(t, 3, updateResp3.Method.Metadata.ModifyIndex)
+	must.Eq(t, createResp.Method.Metadata.CreateTime, updateResp3.Method.Metadata.CreateTime)
+
+	getResp, errResp := testState.JobRegister().Method().Get(&store.JobRegisterMethodGetReq{Name: mockMethod.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, 3, getResp.Index)
+	must.SliceEmpty(t, getResp.Method.Rules)
+}
@@ -4,6 +4,7 @@
 package mem
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-memdb"
@@ -26,6 +27,13 @@ func (j *JobRegisterPlan) Create(req *store.JobRegisterPlanCreateReq) (*store.Jo
 	txn := j.db.Txn(true)
 	defer txn.Abort()
 
+	index, err := bumpIndex(txn, jobRegisterPlanTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Plan.Metadata = req.Plan.Metadata.WithModifyIndex(index)
+
 	if err := txn.Insert(jobRegisterPlanTableName, req.Plan); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to create job registration plan: %w", err), 500)
 	}
@@ -46,6 +54,14 @@ func (j *JobRegisterPlan) Delete(req *store.JobRegisterPlanDeleteReq) (*store.Jo
 		return nil, store.NewErrorResp(fmt.Errorf("job registration plan %q not found", req.ID), 404)
 	}
 
+	if errResp := store.CheckIndex(req.CheckIndex, existingPlan.(*domain.JobRegisterPlan).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, jobRegisterPlanTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
 	if err := txn.Delete(jobRegisterPlanTableName, existingPlan); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to delete job registration plan: %w", err), 500)
 	}
@@ -55,34 +71,78 @@ func (j *JobRegisterPlan) Delete(req *store.JobRegisterPlanDeleteReq) (*store.Jo
 }
 
 func (j *JobRegisterPlan) Get(req *store.JobRegisterPlanGetReq) (*store.JobRegisterPlanGetResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
-	defer txn.Abort()
-
-	existingPlan, err := txn.First(jobRegisterPlanTableName, indexID, req.ID)
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to read job registration plan: %w", err), 500)
-	}
-	if existingPlan == nil {
-		return nil, store.NewErrorResp(fmt.Errorf("job registration plan %q not found", req.ID.String()), 404)
+	var reply store.JobRegisterPlanGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingPlan, err := txn.FirstWatch(jobRegisterPlanTableName, indexID, req.ID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read job registration plan: %w", err), 500)
+		}
+		if existingPlan == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("job registration plan %q not found", req.ID.String()), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, jobRegisterPlanTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterPlanGetResp{
+			Plan:      existingPlan.(*domain.JobRegisterPlan),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	txn.Commit()
-	return &store.JobRegisterPlanGetResp{Plan: existingPlan.(*domain.JobRegisterPlan)}, nil
+	return &reply, nil
 }
 
 func (j *JobRegisterPlan) List(req *store.JobRegisterPlanListReq) (*store.JobRegisterPlanListResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
-	defer txn.Abort()
+	var (
+		opts  *store.QueryOptions
+		reply store.JobRegisterPlanListResp
+	)
 
-	iter, err := txn.Get(jobRegisterPlanTableName, indexID)
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to list job registration plans: %w", err), 500)
+	if req != nil {
+		opts = &req.QueryOptions
 	}
 
-	var reply store.JobRegisterPlanListResp
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(jobRegisterPlanTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list job registration plans: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, jobRegisterPlanTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterPlanListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Plans = append(reply.Plans, raw.(*domain.JobRegisterPlan))
+		}
 
-	for raw := iter.Next(); raw != nil; raw = iter.Next() {
-		reply.Plans = append(reply.Plans, raw.(*domain.JobRegisterPlan))
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
 	return &reply, nil
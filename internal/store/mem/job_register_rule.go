@@ -4,6 +4,7 @@
 package mem
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-memdb"
@@ -30,6 +31,13 @@ func (j *JobRegisterRule) Create(req *store.JobRegisterRuleCreateReq) (*store.Jo
 		return nil, store.NewErrorResp(fmt.Errorf("job register rule %q already exists", req.Rule.Name), 400)
 	}
 
+	index, err := bumpIndex(txn, jobRegisterRuleTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Rule.Metadata = req.Rule.Metadata.WithModifyIndex(index)
+
 	if err := txn.Insert(jobRegisterRuleTableName, req.Rule); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to create job register rule: %w", err), 500)
 	}
@@ -50,6 +58,14 @@ func (j *JobRegisterRule) Delete(req *store.JobRegisterRuleDeleteReq) (*store.Jo
 		return nil, store.NewErrorResp(fmt.Errorf("job register rule %q not found", req.Name), 404)
 	}
 
+	if errResp := store.CheckIndex(req.CheckIndex, existingMethod.(*domain.JobRegisterRule).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, jobRegisterRuleTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
 	if err := txn.Delete(jobRegisterRuleTableName, existingMethod); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to delete job register rule: %w", err), 500)
 	}
@@ -59,35 +75,110 @@ func (j *JobRegisterRule) Delete(req *store.JobRegisterRuleDeleteReq) (*store.Jo
 }
 
 func (j *JobRegisterRule) Get(req *store.JobRegisterRuleGetReq) (*store.JobRegisterRuleGetResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
-	defer txn.Abort()
+	var reply store.JobRegisterRuleGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingRule, err := txn.FirstWatch(jobRegisterRuleTableName, indexID, req.Name)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read job register rule: %w", err), 500)
+		}
+		if existingRule == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("job register rule %q not found", req.Name), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, jobRegisterRuleTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterRuleGetResp{
+			Rule:      existingRule.(*domain.JobRegisterRule),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
 
-	existingMethod, err := txn.First(jobRegisterRuleTableName, indexID, req.Name)
-	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to read job register rule: %w", err), 500)
+	return &reply, nil
+}
+
+func (j *JobRegisterRule) List(req *store.JobRegisterRuleListReq) (*store.JobRegisterRuleListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.JobRegisterRuleListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
 	}
-	if existingMethod == nil {
-		return nil, store.NewErrorResp(fmt.Errorf("job register rule %q not found", req.Name), 404)
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(jobRegisterRuleTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list job register rules: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, jobRegisterRuleTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterRuleListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Rules = append(reply.Rules, raw.(*domain.JobRegisterRule))
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
 	}
 
-	txn.Commit()
-	return &store.JobRegisterRuleGetResp{Rule: existingMethod.(*domain.JobRegisterRule)}, nil
+	return &reply, nil
 }
 
-func (j *JobRegisterRule) List(req *store.JobRegisterRuleListReq) (*store.JobRegisterRuleListResp, *store.ErrorResp) {
-	txn := j.db.Txn(false)
+func (j *JobRegisterRule) Update(req *store.JobRegisterRuleUpdateReq) (*store.JobRegisterRuleUpdateResp, *store.ErrorResp) {
+	txn := j.db.Txn(true)
 	defer txn.Abort()
 
-	iter, err := txn.Get(jobRegisterRuleTableName, indexID)
+	existingRule, err := txn.First(jobRegisterRuleTableName, indexID, req.Rule.Name)
 	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to list job register rules: %w", err), 500)
+		return nil, store.NewErrorResp(fmt.Errorf("failed to read job register rule: %w", err), 500)
+	}
+	if existingRule == nil {
+		return nil, store.NewErrorResp(fmt.Errorf("job register rule %q not found", req.Rule.Name), 404)
 	}
 
-	var reply store.JobRegisterRuleListResp
+	if errResp := store.CheckIndex(req.CheckIndex, existingRule.(*domain.JobRegisterRule).Metadata); errResp != nil {
+		return nil, errResp
+	}
 
-	for raw := iter.Next(); raw != nil; raw = iter.Next() {
-		reply.Rules = append(reply.Rules, raw.(*domain.JobRegisterRule))
+	index, err := bumpIndex(txn, jobRegisterRuleTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
 	}
 
-	return &reply, nil
+	req.Rule.Metadata = existingRule.(*domain.JobRegisterRule).Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
+
+	if err := txn.Insert(jobRegisterRuleTableName, req.Rule); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to update job register rule: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.JobRegisterRuleUpdateResp{Rule: req.Rule}, nil
 }
@@ -0,0 +1,46 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestJobRegisterRule_Update(t *testing.T) {
+	testState, err := New()
+	must.NoError(t, err)
+
+	mockRule := mock.JobRegistrationRule()
+
+	// Updating a rule which does not exist must not advance the index.
+	updateResp1, errResp := testState.JobRegister().Rule().Update(&store.JobRegisterRuleUpdateReq{Rule: mockRule})
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+	must.Nil(t, updateResp1)
+
+	createResp, errResp := testState.JobRegister().Rule().Create(&store.JobRegisterRuleCreateReq{Rule: mockRule})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, createResp.Rule.Metadata.ModifyIndex)
+
+	updatedRule := mock.JobRegistrationRule()
+	updatedRule.Name = mockRule.Name
+	updatedRule.RegionContexts = []domain.JobRegisterRuleRegionContext{{Kind: domain.JobRegisterRuleContextKindNodepool}}
+
+	updateResp2, errResp := testState.JobRegister().Rule().Update(&store.JobRegisterRuleUpdateReq{Rule: updatedRule})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedRule, updateResp2.Rule)
+	must.Eq(t, 2, updateResp2.Rule.Metadata.ModifyIndex)
+	must.Eq(t, createResp.Rule.Metadata.CreateTime, updateResp2.Rule.Metadata.CreateTime)
+
+	getResp, errResp := testState.JobRegister().Rule().Get(&store.JobRegisterRuleGetReq{Name: mockRule.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, getResp.Index)
+	must.Eq(t, updatedRule.RegionContexts, getResp.Rule.RegionContexts)
+}
@@ -0,0 +1,119 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func (j *JobRegister) Run() store.JobRegisterRunState { return &JobRegisterRun{db: j.db} }
+
+type JobRegisterRun struct {
+	db *memdb.MemDB
+}
+
+func (j *JobRegisterRun) Create(req *store.JobRegisterRunCreateReq) (*store.JobRegisterRunCreateResp, *store.ErrorResp) {
+	txn := j.db.Txn(true)
+	defer txn.Abort()
+
+	index, err := bumpIndex(txn, jobRegisterRunTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Run.Metadata = req.Run.Metadata.WithModifyIndex(index)
+
+	if err := txn.Insert(jobRegisterRunTableName, req.Run); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to create job registration run: %w", err), 500)
+	}
+
+	txn.Commit()
+	return &store.JobRegisterRunCreateResp{Run: req.Run}, nil
+}
+
+func (j *JobRegisterRun) Get(req *store.JobRegisterRunGetReq) (*store.JobRegisterRunGetResp, *store.ErrorResp) {
+	var reply store.JobRegisterRunGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingRun, err := txn.FirstWatch(jobRegisterRunTableName, indexID, req.ID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read job registration run: %w", err), 500)
+		}
+		if existingRun == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("job registration run %q not found", req.ID.String()), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, jobRegisterRunTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterRunGetResp{
+			Run:       existingRun.(*domain.JobRegisterPlanRun),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (j *JobRegisterRun) List(req *store.JobRegisterRunListReq) (*store.JobRegisterRunListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.JobRegisterRunListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := j.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(jobRegisterRunTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list job registration runs: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, jobRegisterRunTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.JobRegisterRunListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			if run := raw.(*domain.JobRegisterPlanRun); req.Matches(run) {
+				reply.Runs = append(reply.Runs, run)
+			}
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
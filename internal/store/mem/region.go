@@ -4,6 +4,7 @@
 package mem
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-memdb"
@@ -28,6 +29,13 @@ func (ar *Region) Create(req *store.RegionCreateReq) (*store.RegionCreateResp, *
 		return nil, store.NewErrorResp(fmt.Errorf("region %q already exists", req.Region.Name), 400)
 	}
 
+	index, err := bumpIndex(txn, regionTableName)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	req.Region.Metadata = req.Region.Metadata.WithModifyIndex(index)
+
 	if err := txn.Insert(regionTableName, req.Region); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to create region: %w", err), 500)
 	}
@@ -48,6 +56,14 @@ func (ar *Region) Delete(req *store.RegionDeleteReq) (*store.RegionDeleteResp, *
 		return nil, store.NewErrorResp(fmt.Errorf("region %q not found", req.RegionName), 404)
 	}
 
+	if errResp := store.CheckIndex(req.CheckIndex, existingRegion.(*domain.Region).Metadata); errResp != nil {
+		return nil, errResp
+	}
+
+	if _, err := bumpIndex(txn, regionTableName); err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
 	if err := txn.Delete(regionTableName, existingRegion); err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to delete region: %w", err), 500)
 	}
@@ -57,35 +73,110 @@ func (ar *Region) Delete(req *store.RegionDeleteReq) (*store.RegionDeleteResp, *
 }
 
 func (ar *Region) Get(req *store.RegionGetReq) (*store.RegionGetResp, *store.ErrorResp) {
-	txn := ar.db.Txn(false)
+	var reply store.RegionGetResp
+
+	errResp := store.BlockingQuery(&req.QueryOptions, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := ar.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		watchCh, existingRegion, err := txn.FirstWatch(regionTableName, indexID, req.RegionName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to read region: %w", err), 500)
+		}
+		if existingRegion == nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("region %q not found", req.RegionName), 404)
+		}
+		ws.Add(watchCh)
+
+		index, err := tableIndex(txn, ws, regionTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.RegionGetResp{
+			Region:    existingRegion.(*domain.Region),
+			QueryMeta: store.QueryMeta{Index: index},
+		}
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (ar *Region) List(req *store.RegionListReq) (*store.RegionListResp, *store.ErrorResp) {
+	var (
+		opts  *store.QueryOptions
+		reply store.RegionListResp
+	)
+
+	if req != nil {
+		opts = &req.QueryOptions
+	}
+
+	errResp := store.BlockingQuery(opts, func() (uint64, func(context.Context), *store.ErrorResp) {
+		txn := ar.db.Txn(false)
+		defer txn.Abort()
+
+		ws := memdb.NewWatchSet()
+
+		iter, err := txn.Get(regionTableName, indexID)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(fmt.Errorf("failed to list regions: %w", err), 500)
+		}
+		ws.Add(iter.WatchCh())
+
+		index, err := tableIndex(txn, ws, regionTableName)
+		if err != nil {
+			return 0, nil, store.NewErrorResp(err, 500)
+		}
+
+		reply = store.RegionListResp{QueryMeta: store.QueryMeta{Index: index}}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			reply.Regions = append(reply.Regions, raw.(*domain.Region))
+		}
+
+		return index, watchFunc(ws), nil
+	})
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &reply, nil
+}
+
+func (ar *Region) Update(req *store.RegionUpdateReq) (*store.RegionUpdateResp, *store.ErrorResp) {
+	txn := ar.db.Txn(true)
 	defer txn.Abort()
 
-	existingRegion, err := txn.First(regionTableName, indexID, req.RegionName)
+	existingRegion, err := txn.First(regionTableName, indexID, req.Region.Name)
 	if err != nil {
 		return nil, store.NewErrorResp(fmt.Errorf("failed to read region: %w", err), 500)
 	}
 	if existingRegion == nil {
-		return nil, store.NewErrorResp(fmt.Errorf("region %q not found", req.RegionName), 404)
+		return nil, store.NewErrorResp(fmt.Errorf("region %q not found", req.Region.Name), 404)
 	}
 
-	txn.Commit()
-	return &store.RegionGetResp{Region: existingRegion.(*domain.Region)}, nil
-}
-
-func (ar *Region) List(req *store.RegionListReq) (*store.RegionListResp, *store.ErrorResp) {
-	txn := ar.db.Txn(false)
-	defer txn.Abort()
+	if errResp := store.CheckIndex(req.CheckIndex, existingRegion.(*domain.Region).Metadata); errResp != nil {
+		return nil, errResp
+	}
 
-	iter, err := txn.Get(regionTableName, indexID)
+	index, err := bumpIndex(txn, regionTableName)
 	if err != nil {
-		return nil, store.NewErrorResp(fmt.Errorf("failed to list regions: %w", err), 500)
+		return nil, store.NewErrorResp(err, 500)
 	}
 
-	var reply store.RegionListResp
+	req.Region.Metadata = existingRegion.(*domain.Region).Metadata.UpdatedAt(req.UpdateTime).WithModifyIndex(index)
 
-	for raw := iter.Next(); raw != nil; raw = iter.Next() {
-		reply.Regions = append(reply.Regions, raw.(*domain.Region))
+	if err := txn.Insert(regionTableName, req.Region); err != nil {
+		return nil, store.NewErrorResp(fmt.Errorf("failed to update region: %w", err), 500)
 	}
 
-	return &reply, nil
+	txn.Commit()
+	return &store.RegionUpdateResp{Region: req.Region}, nil
 }
@@ -0,0 +1,150 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestRegion_BlockingQuery(t *testing.T) {
+	testState, err := New()
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	_, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	getResp, errResp := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, getResp.Index)
+
+	// A blocking query at the current index should time out and return the
+	// unchanged data.
+	getResp, errResp = testState.Region().Get(&store.RegionGetReq{
+		RegionName:   mockRegion.Name,
+		QueryOptions: store.QueryOptions{MinIndex: 1, MaxWait: 50 * time.Millisecond},
+	})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, getResp.Index)
+
+	// Cancelling the context should return the query before the wait time.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+
+	_, errResp = testState.Region().List(&store.RegionListReq{
+		QueryOptions: store.QueryOptions{MinIndex: 1, MaxWait: 5 * time.Second, Ctx: ctx},
+	})
+	must.Nil(t, errResp)
+	must.Less(t, 5*time.Second, time.Since(startTime))
+
+	// A write to a different table should not unblock the query, whereas an
+	// update of the region should.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = testState.JobRegister().Rule().Create(
+			&store.JobRegisterRuleCreateReq{Rule: mock.JobRegistrationRule()})
+		time.Sleep(50 * time.Millisecond)
+		_, _ = testState.Region().Update(&store.RegionUpdateReq{Region: mockRegion})
+	}()
+
+	getResp, errResp = testState.Region().Get(&store.RegionGetReq{
+		RegionName:   mockRegion.Name,
+		QueryOptions: store.QueryOptions{MinIndex: 1, MaxWait: 5 * time.Second},
+	})
+	must.Nil(t, errResp)
+	must.Eq(t, 3, getResp.Index)
+	must.Eq(t, 3, getResp.Region.Metadata.ModifyIndex)
+}
+
+func TestRegion_Update(t *testing.T) {
+	testState, err := New()
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	// Updating a region which does not exist must not advance the index.
+	updateResp1, errResp := testState.Region().Update(&store.RegionUpdateReq{Region: mockRegion})
+	must.NotNil(t, errResp)
+	must.Eq(t, 404, errResp.StatusCode())
+	must.Nil(t, updateResp1)
+
+	createResp, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, createResp.Region.Metadata.ModifyIndex)
+
+	updatedRegion := mock.Region()
+	updatedRegion.Name = mockRegion.Name
+	updatedRegion.API = []*domain.RegionAPI{{Address: "http://10.0.0.20:4646", Default: true}}
+
+	updateResp2, errResp := testState.Region().Update(&store.RegionUpdateReq{Region: updatedRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, updatedRegion, updateResp2.Region)
+	must.Eq(t, 2, updateResp2.Region.Metadata.ModifyIndex)
+	must.Eq(t, createResp.Region.Metadata.CreateTime, updateResp2.Region.Metadata.CreateTime)
+
+	getResp, errResp := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, getResp.Index)
+	must.Eq(t, updatedRegion.API, getResp.Region.API)
+}
+
+func TestRegion_CheckIndex(t *testing.T) {
+	testState, err := New()
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+
+	createResp, errResp := testState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 1, createResp.Region.Metadata.ModifyIndex)
+
+	staleIndex := uint64(0)
+	currentIndex := createResp.Region.Metadata.ModifyIndex
+
+	// A stale check index must not perform the update, or advance the index.
+	updateResp1, errResp := testState.Region().Update(
+		&store.RegionUpdateReq{Region: mockRegion, CheckIndex: &staleIndex},
+	)
+	must.NotNil(t, errResp)
+	must.Eq(t, 409, errResp.StatusCode())
+	must.Nil(t, updateResp1)
+
+	updateResp2, errResp := testState.Region().Update(
+		&store.RegionUpdateReq{Region: mockRegion, CheckIndex: &currentIndex},
+	)
+	must.Nil(t, errResp)
+	must.Eq(t, 2, updateResp2.Region.Metadata.ModifyIndex)
+
+	// The previously current index is now stale, so the delete must fail.
+	deleteResp1, errResp := testState.Region().Delete(
+		&store.RegionDeleteReq{RegionName: mockRegion.Name, CheckIndex: &currentIndex},
+	)
+	must.NotNil(t, errResp)
+	must.Eq(t, 409, errResp.StatusCode())
+	must.Nil(t, deleteResp1)
+
+	getResp, errResp := testState.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, 2, getResp.Index)
+	must.Eq(t, 2, getResp.Region.Metadata.ModifyIndex)
+
+	currentIndex = updateResp2.Region.Metadata.ModifyIndex
+
+	deleteResp2, errResp := testState.Region().Delete(
+		&store.RegionDeleteReq{RegionName: mockRegion.Name, CheckIndex: &currentIndex},
+	)
+	must.Nil(t, errResp)
+	must.NotNil(t, deleteResp2)
+}
@@ -16,13 +16,21 @@ const (
 	// to directly translate to a field named ID on the stored object, but
 	// should be mapped to the field which will be used for default lookups.
 	indexID = "id"
+
+	// indexSecret is used by the ACL token table to allow lookups using the
+	// secret ID sent with API requests.
+	indexSecret = "secret"
 )
 
 const (
+	indexTableName             = "index"
+	aclPolicyTableName         = "acl_policy"
+	aclTokenTableName          = "acl_token"
 	regionTableName            = "region"
 	jobRegisterMethodTableName = "job_register_method"
 	jobRegisterRuleTableName   = "job_register_rule"
 	jobRegisterPlanTableName   = "job_register_plan"
+	jobRegisterRunTableName    = "job_register_run"
 )
 
 func newTableSchema() *memdb.DBSchema {
@@ -48,13 +56,36 @@ func newTableSchema() *memdb.DBSchema {
 
 func tableSchemas() []func() *memdb.TableSchema {
 	return []func() *memdb.TableSchema{
+		indexTableSchema,
+		aclPolicyTableSchema,
+		aclTokenTableSchema,
 		jobRegisterMethodTableSchema,
 		jobRegisterPlanTableSchema,
 		jobRegisterRuleTableSchema,
+		jobRegisterRunTableSchema,
 		regionTableSchema,
 	}
 }
 
+// indexTableSchema is the schema for the table which tracks the latest modify
+// index of every other table. The highest value across all entries is the
+// latest index of the state.
+func indexTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: indexTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Key",
+				},
+			},
+		},
+	}
+}
+
 func regionTableSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
 		Name: regionTableName,
@@ -130,3 +161,61 @@ func jobRegisterPlanTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+func jobRegisterRunTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: jobRegisterRunTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &index.SingleIndexer{
+					ReadIndex:  index.ReadIndex(index.ReadULIDIndex),
+					WriteIndex: index.WriteIndex(index.WriteJobRegisterRunIDIndex),
+				},
+			},
+		},
+	}
+}
+
+func aclPolicyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: aclPolicyTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+func aclTokenTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: aclTokenTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &index.SingleIndexer{
+					ReadIndex:  index.ReadIndex(index.ReadULIDIndex),
+					WriteIndex: index.WriteIndex(index.WriteACLTokenAccessorIDIndex),
+				},
+			},
+			indexSecret: {
+				Name:         indexSecret,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "SecretID",
+				},
+			},
+		},
+	}
+}
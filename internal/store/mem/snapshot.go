@@ -0,0 +1,130 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/rasorp/attila/internal/domain"
+)
+
+// Snapshot is a point-in-time copy of every object held within the store,
+// including the table index entries. It is used by replicated backends to
+// persist and restore the full state, so their logs can be compacted.
+type Snapshot struct {
+	Index              []*IndexEntry                `json:"index"`
+	ACLPolicies        []*domain.ACLPolicy          `json:"acl_policies"`
+	ACLTokens          []*domain.ACLToken           `json:"acl_tokens"`
+	JobRegisterMethods []*domain.JobRegisterMethod  `json:"job_register_methods"`
+	JobRegisterPlans   []*domain.JobRegisterPlan    `json:"job_register_plans"`
+	JobRegisterRules   []*domain.JobRegisterRule    `json:"job_register_rules"`
+	JobRegisterRuns    []*domain.JobRegisterPlanRun `json:"job_register_runs"`
+	Regions            []*domain.Region             `json:"regions"`
+}
+
+// Snapshot returns a copy of the current state. Stored objects are never
+// modified in place, so the returned objects are safe to read while the store
+// continues to accept writes.
+func (s *Store) Snapshot() (*Snapshot, error) {
+	txn := s.db.Txn(false)
+	defer txn.Abort()
+
+	var (
+		snap Snapshot
+		err  error
+	)
+
+	if snap.Index, err = snapshotTable[IndexEntry](txn, indexTableName); err != nil {
+		return nil, err
+	}
+	if snap.ACLPolicies, err = snapshotTable[domain.ACLPolicy](txn, aclPolicyTableName); err != nil {
+		return nil, err
+	}
+	if snap.ACLTokens, err = snapshotTable[domain.ACLToken](txn, aclTokenTableName); err != nil {
+		return nil, err
+	}
+	if snap.JobRegisterMethods, err = snapshotTable[domain.JobRegisterMethod](txn, jobRegisterMethodTableName); err != nil {
+		return nil, err
+	}
+	if snap.JobRegisterPlans, err = snapshotTable[domain.JobRegisterPlan](txn, jobRegisterPlanTableName); err != nil {
+		return nil, err
+	}
+	if snap.JobRegisterRules, err = snapshotTable[domain.JobRegisterRule](txn, jobRegisterRuleTableName); err != nil {
+		return nil, err
+	}
+	if snap.JobRegisterRuns, err = snapshotTable[domain.JobRegisterPlanRun](txn, jobRegisterRunTableName); err != nil {
+		return nil, err
+	}
+	if snap.Regions, err = snapshotTable[domain.Region](txn, regionTableName); err != nil {
+		return nil, err
+	}
+
+	return &snap, nil
+}
+
+// Restore replaces the entire contents of the store with the snapshot. This is
+// performed within a single transaction, so readers either see the previous
+// state or the restored state. Blocking queries are notified as each table is
+// rewritten.
+func (s *Store) Restore(snap *Snapshot) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	if err := restoreTable(txn, indexTableName, snap.Index); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, aclPolicyTableName, snap.ACLPolicies); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, aclTokenTableName, snap.ACLTokens); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, jobRegisterMethodTableName, snap.JobRegisterMethods); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, jobRegisterPlanTableName, snap.JobRegisterPlans); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, jobRegisterRuleTableName, snap.JobRegisterRules); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, jobRegisterRunTableName, snap.JobRegisterRuns); err != nil {
+		return err
+	}
+	if err := restoreTable(txn, regionTableName, snap.Regions); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func snapshotTable[T any](txn *memdb.Txn, table string) ([]*T, error) {
+	iter, err := txn.Get(table, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s table: %w", table, err)
+	}
+
+	var objs []*T
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		objs = append(objs, raw.(*T))
+	}
+	return objs, nil
+}
+
+func restoreTable[T any](txn *memdb.Txn, table string, objs []*T) error {
+	if _, err := txn.DeleteAll(table, indexID); err != nil {
+		return fmt.Errorf("failed to clear %s table: %w", table, err)
+	}
+
+	for _, obj := range objs {
+		if err := txn.Insert(table, obj); err != nil {
+			return fmt.Errorf("failed to restore %s table: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+func TestStore_SnapshotRestore(t *testing.T) {
+	srcState, err := NewStore()
+	must.NoError(t, err)
+
+	mockRegion := mock.Region()
+	_, errResp := srcState.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	mockPolicy := mock.ACLPolicy()
+	_, errResp = srcState.ACL().Policy().Create(&store.ACLPolicyCreateReq{Policy: mockPolicy})
+	must.Nil(t, errResp)
+
+	snap, err := srcState.Snapshot()
+	must.NoError(t, err)
+	must.Len(t, 1, snap.Regions)
+	must.Len(t, 1, snap.ACLPolicies)
+	must.Len(t, 2, snap.Index)
+
+	// Writes performed after the snapshot was taken must not modify it.
+	_, errResp = srcState.Region().Delete(&store.RegionDeleteReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Len(t, 1, snap.Regions)
+
+	// Restoring into a store which holds other objects must replace them, and
+	// carry over the index, so the next write continues from it.
+	dstState, err := NewStore()
+	must.NoError(t, err)
+
+	otherRegion := mock.Region()
+	otherRegion.Name = "other"
+	_, errResp = dstState.Region().Create(&store.RegionCreateReq{Region: otherRegion})
+	must.Nil(t, errResp)
+
+	must.NoError(t, dstState.Restore(snap))
+
+	listResp, errResp := dstState.Region().List(&store.RegionListReq{})
+	must.Nil(t, errResp)
+	must.Len(t, 1, listResp.Regions)
+	must.Eq(t, mockRegion.Name, listResp.Regions[0].Name)
+	must.Eq(t, 1, listResp.Index)
+
+	_, errResp = dstState.ACL().Policy().Get(&store.ACLPolicyGetReq{Name: mockPolicy.Name})
+	must.Nil(t, errResp)
+
+	createResp, errResp := dstState.Region().Create(&store.RegionCreateReq{Region: otherRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, 3, createResp.Region.Metadata.ModifyIndex)
+}
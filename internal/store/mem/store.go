@@ -13,7 +13,12 @@ type Store struct {
 	db *memdb.MemDB
 }
 
-func New() (store.State, error) {
+func New() (store.State, error) { return NewStore() }
+
+// NewStore creates an in-memory store, returning the concrete type, so callers
+// which build on the store can access functionality outside store.State, such
+// as snapshots.
+func NewStore() (*Store, error) {
 	db, err := memdb.NewMemDB(newTableSchema())
 	if err != nil {
 		return nil, err
@@ -22,6 +27,7 @@ func New() (store.State, error) {
 	return &Store{db: db}, nil
 }
 
+func (s *Store) ACL() store.ACLState                 { return &ACL{db: s.db} }
 func (s *Store) Region() store.RegionState           { return &Region{db: s.db} }
 func (s *Store) JobRegister() store.JobRegisterState { return &JobRegister{db: s.db} }
 func (s *Store) Name() string                        { return "mem" }
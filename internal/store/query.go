@@ -0,0 +1,91 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultQueryWait is the time a blocking query waits for a change when
+	// the caller does not specify a wait time.
+	DefaultQueryWait = 5 * time.Minute
+
+	// MaxQueryWait is the upper limit of time a blocking query can wait for a
+	// change. Any larger wait time requested by a caller is reduced to this.
+	MaxQueryWait = 10 * time.Minute
+)
+
+// QueryOptions is embedded within read requests and allows the caller to
+// perform a blocking query. When MinIndex is zero, the query returns
+// immediately.
+type QueryOptions struct {
+
+	// MinIndex causes the query to block until the index of the data being
+	// read is greater than this value, or the wait time is reached.
+	MinIndex uint64
+
+	// MaxWait is the maximum time the query blocks for. When zero, the
+	// DefaultQueryWait is used.
+	MaxWait time.Duration
+
+	// Ctx is an optional context, which allows the caller to abandon the
+	// query before the wait time has been reached.
+	Ctx context.Context
+}
+
+// Wait returns the time the query blocks for, taking into account the default
+// and maximum wait times.
+func (q *QueryOptions) Wait() time.Duration {
+	switch {
+	case q.MaxWait <= 0:
+		return DefaultQueryWait
+	case q.MaxWait > MaxQueryWait:
+		return MaxQueryWait
+	default:
+		return q.MaxWait
+	}
+}
+
+// QueryMeta is embedded within read responses and details the index of the
+// data that was read.
+type QueryMeta struct {
+
+	// Index is the latest modify index of the table which was read. It can be
+	// used as the MinIndex of a subsequent blocking query.
+	Index uint64 `json:"-"`
+}
+
+// QueryFunc performs a single execution of a read. It returns the index of the
+// data that was read, along with a function which blocks until the data has
+// potentially changed, or the passed context is done.
+type QueryFunc func() (uint64, func(context.Context), *ErrorResp)
+
+// BlockingQuery runs the query function until the returned index is greater
+// than the minimum index of the options, the wait time has been reached, or
+// the context is done. The query function is always executed at least once and
+// the caller should capture the result of the final execution.
+func BlockingQuery(opts *QueryOptions, fn QueryFunc) *ErrorResp {
+	if opts == nil || opts.MinIndex == 0 {
+		_, _, errResp := fn()
+		return errResp
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Wait())
+	defer cancel()
+
+	for {
+		index, waitFn, errResp := fn()
+		if errResp != nil || index > opts.MinIndex || ctx.Err() != nil {
+			return errResp
+		}
+		waitFn(ctx)
+	}
+}
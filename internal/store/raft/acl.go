@@ -0,0 +1,69 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import "github.com/rasorp/attila/internal/store"
+
+type ACL struct {
+	store *Store
+}
+
+func (a *ACL) Policy() store.ACLPolicyState { return &ACLPolicy{store: a.store} }
+func (a *ACL) Token() store.ACLTokenState   { return &ACLToken{store: a.store} }
+
+type ACLPolicy struct {
+	store *Store
+}
+
+func (a *ACLPolicy) Create(req *store.ACLPolicyCreateReq) (*store.ACLPolicyCreateResp, *store.ErrorResp) {
+	req.Policy.Metadata = leaderMetadata(req.Policy.Metadata)
+	return apply[store.ACLPolicyCreateResp](a.store, aclPolicyCreateCommand, req)
+}
+
+func (a *ACLPolicy) Delete(req *store.ACLPolicyDeleteReq) (*store.ACLPolicyDeleteResp, *store.ErrorResp) {
+	return apply[store.ACLPolicyDeleteResp](a.store, aclPolicyDeleteCommand, req)
+}
+
+func (a *ACLPolicy) Get(req *store.ACLPolicyGetReq) (*store.ACLPolicyGetResp, *store.ErrorResp) {
+	return a.store.state.ACL().Policy().Get(req)
+}
+
+func (a *ACLPolicy) List(req *store.ACLPolicyListReq) (*store.ACLPolicyListResp, *store.ErrorResp) {
+	return a.store.state.ACL().Policy().List(req)
+}
+
+func (a *ACLPolicy) Update(req *store.ACLPolicyUpdateReq) (*store.ACLPolicyUpdateResp, *store.ErrorResp) {
+	req.UpdateTime = leaderTime(req.UpdateTime)
+	return apply[store.ACLPolicyUpdateResp](a.store, aclPolicyUpdateCommand, req)
+}
+
+type ACLToken struct {
+	store *Store
+}
+
+func (a *ACLToken) Bootstrap(req *store.ACLTokenBootstrapReq) (*store.ACLTokenBootstrapResp, *store.ErrorResp) {
+	req.Token.Metadata = leaderMetadata(req.Token.Metadata)
+	return apply[store.ACLTokenBootstrapResp](a.store, aclTokenBootstrapCommand, req)
+}
+
+func (a *ACLToken) Create(req *store.ACLTokenCreateReq) (*store.ACLTokenCreateResp, *store.ErrorResp) {
+	req.Token.Metadata = leaderMetadata(req.Token.Metadata)
+	return apply[store.ACLTokenCreateResp](a.store, aclTokenCreateCommand, req)
+}
+
+func (a *ACLToken) Delete(req *store.ACLTokenDeleteReq) (*store.ACLTokenDeleteResp, *store.ErrorResp) {
+	return apply[store.ACLTokenDeleteResp](a.store, aclTokenDeleteCommand, req)
+}
+
+func (a *ACLToken) Get(req *store.ACLTokenGetReq) (*store.ACLTokenGetResp, *store.ErrorResp) {
+	return a.store.state.ACL().Token().Get(req)
+}
+
+func (a *ACLToken) GetBySecret(req *store.ACLTokenGetBySecretReq) (*store.ACLTokenGetBySecretResp, *store.ErrorResp) {
+	return a.store.state.ACL().Token().GetBySecret(req)
+}
+
+func (a *ACLToken) List(req *store.ACLTokenListReq) (*store.ACLTokenListResp, *store.ErrorResp) {
+	return a.store.state.ACL().Token().List(req)
+}
@@ -0,0 +1,80 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"fmt"
+	"strconv"
+
+	hraft "github.com/hashicorp/raft"
+
+	"github.com/rasorp/attila/internal/store/file"
+)
+
+// snapshotAdditionalData is authenticated alongside every encrypted snapshot.
+var snapshotAdditionalData = []byte("raft-snapshot")
+
+// logAdditionalData returns the data authenticated alongside the encrypted
+// entry at the passed index, so entries cannot be moved within the log.
+func logAdditionalData(index uint64) []byte {
+	return strconv.AppendUint([]byte("raft-log:"), index, 10)
+}
+
+// encryptedLogStore wraps the log store, so command entries are encrypted
+// before being written to disk. Commands hold the full store request, which
+// includes secrets such as region tokens and ACL token secret IDs. Other entry
+// types, such as configuration changes, hold no state and are stored as is.
+type encryptedLogStore struct {
+	hraft.LogStore
+
+	keyring *file.Keyring
+}
+
+// GetLog implements the hraft.LogStore interface.
+func (e *encryptedLogStore) GetLog(index uint64, log *hraft.Log) error {
+	if err := e.LogStore.GetLog(index, log); err != nil {
+		return err
+	}
+	if log.Type != hraft.LogCommand {
+		return nil
+	}
+
+	data, err := e.keyring.Decrypt(log.Data, logAdditionalData(log.Index))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt log entry %v: %w", log.Index, err)
+	}
+
+	log.Data = data
+	return nil
+}
+
+// StoreLog implements the hraft.LogStore interface.
+func (e *encryptedLogStore) StoreLog(log *hraft.Log) error {
+	return e.StoreLogs([]*hraft.Log{log})
+}
+
+// StoreLogs implements the hraft.LogStore interface. The passed entries are
+// still referenced by Raft once written, so they are copied rather than
+// modified.
+func (e *encryptedLogStore) StoreLogs(logs []*hraft.Log) error {
+	encrypted := make([]*hraft.Log, len(logs))
+
+	for i, log := range logs {
+		if log.Type != hraft.LogCommand {
+			encrypted[i] = log
+			continue
+		}
+
+		data, err := e.keyring.Encrypt(log.Data, logAdditionalData(log.Index))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt log entry %v: %w", log.Index, err)
+		}
+
+		encryptedLog := *log
+		encryptedLog.Data = data
+		encrypted[i] = &encryptedLog
+	}
+
+	return e.LogStore.StoreLogs(encrypted)
+}
@@ -0,0 +1,202 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	hraft "github.com/hashicorp/raft"
+
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/file"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+// commandType identifies the state write a log entry performs.
+type commandType uint8
+
+const (
+	regionCreateCommand commandType = iota
+	regionDeleteCommand
+	regionUpdateCommand
+	jobRegisterMethodCreateCommand
+	jobRegisterMethodDeleteCommand
+	jobRegisterMethodUpdateCommand
+	jobRegisterRuleCreateCommand
+	jobRegisterRuleDeleteCommand
+	jobRegisterRuleUpdateCommand
+	jobRegisterPlanCreateCommand
+	jobRegisterPlanDeleteCommand
+	aclPolicyCreateCommand
+	aclPolicyDeleteCommand
+	aclPolicyUpdateCommand
+	aclTokenBootstrapCommand
+	aclTokenCreateCommand
+	aclTokenDeleteCommand
+	jobRegisterRunCreateCommand
+)
+
+// command is the encoded form of a state write stored within the log. The
+// request is the store request object for the write.
+type command struct {
+	Type commandType     `json:"type"`
+	Req  json.RawMessage `json:"req"`
+}
+
+func encodeCommand(cmdType commandType, req any) ([]byte, error) {
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	return json.Marshal(&command{Type: cmdType, Req: reqBytes})
+}
+
+// fsmResponse is returned by the FSM for every applied command and holds the
+// store response, so the caller which submitted the write receives exactly
+// what the underlying state returned.
+type fsmResponse struct {
+	resp    any
+	errResp *store.ErrorResp
+}
+
+// fsm applies committed commands to a local state store. Every node holds a
+// full copy of the state, which serves reads without contacting the leader.
+type fsm struct {
+	state *mem.Store
+
+	// keyring encrypts snapshots before they are persisted and may be nil.
+	keyring *file.Keyring
+}
+
+// Apply implements the hraft.FSM interface.
+func (f *fsm) Apply(log *hraft.Log) any {
+	var cmd command
+
+	if err := json.Unmarshal(log.Data, &cmd); err != nil {
+		return &fsmResponse{errResp: store.NewErrorResp(fmt.Errorf("failed to decode command: %w", err), 500)}
+	}
+
+	switch cmd.Type {
+	case regionCreateCommand:
+		return applyCommand(cmd.Req, f.state.Region().Create)
+	case regionDeleteCommand:
+		return applyCommand(cmd.Req, f.state.Region().Delete)
+	case regionUpdateCommand:
+		return applyCommand(cmd.Req, f.state.Region().Update)
+	case jobRegisterMethodCreateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Method().Create)
+	case jobRegisterMethodDeleteCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Method().Delete)
+	case jobRegisterMethodUpdateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Method().Update)
+	case jobRegisterRuleCreateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Rule().Create)
+	case jobRegisterRuleDeleteCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Rule().Delete)
+	case jobRegisterRuleUpdateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Rule().Update)
+	case jobRegisterPlanCreateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Plan().Create)
+	case jobRegisterPlanDeleteCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Plan().Delete)
+	case jobRegisterRunCreateCommand:
+		return applyCommand(cmd.Req, f.state.JobRegister().Run().Create)
+	case aclPolicyCreateCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Policy().Create)
+	case aclPolicyDeleteCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Policy().Delete)
+	case aclPolicyUpdateCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Policy().Update)
+	case aclTokenBootstrapCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Token().Bootstrap)
+	case aclTokenCreateCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Token().Create)
+	case aclTokenDeleteCommand:
+		return applyCommand(cmd.Req, f.state.ACL().Token().Delete)
+	default:
+		return &fsmResponse{errResp: store.NewErrorResp(fmt.Errorf("unknown command type %v", cmd.Type), 500)}
+	}
+}
+
+func applyCommand[Req, Resp any](raw json.RawMessage, fn func(*Req) (*Resp, *store.ErrorResp)) *fsmResponse {
+	var req Req
+
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return &fsmResponse{errResp: store.NewErrorResp(fmt.Errorf("failed to decode request: %w", err), 500)}
+	}
+
+	resp, errResp := fn(&req)
+	if errResp != nil {
+		return &fsmResponse{errResp: errResp}
+	}
+	return &fsmResponse{resp: resp}
+}
+
+// Snapshot implements the hraft.FSM interface. The state is copied while no
+// commands are being applied, and encoded by Persist in the background.
+func (f *fsm) Snapshot() (hraft.FSMSnapshot, error) {
+	snap, err := f.state.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot state: %w", err)
+	}
+	return &fsmSnapshot{snap: snap, keyring: f.keyring}, nil
+}
+
+// Restore implements the hraft.FSM interface and replaces the local state with
+// the contents of the snapshot.
+func (f *fsm) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if f.keyring != nil {
+		if data, err = f.keyring.Decrypt(data, snapshotAdditionalData); err != nil {
+			return fmt.Errorf("failed to decrypt snapshot: %w", err)
+		}
+	}
+
+	var snap mem.Snapshot
+
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if err := f.state.Restore(&snap); err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	return nil
+}
+
+// fsmSnapshot implements the hraft.FSMSnapshot interface.
+type fsmSnapshot struct {
+	snap    *mem.Snapshot
+	keyring *file.Keyring
+}
+
+func (s *fsmSnapshot) Persist(sink hraft.SnapshotSink) error {
+	data, err := json.Marshal(s.snap)
+	if err != nil {
+		_ = sink.Cancel()
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if s.keyring != nil {
+		if data, err = s.keyring.Encrypt(data, snapshotAdditionalData); err != nil {
+			_ = sink.Cancel()
+			return fmt.Errorf("failed to encrypt snapshot: %w", err)
+		}
+	}
+
+	if _, err := sink.Write(data); err != nil {
+		_ = sink.Cancel()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s *fsmSnapshot) Release() {}
@@ -0,0 +1,107 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import "github.com/rasorp/attila/internal/store"
+
+type JobRegister struct {
+	store *Store
+}
+
+func (j *JobRegister) Method() store.JobRegisterMethodState {
+	return &JobRegisterMethod{store: j.store}
+}
+func (j *JobRegister) Plan() store.JobRegisterPlanState { return &JobRegisterPlan{store: j.store} }
+func (j *JobRegister) Rule() store.JobRegisterRuleState { return &JobRegisterRule{store: j.store} }
+func (j *JobRegister) Run() store.JobRegisterRunState   { return &JobRegisterRun{store: j.store} }
+
+type JobRegisterMethod struct {
+	store *Store
+}
+
+func (j *JobRegisterMethod) Create(req *store.JobRegisterMethodCreateReq) (*store.JobRegisterMethodCreateResp, *store.ErrorResp) {
+	req.Method.Metadata = leaderMetadata(req.Method.Metadata)
+	return apply[store.JobRegisterMethodCreateResp](j.store, jobRegisterMethodCreateCommand, req)
+}
+
+func (j *JobRegisterMethod) Delete(req *store.JobRegisterMethodDeleteReq) (*store.JobRegisterMethodDeleteResp, *store.ErrorResp) {
+	return apply[store.JobRegisterMethodDeleteResp](j.store, jobRegisterMethodDeleteCommand, req)
+}
+
+func (j *JobRegisterMethod) Get(req *store.JobRegisterMethodGetReq) (*store.JobRegisterMethodGetResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Method().Get(req)
+}
+
+func (j *JobRegisterMethod) List(req *store.JobRegisterMethodListReq) (*store.JobRegisterMethodListResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Method().List(req)
+}
+
+func (j *JobRegisterMethod) Update(req *store.JobRegisterMethodUpdateReq) (*store.JobRegisterMethodUpdateResp, *store.ErrorResp) {
+	req.UpdateTime = leaderTime(req.UpdateTime)
+	return apply[store.JobRegisterMethodUpdateResp](j.store, jobRegisterMethodUpdateCommand, req)
+}
+
+type JobRegisterPlan struct {
+	store *Store
+}
+
+func (j *JobRegisterPlan) Create(req *store.JobRegisterPlanCreateReq) (*store.JobRegisterPlanCreateResp, *store.ErrorResp) {
+	req.Plan.Metadata = leaderMetadata(req.Plan.Metadata)
+	return apply[store.JobRegisterPlanCreateResp](j.store, jobRegisterPlanCreateCommand, req)
+}
+
+func (j *JobRegisterPlan) Delete(req *store.JobRegisterPlanDeleteReq) (*store.JobRegisterPlanDeleteResp, *store.ErrorResp) {
+	return apply[store.JobRegisterPlanDeleteResp](j.store, jobRegisterPlanDeleteCommand, req)
+}
+
+func (j *JobRegisterPlan) Get(req *store.JobRegisterPlanGetReq) (*store.JobRegisterPlanGetResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Plan().Get(req)
+}
+
+func (j *JobRegisterPlan) List(req *store.JobRegisterPlanListReq) (*store.JobRegisterPlanListResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Plan().List(req)
+}
+
+type JobRegisterRule struct {
+	store *Store
+}
+
+func (j *JobRegisterRule) Create(req *store.JobRegisterRuleCreateReq) (*store.JobRegisterRuleCreateResp, *store.ErrorResp) {
+	req.Rule.Metadata = leaderMetadata(req.Rule.Metadata)
+	return apply[store.JobRegisterRuleCreateResp](j.store, jobRegisterRuleCreateCommand, req)
+}
+
+func (j *JobRegisterRule) Delete(req *store.JobRegisterRuleDeleteReq) (*store.JobRegisterRuleDeleteResp, *store.ErrorResp) {
+	return apply[store.JobRegisterRuleDeleteResp](j.store, jobRegisterRuleDeleteCommand, req)
+}
+
+func (j *JobRegisterRule) Get(req *store.JobRegisterRuleGetReq) (*store.JobRegisterRuleGetResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Rule().Get(req)
+}
+
+func (j *JobRegisterRule) List(req *store.JobRegisterRuleListReq) (*store.JobRegisterRuleListResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Rule().List(req)
+}
+
+func (j *JobRegisterRule) Update(req *store.JobRegisterRuleUpdateReq) (*store.JobRegisterRuleUpdateResp, *store.ErrorResp) {
+	req.UpdateTime = leaderTime(req.UpdateTime)
+	return apply[store.JobRegisterRuleUpdateResp](j.store, jobRegisterRuleUpdateCommand, req)
+}
+
+type JobRegisterRun struct {
+	store *Store
+}
+
+func (j *JobRegisterRun) Create(req *store.JobRegisterRunCreateReq) (*store.JobRegisterRunCreateResp, *store.ErrorResp) {
+	req.Run.Metadata = leaderMetadata(req.Run.Metadata)
+	return apply[store.JobRegisterRunCreateResp](j.store, jobRegisterRunCreateCommand, req)
+}
+
+func (j *JobRegisterRun) Get(req *store.JobRegisterRunGetReq) (*store.JobRegisterRunGetResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Run().Get(req)
+}
+
+func (j *JobRegisterRun) List(req *store.JobRegisterRunListReq) (*store.JobRegisterRunListResp, *store.ErrorResp) {
+	return j.store.state.JobRegister().Run().List(req)
+}
@@ -0,0 +1,32 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import "github.com/rasorp/attila/internal/store"
+
+type Region struct {
+	store *Store
+}
+
+func (r *Region) Create(req *store.RegionCreateReq) (*store.RegionCreateResp, *store.ErrorResp) {
+	req.Region.Metadata = leaderMetadata(req.Region.Metadata)
+	return apply[store.RegionCreateResp](r.store, regionCreateCommand, req)
+}
+
+func (r *Region) Delete(req *store.RegionDeleteReq) (*store.RegionDeleteResp, *store.ErrorResp) {
+	return apply[store.RegionDeleteResp](r.store, regionDeleteCommand, req)
+}
+
+func (r *Region) Get(req *store.RegionGetReq) (*store.RegionGetResp, *store.ErrorResp) {
+	return r.store.state.Region().Get(req)
+}
+
+func (r *Region) List(req *store.RegionListReq) (*store.RegionListResp, *store.ErrorResp) {
+	return r.store.state.Region().List(req)
+}
+
+func (r *Region) Update(req *store.RegionUpdateReq) (*store.RegionUpdateResp, *store.ErrorResp) {
+	req.UpdateTime = leaderTime(req.UpdateTime)
+	return apply[store.RegionUpdateResp](r.store, regionUpdateCommand, req)
+}
@@ -0,0 +1,412 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	hraft "github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zapio"
+
+	"github.com/rasorp/attila/internal/domain"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/file"
+	"github.com/rasorp/attila/internal/store/mem"
+)
+
+const (
+	// applyTimeout is the maximum time a write waits to be committed by the
+	// cluster before an error is returned to the caller.
+	applyTimeout = 10 * time.Second
+
+	// barrierRetryInterval is the time waited before retrying a failed barrier
+	// after gaining leadership.
+	barrierRetryInterval = 1 * time.Second
+
+	// logCacheSize is the number of recent log entries held in memory, so
+	// followers can be replicated to without reading from disk.
+	logCacheSize = 512
+
+	// snapshotsRetained is the number of snapshots kept within the data
+	// directory. Older snapshots are deleted once a new one is persisted.
+	snapshotsRetained = 2
+
+	// transportMaxPool and transportTimeout configure the transport connection
+	// pool and the I/O deadline applied to each RPC.
+	transportMaxPool = 3
+	transportTimeout = 10 * time.Second
+)
+
+// Config is the configuration used to create a Raft replicated state store.
+type Config struct {
+	NodeID           string
+	BindAddr         string
+	DataDir          string
+	HeartbeatTimeout time.Duration
+	ElectionTimeout  time.Duration
+	Peers            []*Peer
+	Logger           *zap.Logger
+
+	// TLSConfig secures the transport between servers and is required. It is
+	// created using NewTLSConfig.
+	TLSConfig *tls.Config
+
+	// Keyring encrypts log entries and snapshots before they are written to
+	// the data directory, as both contain secrets. A nil keyring disables
+	// encryption.
+	Keyring *file.Keyring
+
+	// SnapshotThreshold and TrailingLogs control log compaction. A snapshot
+	// is taken once the threshold of entries has been written since the last,
+	// after which all but the trailing entries are removed from the log. Zero
+	// values use the library defaults.
+	SnapshotThreshold uint64
+	TrailingLogs      uint64
+}
+
+// Peer is a single member of the Raft cluster.
+type Peer struct {
+	ID          string
+	Address     string
+	HTTPAddress string
+}
+
+// Store is a store.State implementation which replicates all writes across the
+// cluster using Raft. Every server holds a full copy of the state in memory,
+// which serves reads locally. Reads on followers may therefore be slightly
+// stale. Writes are only accepted by the leader.
+type Store struct {
+	raft      *hraft.Raft
+	state     *mem.Store
+	logger    *zap.Logger
+	httpAddrs map[hraft.ServerID]string
+
+	transport hraft.Transport
+	boltStore *raftboltdb.BoltStore
+
+	// leaderCh is the channel exposed via LeaderCh. It only receives true once
+	// the leader has applied all previously committed entries.
+	leaderCh   chan bool
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// New creates a Raft store which communicates with its peers using mutual TLS,
+// listening on the configured bind address. The address of the local peer
+// block is advertised to the rest of the cluster.
+func New(cfg *Config) (*Store, error) {
+	if cfg.TLSConfig == nil {
+		return nil, errors.New("raft transport requires a TLS configuration")
+	}
+
+	var advertise net.Addr
+
+	for _, peer := range cfg.Peers {
+		if peer.ID != cfg.NodeID {
+			continue
+		}
+		addr, err := net.ResolveTCPAddr("tcp", peer.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve raft advertise address: %w", err)
+		}
+		advertise = addr
+	}
+
+	streamLayer, err := newTLSStreamLayer(cfg.BindAddr, advertise, cfg.TLSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup raft listener: %w", err)
+	}
+
+	transport := hraft.NewNetworkTransportWithLogger(
+		streamLayer, transportMaxPool, transportTimeout, newHCLogger(cfg.Logger))
+
+	s, err := newStore(cfg, transport)
+	if err != nil {
+		_ = transport.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// newStore creates the store using the passed transport, which is closed when
+// the store is shutdown.
+func newStore(cfg *Config, transport hraft.Transport) (*Store, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	hcLogger := newHCLogger(logger)
+
+	raftCfg := hraft.DefaultConfig()
+	raftCfg.LocalID = hraft.ServerID(cfg.NodeID)
+	raftCfg.Logger = hcLogger
+
+	if cfg.HeartbeatTimeout > 0 {
+		raftCfg.HeartbeatTimeout = cfg.HeartbeatTimeout
+	}
+	if cfg.ElectionTimeout > 0 {
+		raftCfg.ElectionTimeout = cfg.ElectionTimeout
+	}
+	if cfg.SnapshotThreshold > 0 {
+		raftCfg.SnapshotThreshold = cfg.SnapshotThreshold
+	}
+	if cfg.TrailingLogs > 0 {
+		raftCfg.TrailingLogs = cfg.TrailingLogs
+	}
+
+	// The leader lease must not exceed the heartbeat timeout, which the
+	// library enforces when validating the configuration.
+	raftCfg.LeaderLeaseTimeout = min(raftCfg.LeaderLeaseTimeout, raftCfg.HeartbeatTimeout)
+
+	memState, err := mem.NewStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup local state: %w", err)
+	}
+
+	boltStore, err := raftboltdb.New(raftboltdb.Options{Path: filepath.Join(cfg.DataDir, "raft.db")})
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup raft log: %w", err)
+	}
+
+	var diskLogStore hraft.LogStore = boltStore
+	if cfg.Keyring != nil {
+		diskLogStore = &encryptedLogStore{LogStore: boltStore, keyring: cfg.Keyring}
+	}
+
+	logStore, err := hraft.NewLogCache(logCacheSize, diskLogStore)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, fmt.Errorf("failed to setup raft log cache: %w", err)
+	}
+
+	snapshotStore, err := hraft.NewFileSnapshotStoreWithLogger(cfg.DataDir, snapshotsRetained, hcLogger)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, fmt.Errorf("failed to setup raft snapshots: %w", err)
+	}
+
+	// Every server bootstraps with the same configuration on first start, so
+	// the cluster can elect a leader once a quorum of servers is running.
+	hasState, err := hraft.HasExistingState(logStore, boltStore, snapshotStore)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, fmt.Errorf("failed to read raft state: %w", err)
+	}
+
+	httpAddrs := make(map[hraft.ServerID]string, len(cfg.Peers))
+	servers := make([]hraft.Server, len(cfg.Peers))
+
+	for i, peer := range cfg.Peers {
+		httpAddrs[hraft.ServerID(peer.ID)] = peer.HTTPAddress
+		servers[i] = hraft.Server{
+			ID:      hraft.ServerID(peer.ID),
+			Address: hraft.ServerAddress(peer.Address),
+		}
+	}
+
+	if !hasState {
+		if err := hraft.BootstrapCluster(raftCfg, logStore, boltStore, snapshotStore,
+			transport, hraft.Configuration{Servers: servers}); err != nil {
+			_ = boltStore.Close()
+			return nil, fmt.Errorf("failed to bootstrap raft cluster: %w", err)
+		}
+	}
+
+	r, err := hraft.NewRaft(raftCfg, &fsm{state: memState, keyring: cfg.Keyring}, logStore, boltStore, snapshotStore, transport)
+	if err != nil {
+		_ = boltStore.Close()
+		return nil, fmt.Errorf("failed to setup raft: %w", err)
+	}
+
+	s := Store{
+		raft:       r,
+		state:      memState,
+		logger:     logger.Named("raft_store"),
+		httpAddrs:  httpAddrs,
+		transport:  transport,
+		boltStore:  boltStore,
+		leaderCh:   make(chan bool, 1),
+		shutdownCh: make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.runLeadershipMonitor()
+
+	return &s, nil
+}
+
+// newHCLogger returns a logger for the Raft library, which writes its output
+// to the passed zap logger.
+func newHCLogger(logger *zap.Logger) hclog.Logger {
+	if logger == nil {
+		return hclog.NewNullLogger()
+	}
+	return hclog.New(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  hclog.Info,
+		Output: &zapio.Writer{Log: logger.Named("raft"), Level: zapcore.InfoLevel},
+	})
+}
+
+func (s *Store) ACL() store.ACLState                 { return &ACL{store: s} }
+func (s *Store) JobRegister() store.JobRegisterState { return &JobRegister{store: s} }
+func (s *Store) Region() store.RegionState           { return &Region{store: s} }
+func (s *Store) Name() string                        { return "raft" }
+
+// IsLeader implements the store.Replicated interface.
+func (s *Store) IsLeader() bool { return s.raft.State() == hraft.Leader }
+
+// LeaderHTTPAddress implements the store.Replicated interface.
+func (s *Store) LeaderHTTPAddress() string {
+	_, id := s.raft.LeaderWithID()
+	return s.httpAddrs[id]
+}
+
+// LeaderCh implements the store.Replicated interface.
+func (s *Store) LeaderCh() <-chan bool { return s.leaderCh }
+
+// Shutdown implements the store.Replicated interface.
+func (s *Store) Shutdown() error {
+	select {
+	case <-s.shutdownCh:
+		return nil
+	default:
+		close(s.shutdownCh)
+	}
+
+	var errs []error
+
+	// Shutting down Raft first fails any barrier the leadership monitor is
+	// waiting on, so it can exit promptly.
+	if err := s.raft.Shutdown().Error(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown raft: %w", err))
+	}
+
+	s.wg.Wait()
+
+	// The leadership monitor has exited, so nothing else can write to the
+	// channel. Closing it allows consumers ranging over it to exit.
+	close(s.leaderCh)
+
+	if closer, ok := s.transport.(hraft.WithClose); ok {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close raft transport: %w", err))
+		}
+	}
+	if err := s.boltStore.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close raft log: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// runLeadershipMonitor forwards leadership transitions from Raft. When
+// leadership is gained, it waits until all committed entries are applied, so
+// consumers do not act on partial state.
+func (s *Store) runLeadershipMonitor() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case isLeader := <-s.raft.LeaderCh():
+			if isLeader && !s.establishLeadership() {
+				continue
+			}
+			s.notifyLeadership(isLeader)
+		}
+	}
+}
+
+// establishLeadership waits until all committed entries are applied after
+// gaining leadership. A failed barrier is retried while this server remains
+// the leader, otherwise consumers would never be notified of the leadership.
+// It returns false if the store is shutdown, or leadership is lost, which Raft
+// then notifies the monitor of.
+func (s *Store) establishLeadership() bool {
+	for {
+		err := s.raft.Barrier(applyTimeout).Error()
+		if err == nil {
+			return true
+		}
+
+		s.logger.Error("failed to apply barrier after gaining leadership",
+			zap.Duration("retry_interval", barrierRetryInterval), zap.Error(err))
+
+		if s.raft.State() != hraft.Leader {
+			return false
+		}
+
+		select {
+		case <-s.shutdownCh:
+			return false
+		case <-time.After(barrierRetryInterval):
+		}
+	}
+}
+
+// notifyLeadership publishes the leadership status, replacing any value the
+// reader has not yet consumed. It is only called by the leadership monitor, so
+// there is a single writer.
+func (s *Store) notifyLeadership(isLeader bool) {
+	select {
+	case <-s.leaderCh:
+	default:
+	}
+	s.leaderCh <- isLeader
+}
+
+// leaderMetadata returns the passed metadata, or fresh metadata when it is nil.
+// The state would otherwise create it while applying the write, reading the
+// clock on every server, so it is set once before the write is replicated.
+func leaderMetadata(m *domain.Metadata) *domain.Metadata {
+	if m == nil {
+		return domain.NewMetadata()
+	}
+	return m
+}
+
+// leaderTime returns the passed time, or the current time when it is zero. It
+// is used to set the update time of objects before the write is replicated,
+// so every server records the same value.
+func leaderTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
+// apply submits the write to the cluster and returns the response from the
+// local state once it has been applied.
+func apply[Resp any](s *Store, cmdType commandType, req any) (*Resp, *store.ErrorResp) {
+	data, err := encodeCommand(cmdType, req)
+	if err != nil {
+		return nil, store.NewErrorResp(err, 500)
+	}
+
+	future := s.raft.Apply(data, applyTimeout)
+	if err := future.Error(); err != nil {
+		if errors.Is(err, hraft.ErrNotLeader) {
+			return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 503)
+		}
+		return nil, store.NewErrorResp(fmt.Errorf("state: %w", err), 500)
+	}
+
+	fsmResp := future.Response().(*fsmResponse)
+	if fsmResp.errResp != nil {
+		return nil, fsmResp.errResp
+	}
+	return fsmResp.resp.(*Resp), nil
+}
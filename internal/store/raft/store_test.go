@@ -0,0 +1,394 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	hraft "github.com/hashicorp/raft"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+	"github.com/rasorp/attila/internal/store/file"
+)
+
+// testConfig returns a configuration suitable for running the store in tests,
+// with timeouts short enough for elections to complete quickly.
+func testConfig(t *testing.T, id string, peers []*Peer) *Config {
+	return &Config{
+		NodeID:           id,
+		DataDir:          t.TempDir(),
+		HeartbeatTimeout: 50 * time.Millisecond,
+		ElectionTimeout:  50 * time.Millisecond,
+		Peers:            peers,
+	}
+}
+
+// testCluster creates an in-process cluster of the passed size, connected via
+// in-memory transports. All stores are shutdown when the test completes.
+func testCluster(t *testing.T, size int) ([]*hraft.InmemTransport, []*Store) {
+	t.Helper()
+
+	peers := make([]*Peer, size)
+	transports := make([]*hraft.InmemTransport, size)
+
+	for i := range peers {
+		id := fmt.Sprintf("attila-%v", i)
+		addr, transport := hraft.NewInmemTransport(hraft.ServerAddress(id))
+		peers[i] = &Peer{ID: id, Address: string(addr), HTTPAddress: "http://" + id}
+		transports[i] = transport
+	}
+
+	for _, a := range transports {
+		for _, b := range transports {
+			a.Connect(b.LocalAddr(), b)
+		}
+	}
+
+	stores := make([]*Store, size)
+
+	for i, peer := range peers {
+		s, err := newStore(testConfig(t, peer.ID, peers), transports[i])
+		must.NoError(t, err)
+
+		stores[i] = s
+		t.Cleanup(func() { _ = s.Shutdown() })
+	}
+
+	return transports, stores
+}
+
+// waitForLeader waits until exactly one of the running stores is the leader
+// and all running stores agree on it.
+func waitForLeader(t *testing.T, stores []*Store) *Store {
+	t.Helper()
+
+	var leader *Store
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			leader = nil
+			for _, s := range stores {
+				if s.IsLeader() {
+					if leader != nil {
+						return false
+					}
+					leader = s
+				}
+			}
+			if leader == nil {
+				return false
+			}
+			for _, s := range stores {
+				if s.LeaderHTTPAddress() != leader.LeaderHTTPAddress() {
+					return false
+				}
+			}
+			return true
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	return leader
+}
+
+func waitForRegion(t *testing.T, s *Store, name string) {
+	t.Helper()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			_, errResp := s.Region().Get(&store.RegionGetReq{RegionName: name})
+			return errResp == nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestStore_SingleNode(t *testing.T) {
+	_, stores := testCluster(t, 1)
+
+	leader := waitForLeader(t, stores)
+	must.Eq(t, "http://attila-0", leader.LeaderHTTPAddress())
+
+	mockRegion := mock.Region()
+
+	createResp, errResp := leader.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion.Name, createResp.Region.Name)
+
+	// The FSM returns the error from the underlying state unmodified.
+	_, errResp = leader.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.NotNil(t, errResp)
+	must.Eq(t, 400, errResp.StatusCode())
+
+	getResp, errResp := leader.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+	must.Eq(t, mockRegion.API, getResp.Region.API)
+
+	select {
+	case isLeader := <-leader.LeaderCh():
+		must.True(t, isLeader)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for leadership notification")
+	}
+}
+
+func TestStore_Failover(t *testing.T) {
+	transports, stores := testCluster(t, 3)
+
+	leader := waitForLeader(t, stores)
+
+	mockRegion := mock.Region()
+
+	_, errResp := leader.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	var (
+		followers       []*Store
+		leaderTransport *hraft.InmemTransport
+	)
+
+	for i, s := range stores {
+		if s != leader {
+			followers = append(followers, s)
+		} else {
+			leaderTransport = transports[i]
+		}
+	}
+
+	// Ensure the write is replicated to all followers and that they reject
+	// writes, directing the caller to the leader.
+	for _, follower := range followers {
+		waitForRegion(t, follower, mockRegion.Name)
+		must.Eq(t, leader.LeaderHTTPAddress(), follower.LeaderHTTPAddress())
+
+		_, errResp := follower.Region().Create(&store.RegionCreateReq{Region: mock.Region()})
+		must.NotNil(t, errResp)
+		must.Eq(t, 503, errResp.StatusCode())
+	}
+
+	oldLeaderAddr := leader.LeaderHTTPAddress()
+
+	// Partition and stop the leader, which should trigger an election between
+	// the remaining servers.
+	for _, transport := range transports {
+		transport.Disconnect(leaderTransport.LocalAddr())
+	}
+	leaderTransport.DisconnectAll()
+	must.NoError(t, leader.Shutdown())
+
+	newLeader := waitForLeader(t, followers)
+	must.NotEq(t, oldLeaderAddr, newLeader.LeaderHTTPAddress())
+
+	// The new leader must have all previously committed writes and be able to
+	// accept new ones, which are replicated to the remaining follower.
+	_, errResp = newLeader.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+	must.Nil(t, errResp)
+
+	newRegion := mock.Region()
+	newRegion.Name = "new-region"
+
+	_, errResp = newLeader.Region().Create(&store.RegionCreateReq{Region: newRegion})
+	must.Nil(t, errResp)
+
+	for _, follower := range followers {
+		waitForRegion(t, follower, newRegion.Name)
+	}
+}
+
+func TestStore_DeterministicApply(t *testing.T) {
+	_, stores := testCluster(t, 3)
+
+	leader := waitForLeader(t, stores)
+
+	mockRegion := mock.Region()
+	mockRegion.Metadata = nil
+
+	_, errResp := leader.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	updatedRegion := mock.Region()
+	updatedRegion.Name = mockRegion.Name
+	updatedRegion.Metadata = nil
+
+	updateResp, errResp := leader.Region().Update(&store.RegionUpdateReq{Region: updatedRegion})
+	must.Nil(t, errResp)
+
+	// Every server must record the timestamps set by the leader, rather than
+	// reading its own clock when applying the writes.
+	for _, s := range stores {
+		must.Wait(t, wait.InitialSuccess(
+			wait.BoolFunc(func() bool {
+				getResp, errResp := s.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+				return errResp == nil && getResp.Region.Metadata.ModifyIndex == updateResp.Region.Metadata.ModifyIndex
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(10*time.Millisecond),
+		))
+
+		getResp, errResp := s.Region().Get(&store.RegionGetReq{RegionName: mockRegion.Name})
+		must.Nil(t, errResp)
+		must.True(t, updateResp.Region.Metadata.CreateTime.Equal(getResp.Region.Metadata.CreateTime))
+		must.True(t, updateResp.Region.Metadata.UpdateTime.Equal(getResp.Region.Metadata.UpdateTime))
+	}
+}
+
+func TestStore_Restart(t *testing.T) {
+	addr, transport := hraft.NewInmemTransport("attila-0")
+	cfg := testConfig(t, "attila-0", []*Peer{{ID: "attila-0", Address: string(addr)}})
+
+	s, err := newStore(cfg, transport)
+	must.NoError(t, err)
+
+	waitForLeader(t, []*Store{s})
+
+	mockRegion := mock.Region()
+
+	_, errResp := s.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+	must.NoError(t, s.Shutdown())
+
+	// Create a new store using the same data directory. The log should be
+	// replayed into the local state once the node regains leadership.
+	_, transport = hraft.NewInmemTransport(addr)
+
+	s, err = newStore(cfg, transport)
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = s.Shutdown() })
+
+	waitForLeader(t, []*Store{s})
+	waitForRegion(t, s, mockRegion.Name)
+}
+
+func TestStore_Snapshot(t *testing.T) {
+	addr, transport := hraft.NewInmemTransport("attila-0")
+
+	cfg := testConfig(t, "attila-0", []*Peer{{ID: "attila-0", Address: string(addr)}})
+	cfg.SnapshotThreshold = 4
+	cfg.TrailingLogs = 2
+
+	s, err := newStore(cfg, transport)
+	must.NoError(t, err)
+
+	waitForLeader(t, []*Store{s})
+
+	regionNames := make([]string, 8)
+
+	for i := range regionNames {
+		mockRegion := mock.Region()
+		mockRegion.Name = fmt.Sprintf("region-%v", i)
+		regionNames[i] = mockRegion.Name
+
+		_, errResp := s.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+		must.Nil(t, errResp)
+	}
+
+	// Force a snapshot rather than waiting on the background check, which
+	// compacts the log down to the trailing entries.
+	must.NoError(t, s.raft.Snapshot().Error())
+
+	snapshots, err := os.ReadDir(filepath.Join(cfg.DataDir, "snapshots"))
+	must.NoError(t, err)
+	must.SliceNotEmpty(t, snapshots)
+
+	firstIndex, err := s.boltStore.FirstIndex()
+	must.NoError(t, err)
+	must.Greater(t, 1, firstIndex)
+
+	must.NoError(t, s.Shutdown())
+
+	// A restarted store must restore the state from the snapshot, as the log
+	// no longer holds the entries which created the earlier regions.
+	_, transport = hraft.NewInmemTransport(addr)
+
+	s, err = newStore(cfg, transport)
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = s.Shutdown() })
+
+	waitForLeader(t, []*Store{s})
+
+	for _, name := range regionNames {
+		waitForRegion(t, s, name)
+	}
+
+	listResp, errResp := s.Region().List(&store.RegionListReq{})
+	must.Nil(t, errResp)
+	must.Len(t, len(regionNames), listResp.Regions)
+}
+
+func TestStore_Encryption(t *testing.T) {
+	keyring, err := file.NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, file.KeyringKeySize)})
+	must.NoError(t, err)
+
+	addr, transport := hraft.NewInmemTransport("attila-0")
+
+	cfg := testConfig(t, "attila-0", []*Peer{{ID: "attila-0", Address: string(addr)}})
+	cfg.Keyring = keyring
+
+	s, err := newStore(cfg, transport)
+	must.NoError(t, err)
+
+	waitForLeader(t, []*Store{s})
+
+	// Write one region which is captured by a snapshot, and another which is
+	// only held within the log.
+	snapshotRegion := mock.Region()
+	_, errResp := s.Region().Create(&store.RegionCreateReq{Region: snapshotRegion})
+	must.Nil(t, errResp)
+
+	must.NoError(t, s.raft.Snapshot().Error())
+
+	logRegion := mock.Region()
+	_, errResp = s.Region().Create(&store.RegionCreateReq{Region: logRegion})
+	must.Nil(t, errResp)
+
+	must.NoError(t, s.Shutdown())
+
+	// Neither the log nor the snapshot may contain the region secrets in
+	// plaintext.
+	snapshotFiles, err := filepath.Glob(filepath.Join(cfg.DataDir, "snapshots", "*", "state.bin"))
+	must.NoError(t, err)
+	must.SliceNotEmpty(t, snapshotFiles)
+
+	for _, path := range append(snapshotFiles, filepath.Join(cfg.DataDir, "raft.db")) {
+		fileBytes, err := os.ReadFile(path)
+		must.NoError(t, err)
+		must.False(t, bytes.Contains(fileBytes, []byte(snapshotRegion.Auth.Token)))
+		must.False(t, bytes.Contains(fileBytes, []byte(snapshotRegion.Name)))
+		must.False(t, bytes.Contains(fileBytes, []byte(logRegion.Name)))
+	}
+
+	// A store using a keyring which does not hold the key cannot read the log
+	// or restore the snapshot.
+	otherKeyring, err := file.NewKeyring("k2", map[string][]byte{"k2": bytes.Repeat([]byte{2}, file.KeyringKeySize)})
+	must.NoError(t, err)
+
+	otherCfg := *cfg
+	otherCfg.Keyring = otherKeyring
+
+	_, transport = hraft.NewInmemTransport(addr)
+
+	_, err = newStore(&otherCfg, transport)
+	must.ErrorContains(t, err, `keyring key "k1" not found`)
+
+	// Restarting with the keyring restores the snapshot and replays the log.
+	_, transport = hraft.NewInmemTransport(addr)
+
+	s, err = newStore(cfg, transport)
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = s.Shutdown() })
+
+	waitForLeader(t, []*Store{s})
+	waitForRegion(t, s, snapshotRegion.Name)
+	waitForRegion(t, s, logRegion.Name)
+}
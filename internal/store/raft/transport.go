@@ -0,0 +1,86 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	hraft "github.com/hashicorp/raft"
+)
+
+// NewTLSConfig creates the mutual TLS configuration used by the Raft transport.
+// Every server presents its certificate when accepting and dialing
+// connections, and requires the remote server to present a certificate signed
+// by the CA. If the server name is empty, certificates are verified against
+// the host of the peer address.
+func NewTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return nil, errors.New("failed to parse CA: no certificates found")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// tlsStreamLayer implements the hraft.StreamLayer interface, so all Raft RPCs
+// between servers are performed over mutually authenticated TLS connections.
+type tlsStreamLayer struct {
+	net.Listener
+
+	advertise net.Addr
+	tlsConfig *tls.Config
+}
+
+// newTLSStreamLayer listens on the bind address, wrapping all accepted
+// connections in TLS. The advertise address is reported to the rest of the
+// cluster and defaults to the listener address if it is nil.
+func newTLSStreamLayer(bindAddr string, advertise net.Addr, tlsConfig *tls.Config) (*tlsStreamLayer, error) {
+	ln, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if advertise == nil {
+		advertise = ln.Addr()
+	}
+
+	return &tlsStreamLayer{
+		Listener:  tls.NewListener(ln, tlsConfig),
+		advertise: advertise,
+		tlsConfig: tlsConfig,
+	}, nil
+}
+
+// Addr implements the net.Listener interface and returns the advertise
+// address, which the transport uses to identify the local server.
+func (t *tlsStreamLayer) Addr() net.Addr { return t.advertise }
+
+// Dial implements the hraft.StreamLayer interface.
+func (t *tlsStreamLayer) Dial(address hraft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	return tls.DialWithDialer(&dialer, "tcp", string(address), t.tlsConfig)
+}
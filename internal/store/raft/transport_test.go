@@ -0,0 +1,151 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package raft
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	hraft "github.com/hashicorp/raft"
+	"github.com/shoenig/test/must"
+
+	"github.com/rasorp/attila/internal/helper/test/certs"
+	"github.com/rasorp/attila/internal/helper/test/mock"
+	"github.com/rasorp/attila/internal/store"
+)
+
+// freeAddr returns a loopback address with a port which is not in use.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	must.NoError(t, ln.Close())
+	return ln.Addr().String()
+}
+
+func TestStore_TLS(t *testing.T) {
+	ca := certs.NewCA(t)
+
+	peers := make([]*Peer, 3)
+	for i := range peers {
+		id := fmt.Sprintf("attila-%v", i)
+		peers[i] = &Peer{ID: id, Address: freeAddr(t), HTTPAddress: "http://" + id}
+	}
+
+	stores := make([]*Store, len(peers))
+
+	for i, peer := range peers {
+		certFile, keyFile := ca.Issue(t, peer.ID)
+
+		tlsConfig, err := NewTLSConfig(ca.CertFile, certFile, keyFile, "")
+		must.NoError(t, err)
+
+		cfg := testConfig(t, peer.ID, peers)
+		cfg.BindAddr = peer.Address
+		cfg.TLSConfig = tlsConfig
+
+		s, err := New(cfg)
+		must.NoError(t, err)
+
+		stores[i] = s
+		t.Cleanup(func() { _ = s.Shutdown() })
+	}
+
+	leader := waitForLeader(t, stores)
+
+	mockRegion := mock.Region()
+
+	_, errResp := leader.Region().Create(&store.RegionCreateReq{Region: mockRegion})
+	must.Nil(t, errResp)
+
+	for _, s := range stores {
+		waitForRegion(t, s, mockRegion.Name)
+	}
+}
+
+func TestStore_TLSRequired(t *testing.T) {
+	cfg := testConfig(t, "attila-0", []*Peer{{ID: "attila-0", Address: "127.0.0.1:4647"}})
+	cfg.BindAddr = "127.0.0.1:0"
+
+	_, err := New(cfg)
+	must.ErrorContains(t, err, "requires a TLS configuration")
+}
+
+func TestTLSStreamLayer(t *testing.T) {
+	echoMsg := []byte("attila")
+
+	ca := certs.NewCA(t)
+
+	certFile, keyFile := ca.Issue(t, "attila-0")
+	serverConfig, err := NewTLSConfig(ca.CertFile, certFile, keyFile, "")
+	must.NoError(t, err)
+
+	layer, err := newTLSStreamLayer("127.0.0.1:0", nil, serverConfig)
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = layer.Close() })
+
+	// Echo a short message back on each accepted connection, so the test can
+	// determine whether the connection was accepted.
+	go func() {
+		for {
+			conn, err := layer.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, len(echoMsg))
+				if _, err := io.ReadFull(conn, buf); err == nil {
+					_, _ = conn.Write(buf)
+				}
+			}()
+		}
+	}()
+
+	addr := hraft.ServerAddress(layer.Addr().String())
+
+	echo := func(conn net.Conn) error {
+		defer conn.Close()
+		must.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+		if _, err := conn.Write(echoMsg); err != nil {
+			return err
+		}
+		_, err := io.ReadFull(conn, make([]byte, len(echoMsg)))
+		return err
+	}
+
+	// A peer holding a certificate signed by the cluster CA is accepted.
+	peerCertFile, peerKeyFile := ca.Issue(t, "attila-1")
+	peerConfig, err := NewTLSConfig(ca.CertFile, peerCertFile, peerKeyFile, "")
+	must.NoError(t, err)
+
+	peerLayer := tlsStreamLayer{tlsConfig: peerConfig}
+
+	conn, err := peerLayer.Dial(addr, time.Second)
+	must.NoError(t, err)
+	must.NoError(t, echo(conn))
+
+	// A peer which trusts the cluster CA, but presents a certificate signed by
+	// a different CA, is rejected.
+	otherCA := certs.NewCA(t)
+	otherCertFile, otherKeyFile := otherCA.Issue(t, "attila-2")
+	otherConfig, err := NewTLSConfig(ca.CertFile, otherCertFile, otherKeyFile, "")
+	must.NoError(t, err)
+
+	otherLayer := tlsStreamLayer{tlsConfig: otherConfig}
+
+	if conn, err := otherLayer.Dial(addr, time.Second); err == nil {
+		must.Error(t, echo(conn))
+	}
+
+	// A plaintext client is rejected.
+	conn, err = net.DialTimeout("tcp", string(addr), time.Second)
+	must.NoError(t, err)
+	must.Error(t, echo(conn))
+}
@@ -3,13 +3,18 @@
 
 package store
 
-import "github.com/rasorp/attila/internal/domain"
+import (
+	"time"
+
+	"github.com/rasorp/attila/internal/domain"
+)
 
 type RegionState interface {
 	Create(*RegionCreateReq) (*RegionCreateResp, *ErrorResp)
 	Delete(*RegionDeleteReq) (*RegionDeleteResp, *ErrorResp)
 	Get(*RegionGetReq) (*RegionGetResp, *ErrorResp)
 	List(*RegionListReq) (*RegionListResp, *ErrorResp)
+	Update(*RegionUpdateReq) (*RegionUpdateResp, *ErrorResp)
 }
 
 type RegionCreateReq struct {
@@ -22,20 +27,50 @@ type RegionCreateResp struct {
 
 type RegionDeleteReq struct {
 	RegionName string
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64
 }
 
 type RegionDeleteResp struct{}
 
 type RegionGetReq struct {
 	RegionName string
+
+	QueryOptions `json:"-"`
 }
 
 type RegionGetResp struct {
 	Region *domain.Region `json:"region"`
+
+	QueryMeta `json:"-"`
 }
 
-type RegionListReq struct{}
+type RegionListReq struct {
+	QueryOptions `json:"-"`
+}
 
 type RegionListResp struct {
 	Regions []*domain.Region `json:"regions"`
+
+	QueryMeta `json:"-"`
+}
+
+type RegionUpdateReq struct {
+	Region *domain.Region
+
+	// CheckIndex is an optional modify index which must match that of the
+	// existing object for the write to be performed.
+	CheckIndex *uint64
+
+	// UpdateTime is an optional time to record as the update time of the
+	// object. When zero, the current time is used. Replicated backends set
+	// this before the write is replicated, so every server records the same
+	// value.
+	UpdateTime time.Time
+}
+
+type RegionUpdateResp struct {
+	Region *domain.Region `json:"region"`
 }
@@ -4,7 +4,30 @@
 package store
 
 type State interface {
+	ACL() ACLState
 	JobRegister() JobRegisterState
 	Region() RegionState
 	Name() string
 }
+
+// Replicated is implemented by state backends which replicate state across
+// multiple Attila servers. Only the leader accepts writes and runs the
+// background controllers, so callers use this interface to route requests and
+// react to leadership changes.
+type Replicated interface {
+
+	// IsLeader returns whether this server is the current cluster leader.
+	IsLeader() bool
+
+	// LeaderHTTPAddress returns the HTTP API address of the current leader.
+	// An empty string indicates there is no known leader.
+	LeaderHTTPAddress() string
+
+	// LeaderCh returns a channel which receives true when this server gains
+	// leadership and false when it loses it. The channel is closed once the
+	// backend is shutdown.
+	LeaderCh() <-chan bool
+
+	// Shutdown stops replication and releases all resources.
+	Shutdown() error
+}
@@ -0,0 +1,80 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import "fmt"
+
+// Config is the tracing configuration block. When enabled, spans are exported
+// to an OpenTelemetry collector using OTLP over HTTP.
+type Config struct {
+	Enable *bool `hcl:"enabled,optional"`
+
+	// Endpoint is the host and port of the OTLP HTTP receiver, such as
+	// "localhost:4318". If not set, the standard OTEL_EXPORTER_OTLP_ENDPOINT
+	// environment variable is used, falling back to "localhost:4318".
+	Endpoint string `hcl:"endpoint,optional"`
+
+	// Insecure disables TLS when exporting spans to the endpoint.
+	Insecure *bool `hcl:"insecure,optional"`
+
+	// SampleRatio is the fraction of traces sampled, between 0 and 1. Requests
+	// which include a sampled parent trace are always sampled.
+	SampleRatio *float64 `hcl:"sample_ratio,optional"`
+}
+
+// DefaultConfig returns the default tracing config, which has tracing
+// disabled.
+func DefaultConfig() *Config {
+	return &Config{
+		Enable:      new(false),
+		Insecure:    new(false),
+		SampleRatio: new(1.0),
+	}
+}
+
+// Enabled is a helper function that informs the caller if tracing is enabled.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Enable != nil && *c.Enable
+}
+
+// Validate performs validation of the tracing configuration block. The
+// function can be called safely without checking if the object is nil.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.SampleRatio != nil && (*c.SampleRatio < 0 || *c.SampleRatio > 1) {
+		return fmt.Errorf("tracing sample_ratio must be between 0 and 1, got %v", *c.SampleRatio)
+	}
+
+	return nil
+}
+
+func (c *Config) Merge(z *Config) *Config {
+
+	if c == nil {
+		return z
+	}
+	if z == nil {
+		return c
+	}
+
+	result := *c
+
+	if z.Enable != nil {
+		result.Enable = z.Enable
+	}
+	if z.Endpoint != "" {
+		result.Endpoint = z.Endpoint
+	}
+	if z.Insecure != nil {
+		result.Insecure = z.Insecure
+	}
+	if z.SampleRatio != nil {
+		result.SampleRatio = z.SampleRatio
+	}
+
+	return &result
+}
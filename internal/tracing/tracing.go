@@ -0,0 +1,99 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing configures OpenTelemetry tracing for the Attila server and
+// provides helpers for creating spans. Spans are always created using the
+// global tracer provider, which is a no-op unless Setup has enabled tracing, so
+// instrumented code does not need to check whether tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rasorp/attila/internal/version"
+)
+
+const (
+	serviceName = "attila"
+	tracerName  = "github.com/rasorp/attila"
+)
+
+// Propagator is used to read and write the trace context of HTTP requests. It
+// uses the W3C trace context headers, which the API client also sends.
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Tracer returns the tracer used to create all Attila spans.
+func Tracer() trace.Tracer { return otel.Tracer(tracerName) }
+
+// Start creates a span as a child of any span within the context. It is a
+// small wrapper around the tracer, so callers do not need to import it.
+func Start(ctx context.Context, name string, attrs ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, attrs...)
+}
+
+// End records the error against the span, if it is not nil, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup configures the global tracer provider from the passed config. The
+// returned function flushes and stops the provider, so should be called when
+// the server shuts down. When tracing is disabled, the global provider is left
+// as the no-op default and the returned function does nothing.
+func Setup(cfg *Config) (func(context.Context) error, error) {
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure != nil && *cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup OTLP exporter: %w", err)
+	}
+
+	sampleRatio := 1.0
+	if cfg.SampleRatio != nil {
+		sampleRatio = *cfg.SampleRatio
+	}
+
+	provider := NewProvider(sdktrace.WithBatcher(exporter), sdktrace.WithSampler(
+		sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))))
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// NewProvider returns a tracer provider which identifies its spans as coming
+// from the Attila service. The options configure the exporter and sampler;
+// tests use this with an in-memory exporter to inspect the created spans.
+func NewProvider(opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Get()),
+	)
+	return sdktrace.NewTracerProvider(append([]sdktrace.TracerProviderOption{sdktrace.WithResource(res)}, opts...)...)
+}
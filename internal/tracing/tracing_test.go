@@ -0,0 +1,97 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		inputConfig   *Config
+		expectedError bool
+	}{
+		{
+			name:        "nil",
+			inputConfig: nil,
+		},
+		{
+			name:        "default",
+			inputConfig: DefaultConfig(),
+		},
+		{
+			name:        "disabled ignores invalid ratio",
+			inputConfig: &Config{Enable: new(false), SampleRatio: new(2.0)},
+		},
+		{
+			name:        "enabled",
+			inputConfig: &Config{Enable: new(true), Endpoint: "localhost:4318", SampleRatio: new(0.5)},
+		},
+		{
+			name:          "enabled invalid ratio",
+			inputConfig:   &Config{Enable: new(true), SampleRatio: new(-0.1)},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedError {
+				must.Error(t, tc.inputConfig.Validate())
+			} else {
+				must.NoError(t, tc.inputConfig.Validate())
+			}
+		})
+	}
+}
+
+func TestConfig_Merge(t *testing.T) {
+	cfg := DefaultConfig().Merge(&Config{Enable: new(true), Endpoint: "collector:4318"})
+
+	must.True(t, cfg.Enabled())
+	must.Eq(t, "collector:4318", cfg.Endpoint)
+	must.False(t, *cfg.Insecure)
+	must.Eq(t, 1.0, *cfg.SampleRatio)
+
+	must.Eq(t, cfg, cfg.Merge(nil))
+}
+
+func TestSetup_disabled(t *testing.T) {
+	shutdown, err := Setup(DefaultConfig())
+	must.NoError(t, err)
+	must.NoError(t, shutdown(context.Background()))
+}
+
+func TestEnd(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("failed"))
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	must.Len(t, 2, spans)
+
+	must.Eq(t, "child", spans[0].Name)
+	must.Eq(t, codes.Error, spans[0].Status.Code)
+	must.Eq(t, "failed", spans[0].Status.Description)
+	must.Eq(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+
+	must.Eq(t, "parent", spans[1].Name)
+	must.Eq(t, codes.Unset, spans[1].Status.Code)
+}
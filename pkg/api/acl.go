@@ -0,0 +1,300 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	ACLTokenTypeManagement = "management"
+	ACLTokenTypeClient     = "client"
+
+	ACLAccessDeny  = "deny"
+	ACLAccessRead  = "read"
+	ACLAccessWrite = "write"
+)
+
+type ACLPolicy struct {
+	Name        string    `hcl:"name" json:"name"`
+	Description string    `hcl:"description,optional" json:"description"`
+	Region      string    `hcl:"region,optional" json:"region"`
+	Method      string    `hcl:"method,optional" json:"method"`
+	Rule        string    `hcl:"rule,optional" json:"rule"`
+	Plan        string    `hcl:"plan,optional" json:"plan"`
+	Topology    string    `hcl:"topology,optional" json:"topology"`
+	Job         string    `hcl:"job,optional" json:"job"`
+	Metadata    *Metadata `hcl:"metadata,optional" json:"metadata"`
+}
+
+type ACLPolicyStub struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ACLPolicyCreateResp struct {
+	Policy *ACLPolicy `json:"policy"`
+}
+
+type ACLPolicyGetResp struct {
+	Policy *ACLPolicy `json:"policy"`
+}
+
+type ACLPolicyListResp struct {
+	Policies []*ACLPolicyStub `json:"policies"`
+}
+
+type ACLPolicyUpdateResp struct {
+	Policy *ACLPolicy `json:"policy"`
+}
+
+type ACLToken struct {
+	AccessorID string    `json:"accessor_id"`
+	SecretID   string    `json:"secret_id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Policies   []string  `json:"policies"`
+	Metadata   *Metadata `json:"metadata"`
+}
+
+type ACLTokenStub struct {
+	AccessorID string   `json:"accessor_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Policies   []string `json:"policies"`
+}
+
+type ACLBootstrapResp struct {
+	Token *ACLToken `json:"token"`
+}
+
+type ACLTokenCreateReq struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Policies []string `json:"policies"`
+}
+
+type ACLTokenCreateResp struct {
+	Token *ACLToken `json:"token"`
+}
+
+type ACLTokenGetResp struct {
+	Token *ACLToken `json:"token"`
+}
+
+type ACLTokenListResp struct {
+	Tokens []*ACLTokenStub `json:"tokens"`
+}
+
+type ACL struct {
+	client *Client
+}
+
+func (c *Client) ACL() *ACL {
+	return &ACL{client: c}
+}
+
+// Bootstrap creates the initial management token. It can only succeed once, as
+// the server rejects the request if any token already exists.
+func (a *ACL) Bootstrap(ctx context.Context) (*ACLBootstrapResp, *Response, error) {
+
+	var bootstrapResp ACLBootstrapResp
+
+	req, err := a.client.NewRequest(http.MethodPost, "/v1alpha1/acl/bootstrap", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &bootstrapResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &bootstrapResp, resp, nil
+}
+
+type ACLPolicies struct {
+	client *Client
+}
+
+func (c *Client) ACLPolicies() *ACLPolicies {
+	return &ACLPolicies{client: c}
+}
+
+func (a *ACLPolicies) Create(ctx context.Context, policy *ACLPolicy) (*ACLPolicyCreateResp, *Response, error) {
+
+	var policyCreateResp ACLPolicyCreateResp
+
+	req, err := a.client.NewRequest(http.MethodPost, "/v1alpha1/acl/policies", policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &policyCreateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &policyCreateResp, resp, nil
+}
+
+func (a *ACLPolicies) Delete(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
+
+	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/acl/policies/"+name, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (a *ACLPolicies) Get(ctx context.Context, name string, opts ...RequestOption) (*ACLPolicyGetResp, *Response, error) {
+
+	var policyGetResp ACLPolicyGetResp
+
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/acl/policies/"+name, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &policyGetResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &policyGetResp, resp, nil
+}
+
+func (a *ACLPolicies) List(ctx context.Context, opts ...RequestOption) (*ACLPolicyListResp, *Response, error) {
+
+	var policyListResp ACLPolicyListResp
+
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/acl/policies", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &policyListResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &policyListResp, resp, nil
+}
+
+func (a *ACLPolicies) Update(
+	ctx context.Context, policy *ACLPolicy, opts ...RequestOption) (*ACLPolicyUpdateResp, *Response, error) {
+
+	var policyUpdateResp ACLPolicyUpdateResp
+
+	req, err := a.client.NewRequest(http.MethodPut, "/v1alpha1/acl/policies/"+policy.Name, policy, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &policyUpdateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &policyUpdateResp, resp, nil
+}
+
+type ACLTokens struct {
+	client *Client
+}
+
+func (c *Client) ACLTokens() *ACLTokens {
+	return &ACLTokens{client: c}
+}
+
+func (a *ACLTokens) Create(ctx context.Context, req *ACLTokenCreateReq) (*ACLTokenCreateResp, *Response, error) {
+
+	var tokenCreateResp ACLTokenCreateResp
+
+	httpReq, err := a.client.NewRequest(http.MethodPost, "/v1alpha1/acl/tokens", req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, httpReq, &tokenCreateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &tokenCreateResp, resp, nil
+}
+
+func (a *ACLTokens) Delete(ctx context.Context, accessorID string, opts ...RequestOption) (*Response, error) {
+
+	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/acl/tokens/"+accessorID, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func (a *ACLTokens) Get(ctx context.Context, accessorID string, opts ...RequestOption) (*ACLTokenGetResp, *Response, error) {
+
+	var tokenGetResp ACLTokenGetResp
+
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/acl/tokens/"+accessorID, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &tokenGetResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &tokenGetResp, resp, nil
+}
+
+func (a *ACLTokens) List(ctx context.Context, opts ...RequestOption) (*ACLTokenListResp, *Response, error) {
+
+	var tokenListResp ACLTokenListResp
+
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/acl/tokens", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &tokenListResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &tokenListResp, resp, nil
+}
+
+// Self returns the token used to authenticate the request.
+func (a *ACLTokens) Self(ctx context.Context, opts ...RequestOption) (*ACLTokenGetResp, *Response, error) {
+
+	var tokenGetResp ACLTokenGetResp
+
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/acl/tokens/self", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &tokenGetResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &tokenGetResp, resp, nil
+}
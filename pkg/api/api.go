@@ -6,11 +6,19 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -19,6 +27,14 @@ const (
 	defaultUserAgent = "go-attila" + "/" + Version
 
 	defaultAddress = "http://127.0.0.1:8080"
+
+	// HeaderIndex is the response header which details the index of the data
+	// returned by a read request.
+	HeaderIndex = "X-Attila-Index"
+
+	// HeaderToken is the request header used to send the secret ID of the ACL
+	// token which authenticates the request.
+	HeaderToken = "X-Attila-Token"
 )
 
 type ResponseError struct {
@@ -42,10 +58,72 @@ type Response struct {
 	*http.Response
 }
 
+// Index returns the index of the data returned by a read request, which can be
+// passed to WithIndex to perform a blocking query. Zero is returned if the
+// response does not include an index.
+func (r *Response) Index() uint64 {
+	if r == nil || r.Response == nil {
+		return 0
+	}
+	index, _ := strconv.ParseUint(r.Header.Get(HeaderIndex), 10, 64)
+	return index
+}
+
 type Config struct {
 	Address    string
 	HTTPClient *http.Client
 	UserAgent  string
+
+	// Token is the secret ID of the ACL token sent with every request. It is
+	// only required when the server has ACLs enabled.
+	Token string
+
+	// TLS configures the client when communicating with an HTTPS server. It is
+	// ignored when HTTPClient is set, as the caller controls the transport.
+	TLS *TLSConfig
+}
+
+// TLSConfig details the files used to secure requests to an HTTPS server. All
+// fields are optional and hold paths to PEM encoded files.
+type TLSConfig struct {
+
+	// CACert is the CA used to verify the server certificate. If not set, the
+	// system roots are used.
+	CACert string
+
+	// ClientCert and ClientKey are presented to the server, which is required
+	// when it verifies client certificates.
+	ClientCert string
+	ClientKey  string
+}
+
+// tlsConfig builds the crypto/tls configuration from the config files.
+func (t *TLSConfig) tlsConfig() (*tls.Config, error) {
+
+	cfg := tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.CACert != "" {
+		caBytes, err := os.ReadFile(t.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA cert: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, errors.New("failed to parse CA cert: no certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if t.ClientCert != "" || t.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(t.ClientCert, t.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client cert: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return &cfg, nil
 }
 
 func DefaultConfig() *Config {
@@ -59,6 +137,12 @@ type Client struct {
 	client    *http.Client
 	address   string
 	userAgent string
+	token     string
+
+	// configErr is any error encountered while building the client from its
+	// config. It is returned by every request, so NewClient does not need to
+	// return an error.
+	configErr error
 }
 
 func NewClient(cfg *Config) *Client {
@@ -68,7 +152,19 @@ func NewClient(cfg *Config) *Client {
 		address = defaultAddress
 	}
 
+	var configErr error
+
 	httpClient := cfg.HTTPClient
+	if httpClient == nil && cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.tlsConfig()
+		if err != nil {
+			configErr = fmt.Errorf("failed to configure TLS: %w", err)
+		} else {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = tlsConfig
+			httpClient = &http.Client{Transport: transport}
+		}
+	}
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -82,11 +178,66 @@ func NewClient(cfg *Config) *Client {
 		address:   address,
 		client:    httpClient,
 		userAgent: userAgent,
+		token:     cfg.Token,
+		configErr: configErr,
 	}
 }
 
 type RequestOption func(req *http.Request)
 
+// WithCheckIndex sets the check-and-set index on a write request. The write
+// will only be performed if the index matches the modify index of the existing
+// object, otherwise the server responds with a conflict error.
+func WithCheckIndex(index uint64) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Set("check_index", strconv.FormatUint(index, 10))
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithIndex turns a read request into a blocking query. The server will not
+// respond until the index of the requested data is greater than the passed
+// index, or the wait time is reached. The index is usually obtained from the
+// Index of a previous response.
+func WithIndex(index uint64) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Set("index", strconv.FormatUint(index, 10))
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithWait sets the maximum time a blocking query waits for a change before the
+// server responds. It has no effect unless used alongside WithIndex. If not
+// set, the server uses its default wait time.
+func WithWait(wait time.Duration) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Set("wait", wait.String())
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithSecrets requests that secret fields are included within the response,
+// rather than being redacted. The server rejects the request unless the caller
+// holds a management token.
+func WithSecrets() RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Set("secrets", "true")
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithToken overrides the ACL token sent with the request, which allows a
+// single client to perform requests on behalf of different tokens.
+func WithToken(token string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(HeaderToken, token)
+	}
+}
+
 func (c *Client) NewRequest(method, path string, body any, opts ...RequestOption) (*http.Request, error) {
 
 	if !strings.HasPrefix(path, "/") {
@@ -118,6 +269,10 @@ func (c *Client) NewRequest(method, path string, body any, opts ...RequestOption
 
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if c.token != "" {
+		req.Header.Set(HeaderToken, c.token)
+	}
+
 	for _, opt := range opts {
 		opt(req)
 	}
@@ -154,6 +309,15 @@ func (c *Client) bareDo(ctx context.Context, req *http.Request) (*Response, erro
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	// Attach the context to the request, so blocking queries can be abandoned
+	// by the caller. Any trace within the context is propagated using the W3C
+	// trace context headers, so server spans join the caller's trace.
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := c.client.Do(req)
 	if err != nil {
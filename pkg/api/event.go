@@ -0,0 +1,149 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	EventTopicRegion            = "Region"
+	EventTopicJobRegisterMethod = "JobRegisterMethod"
+	EventTopicJobRegisterRule   = "JobRegisterRule"
+	EventTopicJobRegisterPlan   = "JobRegisterPlan"
+	EventTopicJobRegisterRun    = "JobRegisterRun"
+	EventTopicAll               = "*"
+
+	EventTypeCreated  = "Created"
+	EventTypeUpdated  = "Updated"
+	EventTypeDeleted  = "Deleted"
+	EventTypeFinished = "Finished"
+	EventTypeProgress = "Progress"
+)
+
+type Event struct {
+	Topic   string          `json:"topic"`
+	Type    string          `json:"type"`
+	Key     string          `json:"key"`
+	Time    time.Time       `json:"time"`
+	Index   uint64          `json:"index"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// DecodePayload decodes the event payload into the passed object, which should
+// match the event topic. For example, a JobRegisterPlan event payload can be
+// decoded into a JobRegisterPlan.
+func (e *Event) DecodePayload(obj any) error {
+	if len(e.Payload) == 0 {
+		return errors.New("event has no payload")
+	}
+	return json.Unmarshal(e.Payload, obj)
+}
+
+type EventStreamReq struct {
+
+	// Topics maps each topic to the keys the caller is interested in. A topic
+	// with no keys matches all keys. If empty, all events are streamed.
+	Topics map[string][]string
+}
+
+// EventStreamMsg is received for each event within the stream. If the stream
+// ends unexpectedly, a final message containing the error is received before
+// the channel is closed.
+type EventStreamMsg struct {
+	Event *Event
+	Err   error
+}
+
+type Events struct {
+	client *Client
+}
+
+func (c *Client) Events() *Events {
+	return &Events{client: c}
+}
+
+// Stream subscribes to the event stream. It returns once the server has
+// established the subscription, so events published afterwards are received.
+// Events are received on the returned channel until the context is cancelled or
+// the stream ends, at which point the channel is closed.
+func (e *Events) Stream(ctx context.Context, req *EventStreamReq, opts ...RequestOption) (<-chan *EventStreamMsg, error) {
+
+	query := url.Values{}
+
+	if req != nil {
+		for topic, keys := range req.Topics {
+			if len(keys) == 0 {
+				query.Add("topic", topic)
+			}
+			for _, key := range keys {
+				query.Add("topic", topic+":"+key)
+			}
+		}
+	}
+
+	path := "/v1alpha1/event/stream"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	httpReq, err := e.client.NewRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.client.bareDo(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	msgCh := make(chan *EventStreamMsg, 10)
+
+	go func() {
+		defer close(msgCh)
+		defer func(Body io.ReadCloser) { _ = Body.Close() }(resp.Body)
+
+		dec := json.NewDecoder(resp.Body)
+
+		for {
+			var event Event
+
+			if err := dec.Decode(&event); err != nil {
+				// A cancelled context is the caller ending the stream, so is
+				// not reported as an error.
+				if ctx.Err() != nil {
+					return
+				}
+				if errors.Is(err, io.EOF) {
+					err = errors.New("event stream closed by server")
+				}
+				select {
+				case msgCh <- &EventStreamMsg{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			// Heartbeats are sent as empty objects to keep the connection
+			// alive and are not passed to the caller.
+			if event.Topic == "" {
+				continue
+			}
+
+			select {
+			case msgCh <- &EventStreamMsg{Event: &event}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return msgCh, nil
+}
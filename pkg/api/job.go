@@ -0,0 +1,200 @@
+// Copyright James Rasell 2025, 2026
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// JobStop is the outcome of stopping a job within every region which holds
+// it.
+type JobStop struct {
+	ID           ulid.ULID                 `json:"id"`
+	JobID        string                    `json:"job_id"`
+	JobNamespace string                    `json:"job_namespace"`
+	Regions      map[string]*JobStopRegion `json:"regions"`
+	Purge        bool                      `json:"purge"`
+	Plan         bool                      `json:"plan"`
+	Error        string                    `json:"error,omitempty"`
+	Metadata     *Metadata                 `json:"metadata"`
+}
+
+type JobStopRegion struct {
+	Region     string  `json:"region"`
+	JobVersion *uint64 `json:"job_version,omitempty"`
+	JobStatus  string  `json:"job_status"`
+	EvalID     string  `json:"eval_id,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type JobStopReq struct {
+	Namespace string
+	ID        string
+
+	// Purge removes the job from each region, rather than leaving it to be
+	// garbage collected by Nomad.
+	Purge bool
+
+	// Plan only finds the regions which hold the job, without stopping it.
+	Plan bool
+}
+
+type JobStopResp struct {
+	Stop *JobStop `json:"stop"`
+}
+
+type Jobs struct {
+	client *Client
+}
+
+func (c *Client) Jobs() *Jobs {
+	return &Jobs{client: c}
+}
+
+func (j *Jobs) Stop(ctx context.Context, req *JobStopReq, opts ...RequestOption) (*JobStopResp, *Response, error) {
+
+	var resp JobStopResp
+
+	query := url.Values{}
+
+	if req.Purge {
+		query.Set("purge", "true")
+	}
+	if req.Plan {
+		query.Set("plan", "true")
+	}
+
+	path := "/v1alpha1/jobs/" + url.PathEscape(req.Namespace) + "/" + url.PathEscape(req.ID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	httpReq, err := j.client.NewRequest(http.MethodDelete, path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := j.client.Do(ctx, httpReq, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, httpResp, nil
+}
+
+// JobStatus is the status of a job merged across every region Attila manages.
+type JobStatus struct {
+	JobID        string                      `json:"job_id"`
+	JobNamespace string                      `json:"job_namespace"`
+	Regions      map[string]*JobStatusRegion `json:"regions"`
+	RunID        *ulid.ULID                  `json:"run_id,omitempty"`
+}
+
+type JobStatusRegion struct {
+	Region           string         `json:"region"`
+	JobStatus        string         `json:"job_status,omitempty"`
+	JobVersion       *uint64        `json:"job_version,omitempty"`
+	Allocations      map[string]int `json:"allocations,omitempty"`
+	DeploymentID     string         `json:"deployment_id,omitempty"`
+	DeploymentStatus string         `json:"deployment_status,omitempty"`
+	Missing          bool           `json:"missing"`
+	Mismatch         bool           `json:"mismatch"`
+	Error            string         `json:"error,omitempty"`
+}
+
+type JobStatusReq struct {
+	Namespace string
+	ID        string
+}
+
+type JobStatusResp struct {
+	Status *JobStatus `json:"status"`
+}
+
+func (j *Jobs) Status(ctx context.Context, req *JobStatusReq, opts ...RequestOption) (*JobStatusResp, *Response, error) {
+
+	var resp JobStatusResp
+
+	path := "/v1alpha1/jobs/" + url.PathEscape(req.Namespace) + "/" + url.PathEscape(req.ID) + "/status"
+
+	httpReq, err := j.client.NewRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := j.client.Do(ctx, httpReq, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, httpResp, nil
+}
+
+const (
+	JobDriftStatusInSync  = "in_sync"
+	JobDriftStatusDrifted = "drifted"
+	JobDriftStatusUnknown = "unknown"
+)
+
+// JobDrift is the result of comparing a job registered by a successful run with
+// the job running within one of the run's regions.
+type JobDrift struct {
+	JobID        string    `json:"job_id"`
+	JobNamespace string    `json:"job_namespace"`
+	Region       string    `json:"region"`
+	RunID        ulid.ULID `json:"run_id"`
+	Status       string    `json:"status"`
+	Reason       string    `json:"reason,omitempty"`
+	JobVersion   *uint64   `json:"job_version,omitempty"`
+	JobSpecHash  string    `json:"job_spec_hash,omitempty"`
+	CheckTime    time.Time `json:"check_time"`
+	DriftTime    time.Time `json:"drift_time,omitzero"`
+}
+
+type JobDriftListReq struct {
+	JobID     string
+	Namespace string
+}
+
+type JobDriftListResp struct {
+	Drifts []*JobDrift `json:"drifts"`
+}
+
+func (j *Jobs) Drift(ctx context.Context, req *JobDriftListReq, opts ...RequestOption) (*JobDriftListResp, *Response, error) {
+
+	var resp JobDriftListResp
+
+	query := url.Values{}
+
+	if req != nil {
+		if req.JobID != "" {
+			query.Set("job_id", req.JobID)
+		}
+		if req.Namespace != "" {
+			query.Set("namespace", req.Namespace)
+		}
+	}
+
+	path := "/v1alpha1/jobs/drift"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	httpReq, err := j.client.NewRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := j.client.Do(ctx, httpReq, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, httpResp, nil
+}
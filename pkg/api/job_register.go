@@ -6,6 +6,8 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/oklog/ulid/v2"
@@ -64,6 +66,10 @@ type JobRegisterMethodGetResp struct {
 	Method *JobRegisterMethod `json:"method"`
 }
 
+type JobRegisterMethodUpdateResp struct {
+	Method *JobRegisterMethod `json:"method"`
+}
+
 type JobRegisterMethods struct {
 	client *Client
 }
@@ -90,9 +96,9 @@ func (a *JobRegisterMethods) Create(
 	return &regionCreateResp, resp, nil
 }
 
-func (a *JobRegisterMethods) Delete(ctx context.Context, name string) (*Response, error) {
+func (a *JobRegisterMethods) Delete(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
 
-	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/methods/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/methods/"+name, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +112,11 @@ func (a *JobRegisterMethods) Delete(ctx context.Context, name string) (*Response
 }
 
 func (a *JobRegisterMethods) Get(
-	ctx context.Context, name string) (*JobRegisterMethodGetResp, *Response, error) {
+	ctx context.Context, name string, opts ...RequestOption) (*JobRegisterMethodGetResp, *Response, error) {
 
 	var methodGetResp JobRegisterMethodGetResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/methods/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/methods/"+name, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -123,11 +129,11 @@ func (a *JobRegisterMethods) Get(
 	return &methodGetResp, resp, nil
 }
 
-func (a *JobRegisterMethods) List(ctx context.Context) (*JobRegisterMethodListResp, *Response, error) {
+func (a *JobRegisterMethods) List(ctx context.Context, opts ...RequestOption) (*JobRegisterMethodListResp, *Response, error) {
 
 	var methodListResp JobRegisterMethodListResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/methods", nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/methods", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -140,10 +146,29 @@ func (a *JobRegisterMethods) List(ctx context.Context) (*JobRegisterMethodListRe
 	return &methodListResp, resp, nil
 }
 
+func (a *JobRegisterMethods) Update(
+	ctx context.Context, method *JobRegisterMethod, opts ...RequestOption) (*JobRegisterMethodUpdateResp, *Response, error) {
+
+	var methodUpdateResp JobRegisterMethodUpdateResp
+
+	req, err := a.client.NewRequest(http.MethodPut, "/v1alpha1/jobs/register/methods/"+method.Name, method, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &methodUpdateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &methodUpdateResp, resp, nil
+}
+
 type JobRegisterRule struct {
 	Name           string                          `hcl:"name" json:"name"`
 	RegionContexts []*JobRegisterRuleRegionContext `hcl:"region_context,block" json:"region_contexts"`
 	RegionPickers  []*JobRegisterRegionPicker      `hcl:"region_picker,block" json:"region_pickers"`
+	Rollout        *JobRegisterRollout             `hcl:"rollout,block" json:"rollout,omitempty"`
 	Metadata       *Metadata                       `hcl:"metadata" json:"metadata"`
 }
 
@@ -185,6 +210,49 @@ type JobRegisterRegionPicker struct {
 	Config map[string]any `hcl:"config,block" json:"config,omitempty"`
 }
 
+// JobRegisterRollout controls the order in which regions are registered when a
+// job registration plan is run. Regions are split into stages, and the Nomad
+// deployments of each stage must succeed before the next stage is started.
+type JobRegisterRollout struct {
+
+	// Strategy determines how regions are split into stages. It is one of
+	// "all-at-once", "serial", "canary-then-rest", or "batched", and defaults
+	// to "all-at-once" if not set.
+	Strategy string `hcl:"strategy,optional" json:"strategy"`
+
+	// CanaryRegions is the number of regions within the first stage of the
+	// "canary-then-rest" strategy.
+	CanaryRegions int `hcl:"canary_regions,optional" json:"canary_regions,omitempty"`
+
+	// BatchSize is the number of regions within each stage of the "batched"
+	// strategy.
+	BatchSize int `hcl:"batch_size,optional" json:"batch_size,omitempty"`
+
+	// DeploymentTimeout is the maximum time a stage waits for its Nomad
+	// deployments to complete. It is parsed as a Go duration.
+	DeploymentTimeout string `hcl:"deployment_timeout,optional" json:"deployment_timeout,omitempty"`
+
+	// OnFailure controls what happens to the regions which were successfully
+	// registered when the run fails. It is either "none", the default, or
+	// "rollback", which reverts them to their prior job version.
+	OnFailure string `hcl:"on_failure,optional" json:"on_failure,omitempty"`
+
+	// WaitForHealthy causes the final stage to also wait for its Nomad
+	// deployments to succeed, so the run only succeeds once every region is
+	// healthy.
+	WaitForHealthy bool `hcl:"wait_for_healthy,optional" json:"wait_for_healthy,omitempty"`
+}
+
+const (
+	JobRegisterRolloutStrategyAllAtOnce      = "all-at-once"
+	JobRegisterRolloutStrategySerial         = "serial"
+	JobRegisterRolloutStrategyCanaryThenRest = "canary-then-rest"
+	JobRegisterRolloutStrategyBatched        = "batched"
+
+	JobRegisterRolloutOnFailureNone     = "none"
+	JobRegisterRolloutOnFailureRollback = "rollback"
+)
+
 type JobRegisterRuleStub struct {
 	Name           string                         `json:"name"`
 	RegionContexts []JobRegisterRuleRegionContext `json:"region_contexts"`
@@ -202,6 +270,10 @@ type JobRegisterRuleGetResp struct {
 	Rule *JobRegisterRule `json:"rule"`
 }
 
+type JobRegisterRuleUpdateResp struct {
+	Rule *JobRegisterRule `json:"rule"`
+}
+
 type JobRegisterRules struct {
 	client *Client
 }
@@ -228,9 +300,9 @@ func (a *JobRegisterRules) Create(
 	return &ruleCreateResp, resp, nil
 }
 
-func (a *JobRegisterRules) Delete(ctx context.Context, name string) (*Response, error) {
+func (a *JobRegisterRules) Delete(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
 
-	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/rules/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/rules/"+name, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -244,11 +316,11 @@ func (a *JobRegisterRules) Delete(ctx context.Context, name string) (*Response,
 }
 
 func (a *JobRegisterRules) Get(
-	ctx context.Context, name string) (*JobRegisterRuleGetResp, *Response, error) {
+	ctx context.Context, name string, opts ...RequestOption) (*JobRegisterRuleGetResp, *Response, error) {
 
 	var ruleGetResp JobRegisterRuleGetResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/rules/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/rules/"+name, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -261,11 +333,11 @@ func (a *JobRegisterRules) Get(
 	return &ruleGetResp, resp, nil
 }
 
-func (a *JobRegisterRules) List(ctx context.Context) (*JobRegisterRuleListResp, *Response, error) {
+func (a *JobRegisterRules) List(ctx context.Context, opts ...RequestOption) (*JobRegisterRuleListResp, *Response, error) {
 
 	var ruleListResp JobRegisterRuleListResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/rules", nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/rules", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -278,33 +350,126 @@ func (a *JobRegisterRules) List(ctx context.Context) (*JobRegisterRuleListResp,
 	return &ruleListResp, resp, nil
 }
 
+func (a *JobRegisterRules) Update(
+	ctx context.Context, rule *JobRegisterRule, opts ...RequestOption) (*JobRegisterRuleUpdateResp, *Response, error) {
+
+	var ruleUpdateResp JobRegisterRuleUpdateResp
+
+	req, err := a.client.NewRequest(http.MethodPut, "/v1alpha1/jobs/register/rules/"+rule.Name, rule, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, req, &ruleUpdateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &ruleUpdateResp, resp, nil
+}
+
 type JobRegisterPlan struct {
 	ID           ulid.ULID                         `json:"id"`
 	JobID        string                            `json:"job_id"`
 	JobNamespace string                            `json:"job_namespace"`
 	Regions      map[string]*JobRegisterRegionPlan `json:"regions"`
+	Rollout      *JobRegisterRollout               `json:"rollout,omitempty"`
+	Job          *api.Job                          `json:"job,omitempty"`
+	Submission   *api.JobSubmission                `json:"submission,omitempty"`
+	JobHash      string                            `json:"job_hash"`
+	ExpireTime   time.Time                         `json:"expire_time,omitzero"`
+	Metadata     *Metadata                         `json:"metadata"`
 }
 
 type JobRegisterRegionPlan struct {
-	Region string               `json:"region"`
-	Plan   *api.JobPlanResponse `json:"plan"`
+	Region     string                     `json:"region"`
+	Plan       *api.JobPlanResponse       `json:"plan"`
+	JobVersion *uint64                    `json:"job_version,omitempty"`
+	Topology   *JobRegisterRegionTopology `json:"topology,omitempty"`
+}
+
+// JobDiffTypeNone is the Nomad job diff type of a region where the planned
+// job matches the registered job.
+const JobDiffTypeNone = "None"
+
+// NoChange is a helper function that informs the caller if registering the
+// planned job would not change the job in the region. It is false if the plan
+// does not include a diff.
+func (j *JobRegisterRegionPlan) NoChange() bool {
+	return j.Plan != nil && j.Plan.Diff != nil && j.Plan.Diff.Type == JobDiffTypeNone
+}
+
+type JobRegisterRegionTopology struct {
+	NumClients        int   `json:"num_clients"`
+	CPUAllocatable    int64 `json:"cpu_allocatable"`
+	MemoryAllocatable int64 `json:"memory_allocatable"`
 }
 
 type JobRegisterPlanRun struct {
 	ID           ulid.ULID                            `json:"id"`
+	PlanID       ulid.ULID                            `json:"plan_id"`
 	JobID        string                               `json:"job_id"`
 	JobNamespace string                               `json:"job_namespace"`
 	Regions      map[string]*JobRegisterRegionPlanRun `json:"regions"`
+	Error        string                               `json:"error,omitempty"`
+	Metadata     *Metadata                            `json:"metadata"`
 }
 
 type JobRegisterRegionPlanRun struct {
-	Region string                   `json:"region"`
-	Run    *api.JobRegisterResponse `json:"run"`
-	Error  error                    `json:"error"`
+	Region   string                     `json:"region"`
+	Run      *api.JobRegisterResponse   `json:"run"`
+	Error    string                     `json:"error,omitempty"`
+	Rollback *JobRegisterRegionRollback `json:"rollback,omitempty"`
+	Health   *JobRegisterRegionHealth   `json:"health,omitempty"`
+}
+
+type JobRegisterRegionHealth struct {
+	Status       string `json:"status"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+const (
+	JobRegisterHealthStatusHealthy   = "healthy"
+	JobRegisterHealthStatusUnhealthy = "unhealthy"
+	JobRegisterHealthStatusTimeout   = "timeout"
+	JobRegisterHealthStatusUnknown   = "unknown"
+)
+
+// JobRegisterRegionProgress is the payload of the JobRegisterRun progress
+// events, which are published as each region moves through a run.
+type JobRegisterRegionProgress struct {
+	Region string `json:"region"`
+	Phase  string `json:"phase"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+const (
+	JobRegisterProgressPhaseRegister = "register"
+	JobRegisterProgressPhaseHealth   = "health"
+	JobRegisterProgressPhaseRollback = "rollback"
+)
+
+type JobRegisterRegionRollback struct {
+	Status     string                   `json:"status"`
+	JobVersion *uint64                  `json:"job_version,omitempty"`
+	Revert     *api.JobRegisterResponse `json:"revert,omitempty"`
+	Error      string                   `json:"error,omitempty"`
 }
 
+const (
+	JobRegisterRollbackStatusReverted     = "reverted"
+	JobRegisterRollbackStatusFailed       = "failed"
+	JobRegisterRollbackStatusDeregistered = "deregistered"
+)
+
 type JobRegisterPlanCreateReq struct {
 	Job *api.Job `json:"job"`
+
+	// Submission is the optional original source and variables of the job,
+	// which is stored with the plan.
+	Submission *api.JobSubmission `json:"submission,omitempty"`
 }
 
 type JobRegisterPlanCreateResp struct {
@@ -332,8 +497,15 @@ type JobRegisterPlanListResp struct {
 }
 
 type JobsRegisterPlanRunReq struct {
-	ID  ulid.ULID `json:"id"`
-	Job *api.Job  `json:"job"`
+	ID ulid.ULID `json:"id"`
+
+	// Job is the job to register, which must match the planned job. If it is
+	// not set, the job stored with the plan is registered.
+	Job *api.Job `json:"job,omitempty"`
+
+	// Rollout overrides the rollout of the plan, which is taken from the rules
+	// that picked its regions.
+	Rollout *JobRegisterRollout `json:"rollout,omitempty"`
 }
 
 type JobsRegisterPlanRunResp struct {
@@ -367,9 +539,9 @@ func (j *JobRegisterPlans) Create(
 	return &resp, httpResp, nil
 }
 
-func (j *JobRegisterPlans) Delete(ctx context.Context, req *JobRegisterPlanDeleteReq) (*Response, error) {
+func (j *JobRegisterPlans) Delete(ctx context.Context, req *JobRegisterPlanDeleteReq, opts ...RequestOption) (*Response, error) {
 
-	httpReq, err := j.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/plans/"+req.ID.String(), nil)
+	httpReq, err := j.client.NewRequest(http.MethodDelete, "/v1alpha1/jobs/register/plans/"+req.ID.String(), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -383,11 +555,11 @@ func (j *JobRegisterPlans) Delete(ctx context.Context, req *JobRegisterPlanDelet
 }
 
 func (j *JobRegisterPlans) Get(
-	ctx context.Context, req *JobRegisterPlanGetReq) (*JobRegisterPlanGetResp, *Response, error) {
+	ctx context.Context, req *JobRegisterPlanGetReq, opts ...RequestOption) (*JobRegisterPlanGetResp, *Response, error) {
 
 	var resp JobRegisterPlanGetResp
 
-	httpReq, err := j.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/plans/"+req.ID.String(), nil)
+	httpReq, err := j.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/plans/"+req.ID.String(), nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -401,11 +573,11 @@ func (j *JobRegisterPlans) Get(
 }
 
 func (j *JobRegisterPlans) List(
-	ctx context.Context, req *JobRegisterPlanListReq) (*JobRegisterPlanListResp, *Response, error) {
+	ctx context.Context, req *JobRegisterPlanListReq, opts ...RequestOption) (*JobRegisterPlanListResp, *Response, error) {
 
 	var resp JobRegisterPlanListResp
 
-	httpReq, err := j.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/plans", req)
+	httpReq, err := j.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/plans", req, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -437,3 +609,83 @@ func (j *JobRegisterPlans) Run(
 
 	return &resp, httpResp, nil
 }
+
+type JobRegisterRunGetReq struct {
+	ID ulid.ULID `json:"id"`
+}
+
+type JobRegisterRunGetResp struct {
+	Run *JobRegisterPlanRun `json:"run"`
+}
+
+type JobRegisterRunListReq struct {
+
+	// JobID and Namespace optionally filter the listed runs to those of the
+	// matching job.
+	JobID     string
+	Namespace string
+}
+
+type JobRegisterRunListResp struct {
+	Runs []*JobRegisterPlanRun `json:"runs"`
+}
+
+type JobRegisterRuns struct {
+	client *Client
+}
+
+func (c *Client) JobRegisterRuns() *JobRegisterRuns {
+	return &JobRegisterRuns{client: c}
+}
+
+func (j *JobRegisterRuns) Get(
+	ctx context.Context, req *JobRegisterRunGetReq, opts ...RequestOption) (*JobRegisterRunGetResp, *Response, error) {
+
+	var resp JobRegisterRunGetResp
+
+	httpReq, err := j.client.NewRequest(http.MethodGet, "/v1alpha1/jobs/register/runs/"+req.ID.String(), nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := j.client.Do(ctx, httpReq, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, httpResp, nil
+}
+
+func (j *JobRegisterRuns) List(
+	ctx context.Context, req *JobRegisterRunListReq, opts ...RequestOption) (*JobRegisterRunListResp, *Response, error) {
+
+	var resp JobRegisterRunListResp
+
+	query := url.Values{}
+
+	if req != nil {
+		if req.JobID != "" {
+			query.Set("job_id", req.JobID)
+		}
+		if req.Namespace != "" {
+			query.Set("namespace", req.Namespace)
+		}
+	}
+
+	path := "/v1alpha1/jobs/register/runs"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	httpReq, err := j.client.NewRequest(http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := j.client.Do(ctx, httpReq, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, httpResp, nil
+}
@@ -8,4 +8,8 @@ import "time"
 type Metadata struct {
 	CreateTime time.Time `json:"create_time"`
 	UpdateTime time.Time `json:"update_time"`
+
+	// ModifyIndex is the state index at which the object was last written and
+	// can be used with WithCheckIndex to perform check-and-set writes.
+	ModifyIndex uint64 `json:"modify_index"`
 }
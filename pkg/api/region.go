@@ -8,6 +8,11 @@ import (
 	"net/http"
 )
 
+// RegionRedactedValue replaces secret region fields within API responses. A
+// region can be written back containing this value, in which case the server
+// keeps the stored secret.
+const RegionRedactedValue = "<redacted>"
+
 type Region struct {
 	Name     string       `hcl:"name" json:"name"`
 	Group    string       `hcl:"group,optional" json:"group"`
@@ -51,10 +56,11 @@ func (a *Region) DefaultOrFirstAddress() string {
 }
 
 type RegionStub struct {
-	Name       string   `json:"name"`
-	Group      string   `json:"group"`
-	Addresses  []string `json:"addresses"`
-	TLSEnabled bool     `json:"tls_enabled"`
+	Name        string   `json:"name"`
+	Group       string   `json:"group"`
+	Addresses   []string `json:"addresses"`
+	AuthEnabled bool     `json:"auth_enabled"`
+	TLSEnabled  bool     `json:"tls_enabled"`
 }
 
 type RegionCreateReq struct {
@@ -73,6 +79,14 @@ type RegionGetResp struct {
 	Region *Region `json:"region"`
 }
 
+type RegionUpdateReq struct {
+	Region *Region `json:"region"`
+}
+
+type RegionUpdateResp struct {
+	Region *Region `json:"region"`
+}
+
 type Regions struct {
 	client *Client
 }
@@ -98,9 +112,9 @@ func (a *Regions) Create(ctx context.Context, req *RegionCreateReq) (*RegionCrea
 	return &regionCreateResp, resp, nil
 }
 
-func (a *Regions) Delete(ctx context.Context, name string) (*Response, error) {
+func (a *Regions) Delete(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
 
-	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/regions/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodDelete, "/v1alpha1/regions/"+name, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,11 +127,11 @@ func (a *Regions) Delete(ctx context.Context, name string) (*Response, error) {
 	return resp, nil
 }
 
-func (a *Regions) Get(ctx context.Context, name string) (*RegionGetResp, *Response, error) {
+func (a *Regions) Get(ctx context.Context, name string, opts ...RequestOption) (*RegionGetResp, *Response, error) {
 
 	var regionGetResp RegionGetResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/regions/"+name, nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/regions/"+name, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -130,11 +144,11 @@ func (a *Regions) Get(ctx context.Context, name string) (*RegionGetResp, *Respon
 	return &regionGetResp, resp, nil
 }
 
-func (a *Regions) List(ctx context.Context) (*RegionListResp, *Response, error) {
+func (a *Regions) List(ctx context.Context, opts ...RequestOption) (*RegionListResp, *Response, error) {
 
 	var regionListResp RegionListResp
 
-	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/regions", nil)
+	req, err := a.client.NewRequest(http.MethodGet, "/v1alpha1/regions", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -146,3 +160,20 @@ func (a *Regions) List(ctx context.Context) (*RegionListResp, *Response, error)
 
 	return &regionListResp, resp, nil
 }
+
+func (a *Regions) Update(ctx context.Context, req *RegionUpdateReq, opts ...RequestOption) (*RegionUpdateResp, *Response, error) {
+
+	var regionUpdateResp RegionUpdateResp
+
+	httpReq, err := a.client.NewRequest(http.MethodPut, "/v1alpha1/regions/"+req.Region.Name, req, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := a.client.Do(ctx, httpReq, &regionUpdateResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &regionUpdateResp, resp, nil
+}
@@ -122,11 +122,11 @@ func (c *Client) Topologies() *Topologies {
 	return &Topologies{client: c}
 }
 
-func (t *Topologies) Get(ctx context.Context, req *TopologiesGetReq) (*TopologiesGetResp, *Response, error) {
+func (t *Topologies) Get(ctx context.Context, req *TopologiesGetReq, opts ...RequestOption) (*TopologiesGetResp, *Response, error) {
 
 	var resp TopologiesGetResp
 
-	httpReq, err := t.client.NewRequest(http.MethodGet, "/v1alpha1/topologies/"+req.RegionName, nil)
+	httpReq, err := t.client.NewRequest(http.MethodGet, "/v1alpha1/topologies/"+req.RegionName, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -139,11 +139,11 @@ func (t *Topologies) Get(ctx context.Context, req *TopologiesGetReq) (*Topologie
 	return &resp, httpResp, nil
 }
 
-func (t *Topologies) List(ctx context.Context, _ *TopologiesListReq) (*TopologiesListResp, *Response, error) {
+func (t *Topologies) List(ctx context.Context, _ *TopologiesListReq, opts ...RequestOption) (*TopologiesListResp, *Response, error) {
 
 	var resp TopologiesListResp
 
-	httpReq, err := t.client.NewRequest(http.MethodGet, "/v1alpha1/topologies", nil)
+	httpReq, err := t.client.NewRequest(http.MethodGet, "/v1alpha1/topologies", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}